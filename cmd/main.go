@@ -9,10 +9,16 @@ import (
 	"backend/internal/models/book"
 	"backend/internal/models/cafe"
 	"backend/internal/models/hotel"
+	"backend/internal/models/scheduler"
 	"backend/internal/models/souvenir"
+	"backend/internal/models/tax"
 	"backend/internal/repository/admin"
+	"backend/internal/repository/guest"
+	"backend/internal/repository/reposcheduler"
+	"backend/internal/service/schedulerservice"
 	"backend/internal/service/serviceauth"
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -31,29 +37,65 @@ func main() {
 	db := config.InitDB()
 
 	// === MIGRASI & SEED ===
+	// Akun tamu dipindah dari admins ke guests sebelum FK bookings/ulasan dibuat
+	if err := migrateGuests(db); err != nil {
+		log.Fatalf("Migrasi guest gagal: %v", err)
+	}
+	legacyPaid := needsLegacyPaidBackfill(db)
+	legacyHolds := needsLegacyHoldBackfill(db)
+	redemptionReservations := needsRedemptionReservationBackfill(db)
+	occupancy := pendingOccupancyMigration(db)
 	if err := db.AutoMigrate(
-		&auth.Admin{},&hotel.RoomType{},
+		&auth.Admin{}, &auth.Guest{}, &hotel.RoomType{},
 		&hotel.Room{}, &hotel.Gallery{}, &hotel.News{}, &hotel.VisionMission{},
 		&souvenir.Product{}, &souvenir.Category{},
 		&book.ProductBook{}, &book.CategoryBook{},
 		&cafe.ProductCafe{}, &cafe.CategoryCafe{},
-		&hotel.GuestReview{}, &hotel.Booking{},
+		&hotel.GuestReview{}, &hotel.Reservation{}, &hotel.Booking{}, &hotel.Payment{},
+		&hotel.RatePlan{}, &hotel.RateOverride{}, &hotel.BookingNight{}, &hotel.StayRestriction{},
+		&hotel.PromoCode{}, &hotel.PromoRedemption{}, &hotel.BookingChange{},
+		&hotel.CancellationPolicy{}, &hotel.ICalFeed{},
+		&hotel.HousekeepingTask{}, &hotel.WaitlistEntry{},
+		&hotel.AddOn{}, &hotel.BookingAddOn{}, &hotel.Folio{}, &hotel.FolioEntry{},
+		&hotel.MaintenanceBlock{},
+		&tax.TaxRate{}, &tax.TaxLine{},
+		&scheduler.JobLease{}, &scheduler.JobRun{},
 	); err != nil {
 		log.Fatalf("AutoMigrate failed: %v", err)
 	}
-
-	// Tambahkan kolom user_id
-	if !db.Migrator().HasColumn(&hotel.Booking{}, "user_id") {
-		db.Exec("ALTER TABLE bookings ADD COLUMN user_id BIGINT NOT NULL DEFAULT 1")
-		db.Exec("ALTER TABLE bookings ADD CONSTRAINT fk_bookings_user FOREIGN KEY (user_id) REFERENCES admins(id)")
-		log.Println("Kolom user_id ditambahkan ke tabel bookings")
+	if legacyPaid {
+		if err := backfillLegacyPaid(db); err != nil {
+			log.Fatalf("Migrasi pembayaran lama gagal: %v", err)
+		}
+	}
+	if legacyHolds {
+		if err := backfillLegacyHolds(db); err != nil {
+			log.Fatalf("Migrasi hold booking lama gagal: %v", err)
+		}
+	}
+	if redemptionReservations {
+		if err := backfillRedemptionReservations(db); err != nil {
+			log.Fatalf("Migrasi reservasi pemakaian promo gagal: %v", err)
+		}
+	}
+	if err := backfillOccupancy(db, occupancy); err != nil {
+		log.Fatalf("Migrasi okupansi tipe kamar gagal: %v", err)
 	}
 
 	seedSuperAdmin(db)
 
 	// === REPO & SERVICE ===
 	adminRepo := admin.NewAdminRepository(db)
-	adminService := serviceauth.NewAdminService(adminRepo, config.GetConfig().JWTSecret)
+	adminService := serviceauth.NewAdminService(adminRepo, guest.NewGuestRepository(db), config.GetConfig().JWTSecret)
+	services, err := handler.NewServices(db)
+	if err != nil {
+		log.Fatalf("Inisialisasi service gagal: %v", err)
+	}
+
+	// === BACKGROUND JOB ===
+	// Lease di database memastikan tiap job hanya jalan di satu replica
+	sched := schedulerservice.NewScheduler(reposcheduler.NewSchedulerRepository(db))
+	registerJobs(sched, services)
 
 	// === GIN SETUP ===
 	r := gin.Default()
@@ -69,7 +111,7 @@ func main() {
 	r.MaxMultipartMemory = 8 << 20
 
 	// === ROUTES ===
-	handler.SetupRoutes(r, adminService, db)
+	handler.SetupRoutes(r, adminService, services, sched, db)
 
 	// === STATIC ===
 	if err := os.MkdirAll("uploads", os.ModePerm); err != nil {
@@ -90,8 +132,7 @@ func main() {
 		}
 	}()
 
-	// === BACKGROUND JOB ===
-	go startAutoCheckout(db)
+	sched.Start()
 
 	// === GRACEFUL SHUTDOWN ===
 	quit := make(chan os.Signal, 1)
@@ -106,66 +147,75 @@ func main() {
 	} else {
 		log.Println("Server gracefully stopped")
 	}
+	if err := sched.Stop(ctx); err != nil {
+		log.Printf("%v", err)
+	}
 }
 
-// === BACKGROUND JOB: Auto Checkout & Check-in ===
-func startAutoCheckout(db *gorm.DB) {
-	ticker := time.NewTicker(1 * time.Hour)
-	defer ticker.Stop()
-
-	log.Println("Background job: Auto Checkout dimulai")
-
-// cmd/main.go → di dalam startAutoCheckout
-for {
-	select {
-	case <-ticker.C:
-		now := time.Now()
-		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-
-		tx := db.Begin()
-		if tx.Error != nil {
-			log.Printf("AutoCheckout: gagal mulai transaksi: %v", tx.Error)
-			continue
-		}
-
-		// CHECKOUT
-		var expired []hotel.Booking
-		if err := tx.Where("DATE(check_out) < ? AND status IN ?", today, []string{
-			hotel.BookingStatusConfirmed.String(),
-			hotel.BookingStatusCheckedIn.String(),
-		}).Find(&expired).Error; err != nil {
-			tx.Rollback()
-			log.Printf("AutoCheckout: error query expired: %v", err)
-			continue
-		}
-
-		for _, b := range expired {
-			tx.Model(&b).Update("status", hotel.BookingStatusCheckedOut.String())
-			var room hotel.Room
-			if err := tx.First(&room, b.RoomID).Error; err == nil {
-				tx.Model(&room).Update("status", hotel.RoomStatusAvailable.String())
-			}
-		}
-
-		// CHECKIN
-		var todayCheckins []hotel.Booking
-		if err := tx.Where("DATE(check_in) = ? AND status = ?", today, hotel.BookingStatusConfirmed.String()).
-			Find(&todayCheckins).Error; err != nil {
-			tx.Rollback()
-			log.Printf("AutoCheckin: error query today: %v", err)
-			continue
-		}
-
-		for _, b := range todayCheckins {
-			tx.Model(&b).Update("status", hotel.BookingStatusCheckedIn.String())
-		}
+// registerJobs: daftar job latar belakang beserta jadwal cron-nya (UTC)
+func registerJobs(sched schedulerservice.Scheduler, services *handler.Services) {
+	// Interval sinkronisasi iCal, contoh: 15m, 1h
+	icalSchedule := "@every 15m"
+	if interval, err := time.ParseDuration(os.Getenv("ICAL_SYNC_INTERVAL")); err == nil && interval > 0 {
+		icalSchedule = "@every " + interval.String()
+	}
 
-		if err := tx.Commit().Error; err != nil {
-			log.Printf("AutoCheckout: commit error: %v", err)
+	jobs := []schedulerservice.Job{
+		{
+			// Check-in/check-out dilakukan manual oleh front desk; job ini hanya menangani
+			// tamu yang lewat tanggal check-out tanpa check-out dan booking no-show.
+			Name:     "front-desk-fallbacks",
+			Schedule: "0 * * * *",
+			Run: func(ctx context.Context) (string, error) {
+				res, err := services.FrontDesk.RunFallbacks(ctx)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d check-out otomatis, %d no-show", res.AutoCheckedOut, res.NoShows), nil
+			},
+		},
+		{
+			Name:     "hold-expiry",
+			Schedule: "* * * * *",
+			Timeout:  5 * time.Minute,
+			Run: func(ctx context.Context) (string, error) {
+				n, err := services.Booking.ExpireHolds(ctx)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d booking pending kedaluwarsa", n), nil
+			},
+		},
+		{
+			// Satu-satunya pemroses antrean waitlist: kamar yang lepas karena pembatalan,
+			// hold kedaluwarsa atau link klaim kedaluwarsa ditawarkan paling lambat semenit kemudian.
+			Name:     "waitlist-queue",
+			Schedule: "* * * * *",
+			Timeout:  5 * time.Minute,
+			Run: func(ctx context.Context) (string, error) {
+				n, err := services.Waitlist.ProcessQueue(ctx)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d link klaim dikirim", n), nil
+			},
+		},
+		{
+			// Impor feed iCal OTA; feed yang gagal tidak menghentikan feed lain
+			Name:     "ical-sync",
+			Schedule: icalSchedule,
+			Run: func(ctx context.Context) (string, error) {
+				n, err := services.ICal.SyncAll(ctx)
+				return fmt.Sprintf("%d feed tersinkron", n), err
+			},
+		},
+	}
+	for _, job := range jobs {
+		if err := sched.Register(job); err != nil {
+			log.Fatalf("Registrasi job %s gagal: %v", job.Name, err)
 		}
 	}
 }
-}
 
 func seedSuperAdmin(db *gorm.DB) {
 	hashed, err := bcrypt.GenerateFromPassword([]byte("rahasia123"), bcrypt.DefaultCost)
@@ -196,4 +246,4 @@ func seedSuperAdmin(db *gorm.DB) {
 			log.Println("Superadmin berhasil dibuat: supperpedrooo@gmail.com")
 		}
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,143 @@
+// cmd/migrate_guests.go
+package main
+
+import (
+	"errors"
+	"log"
+
+	"backend/internal/models/auth"
+	"backend/internal/models/hotel"
+	"gorm.io/gorm"
+)
+
+// guestReference: kolom di tabel lain yang menyimpan id akun tamu
+type guestReference struct {
+	table  string
+	column string
+	cond   string
+}
+
+var guestReferences = []guestReference{
+	{table: "bookings", column: "user_id"},
+	{table: "reservations", column: "user_id"},
+	{table: "waitlist_entries", column: "user_id"},
+	{table: "promo_redemptions", column: "user_id"},
+	{table: "guest_reviews", column: "guest_id"},
+	{table: "booking_changes", column: "changed_by", cond: "source = 'guest'"},
+}
+
+// migrateGuests: pindahkan akun role guest dari tabel admins ke tabel guests.
+// Harus jalan sebelum AutoMigrate utama karena FK bookings & ulasan kini mengarah ke guests.
+func migrateGuests(db *gorm.DB) error {
+	m := db.Migrator()
+
+	// FK lama bookings.user_id → admins dan kolom NOT NULL DEFAULT 1
+	if m.HasTable(&hotel.Booking{}) {
+		if m.HasConstraint(&hotel.Booking{}, "fk_bookings_user") {
+			if err := m.DropConstraint(&hotel.Booking{}, "fk_bookings_user"); err != nil {
+				return err
+			}
+		}
+		if m.HasColumn(&hotel.Booking{}, "user_id") {
+			if err := m.AlterColumn(&hotel.Booking{}, "user_id"); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Ulasan: admin_id → guest_id
+	if m.HasTable(&hotel.GuestReview{}) {
+		if m.HasConstraint(&hotel.GuestReview{}, "fk_guest_reviews_admin") {
+			if err := m.DropConstraint(&hotel.GuestReview{}, "fk_guest_reviews_admin"); err != nil {
+				return err
+			}
+		}
+		if m.HasColumn(&hotel.GuestReview{}, "admin_id") && !m.HasColumn(&hotel.GuestReview{}, "guest_id") {
+			if err := m.RenameColumn(&hotel.GuestReview{}, "admin_id", "guest_id"); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := db.AutoMigrate(&auth.Guest{}); err != nil {
+		return err
+	}
+
+	if m.HasTable(&auth.Admin{}) {
+		if err := moveLegacyGuests(db); err != nil {
+			return err
+		}
+	}
+
+	// Booking yang user_id-nya bukan akun tamu (mis. default 1 dari kolom lama)
+	if m.HasTable(&hotel.Booking{}) {
+		if err := db.Exec("UPDATE bookings SET user_id = NULL WHERE user_id IS NOT NULL AND user_id NOT IN (SELECT id FROM guests)").Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveLegacyGuests: ID dipertahankan supaya booking, ulasan & token yang sudah terbit tetap valid.
+// Bila ID sudah dipakai guest lain, referensi dipindahkan ke ID baru.
+func moveLegacyGuests(db *gorm.DB) error {
+	var legacy []auth.Admin
+	if err := db.Where("role = ?", auth.RoleGuest).Find(&legacy).Error; err != nil {
+		return err
+	}
+	if len(legacy) == 0 {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, a := range legacy {
+			var g auth.Guest
+			err := tx.Where("email = ?", a.Email).First(&g).Error
+			switch {
+			case err == nil:
+				// Sudah punya akun tamu dengan email yang sama
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				g = auth.Guest{
+					FullName:    a.FullName,
+					Email:       a.Email,
+					PhoneNumber: a.PhoneNumber,
+					Password:    a.Password,
+					CreatedAt:   a.CreatedAt,
+				}
+				var taken int64
+				if err := tx.Model(&auth.Guest{}).Where("id = ?", a.ID).Count(&taken).Error; err != nil {
+					return err
+				}
+				if taken == 0 {
+					g.ID = a.ID
+				}
+				if err := tx.Create(&g).Error; err != nil {
+					return err
+				}
+			default:
+				return err
+			}
+
+			if g.ID != a.ID {
+				for _, ref := range guestReferences {
+					if !tx.Migrator().HasTable(ref.table) {
+						continue
+					}
+					q := tx.Table(ref.table).Where(ref.column+" = ?", a.ID)
+					if ref.cond != "" {
+						q = q.Where(ref.cond)
+					}
+					if err := q.Update(ref.column, g.ID).Error; err != nil {
+						return err
+					}
+				}
+			}
+
+			if err := tx.Delete(&auth.Admin{}, a.ID).Error; err != nil {
+				return err
+			}
+		}
+		log.Printf("Migrasi guest: %d akun tamu dipindahkan dari admins ke guests", len(legacy))
+		return nil
+	})
+}
@@ -0,0 +1,36 @@
+// cmd/migrate_legacy_holds.go
+package main
+
+import (
+	"log"
+	"time"
+
+	"backend/internal/models/hotel"
+	"gorm.io/gorm"
+)
+
+// legacyHoldGrace: waktu yang diberikan ke booking pending lama (menunggu transfer manual)
+// sebelum ikut kedaluwarsa seperti booking baru
+const legacyHoldGrace = 24 * time.Hour
+
+// needsLegacyHoldBackfill: kolom hold_expires_at belum ada berarti booking pending yang
+// ada dibuat sebelum fitur hold. Dicek sebelum AutoMigrate menambahkan kolomnya.
+func needsLegacyHoldBackfill(db *gorm.DB) bool {
+	m := db.Migrator()
+	return m.HasTable(&hotel.Booking{}) && !m.HasColumn(&hotel.Booking{}, "hold_expires_at")
+}
+
+// backfillLegacyHolds: booking pending lama diberi hold supaya tidak langsung
+// dikedaluwarsakan massal oleh job expire-holds pertama setelah deploy
+func backfillLegacyHolds(db *gorm.DB) error {
+	res := db.Model(&hotel.Booking{}).
+		Where("status = ? AND hold_expires_at IS NULL", hotel.BookingStatusPending).
+		Update("hold_expires_at", time.Now().Add(legacyHoldGrace))
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		log.Printf("Migrasi: %d booking pending lama diberi hold %s", res.RowsAffected, legacyHoldGrace)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+// cmd/migrate_legacy_payments.go
+package main
+
+import (
+	"log"
+
+	"backend/internal/models/hotel"
+	"gorm.io/gorm"
+)
+
+// needsLegacyPaidBackfill: kolom legacy_paid_amount belum ada berarti database lama
+// yang belum pernah dimigrasi. Dicek sebelum AutoMigrate menambahkan kolomnya.
+func needsLegacyPaidBackfill(db *gorm.DB) bool {
+	m := db.Migrator()
+	return m.HasTable(&hotel.Booking{}) && !m.HasColumn(&hotel.Booking{}, "legacy_paid_amount")
+}
+
+// backfillLegacyPaid: booking yang sudah dikonfirmasi sebelum pembayaran dicatat per
+// transaksi dianggap lunas lewat transfer manual. Hanya jalan sekali saat kolom dibuat;
+// booking yang dikonfirmasi setelahnya hanya dihitung dari catatan payment.
+func backfillLegacyPaid(db *gorm.DB) error {
+	res := db.Model(&hotel.Booking{}).
+		Where("status IN ?", []hotel.BookingStatus{
+			hotel.BookingStatusConfirmed, hotel.BookingStatusCheckedIn, hotel.BookingStatusCheckedOut,
+		}).
+		Where("NOT EXISTS (SELECT 1 FROM payments WHERE payments.booking_id = bookings.id AND payments.status = ?)", hotel.PaymentStatusPaid).
+		Update("legacy_paid_amount", gorm.Expr("total_price"))
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		log.Printf("Migrasi: %d booking lama ditandai lunas manual", res.RowsAffected)
+	}
+	return nil
+}
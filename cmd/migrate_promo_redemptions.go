@@ -0,0 +1,21 @@
+// cmd/migrate_promo_redemptions.go
+package main
+
+import (
+	"backend/internal/models/hotel"
+	"gorm.io/gorm"
+)
+
+// needsRedemptionReservationBackfill: dicek sebelum AutoMigrate menambahkan kolom reservation_id
+func needsRedemptionReservationBackfill(db *gorm.DB) bool {
+	m := db.Migrator()
+	return m.HasTable(&hotel.PromoRedemption{}) && !m.HasColumn(&hotel.PromoRedemption{}, "reservation_id")
+}
+
+// backfillRedemptionReservations: pemakaian promo lama hanya menunjuk booking pertama,
+// reservasinya diisi supaya kamar lain di reservasi yang sama ikut dihitung
+func backfillRedemptionReservations(db *gorm.DB) error {
+	return db.Exec(`UPDATE promo_redemptions SET reservation_id = (
+			SELECT bookings.reservation_id FROM bookings WHERE bookings.id = promo_redemptions.booking_id)
+		WHERE reservation_id IS NULL`).Error
+}
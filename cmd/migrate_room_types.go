@@ -0,0 +1,50 @@
+// cmd/migrate_room_types.go
+package main
+
+import (
+	"backend/internal/models/hotel"
+	"gorm.io/gorm"
+)
+
+// occupancyMigration: kolom okupansi tipe kamar yang baru dibuat AutoMigrate
+// dengan nilai default, sehingga perlu diisi dari data kamar yang sudah ada
+type occupancyMigration struct {
+	maxOccupancy  bool
+	baseOccupancy bool
+}
+
+// pendingOccupancyMigration: dicek sebelum AutoMigrate menambahkan kolomnya
+func pendingOccupancyMigration(db *gorm.DB) occupancyMigration {
+	m := db.Migrator()
+	if !m.HasTable(&hotel.RoomType{}) {
+		return occupancyMigration{}
+	}
+	return occupancyMigration{
+		maxOccupancy:  !m.HasColumn(&hotel.RoomType{}, "max_occupancy"),
+		baseOccupancy: !m.HasColumn(&hotel.RoomType{}, "base_occupancy"),
+	}
+}
+
+// backfillOccupancy: default 2 tamu akan memotong kamar keluarga yang kapasitasnya
+// lebih besar (checkOccupancy memakai min(capacity, max_occupancy)). max_occupancy
+// diisi dari kapasitas kamar terbesar tipe tersebut, base_occupancy tidak boleh melebihinya.
+func backfillOccupancy(db *gorm.DB, pending occupancyMigration) error {
+	if pending.maxOccupancy {
+		if err := db.Exec(`UPDATE room_types SET max_occupancy = (
+				SELECT MAX(rooms.capacity) FROM rooms
+				WHERE rooms.room_type_id = room_types.id AND rooms.deleted_at IS NULL)
+			WHERE EXISTS (
+				SELECT 1 FROM rooms
+				WHERE rooms.room_type_id = room_types.id AND rooms.deleted_at IS NULL)`).Error; err != nil {
+			return err
+		}
+	}
+	if pending.maxOccupancy || pending.baseOccupancy {
+		if err := db.Model(&hotel.RoomType{}).
+			Where("base_occupancy > max_occupancy").
+			Update("base_occupancy", gorm.Expr("max_occupancy")).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -72,7 +72,7 @@ func (h *AdminHandler) Login(c *gin.Context) {
 
 func (h *AdminHandler) GetProfile(c *gin.Context) {
 	userID := c.GetUint("user_id")
-	admin, err := h.service.GetProfile(userID)
+	admin, err := h.service.GetProfile(userID, auth.Role(c.GetString("role")))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
@@ -89,7 +89,7 @@ func (h *AdminHandler) ApproveUser(c *gin.Context) {
 	}
 
 	// Kirim email sukses
-	admin, _ := h.service.GetProfile(uint(id))
+	admin, _ := h.service.GetProfile(uint(id), "") // akun admin, bukan guest
 	if admin != nil {
 		go utils.SendApprovalSuccessEmail(admin.Email, admin.FullName)
 	}
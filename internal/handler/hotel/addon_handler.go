@@ -0,0 +1,169 @@
+// internal/handler/hotel/addon_handler.go
+package hotel
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"backend/internal/models/hotel"
+	"backend/internal/service/hotelservice"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type AddOnHandler struct {
+	service hotelservice.AddOnService
+}
+
+func NewAddOnHandler(service hotelservice.AddOnService) *AddOnHandler {
+	return &AddOnHandler{service: service}
+}
+
+// POST /api/add-ons
+func (h *AddOnHandler) Create(c *gin.Context) {
+	var req hotel.CreateAddOnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	addOn, err := h.service.Create(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": addOn})
+}
+
+// GET /api/add-ons?active=true
+func (h *AddOnHandler) List(c *gin.Context) {
+	addOns, err := h.service.List(c.Query("active") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": addOns})
+}
+
+// GET /public/add-ons (hanya yang aktif)
+func (h *AddOnHandler) ListPublic(c *gin.Context) {
+	addOns, err := h.service.List(true)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": addOns})
+}
+
+// PUT /api/add-ons/:id
+func (h *AddOnHandler) Update(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req hotel.UpdateAddOnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	addOn, err := h.service.Update(uint(id), req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "add-on not found"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": addOn})
+}
+
+// DELETE /api/add-ons/:id
+func (h *AddOnHandler) Delete(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.service.Delete(uint(id)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "add-on deleted"})
+}
+
+// POST /api/bookings/:id/add-ons
+// Body: {"add_ons": [{"add_on_id": 1, "quantity": 2}]}
+func (h *AddOnHandler) Attach(c *gin.Context) {
+	h.attach(c, hotel.BookingChangeByAdmin)
+}
+
+// POST /public/me/bookings/:id/add-ons (guest, hanya booking miliknya)
+func (h *AddOnHandler) AttachMine(c *gin.Context) {
+	h.attach(c, hotel.BookingChangeByGuest)
+}
+
+func (h *AddOnHandler) attach(c *gin.Context, source string) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req hotel.AttachAddOnsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	booking, err := h.service.Attach(uint(id), c.GetUint("user_id"), source, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Add-on ditambahkan", "data": booking})
+}
+
+// DELETE /api/bookings/:id/add-ons/:item_id
+func (h *AddOnHandler) Remove(c *gin.Context) {
+	h.remove(c, hotel.BookingChangeByAdmin)
+}
+
+// DELETE /public/me/bookings/:id/add-ons/:item_id
+func (h *AddOnHandler) RemoveMine(c *gin.Context) {
+	h.remove(c, hotel.BookingChangeByGuest)
+}
+
+func (h *AddOnHandler) remove(c *gin.Context, source string) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+	itemID, err := strconv.ParseUint(c.Param("item_id"), 10, 32)
+	if err != nil || itemID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid item ID"})
+		return
+	}
+
+	booking, err := h.service.Remove(uint(id), uint(itemID), c.GetUint("user_id"), source)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Add-on dihapus", "data": booking})
+}
+
+func (h *AddOnHandler) handleError(c *gin.Context, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "booking not found"})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
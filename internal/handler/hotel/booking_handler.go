@@ -28,14 +28,34 @@ func NewBookingHandler(service hotelservice.BookingService) *BookingHandler {
 	return &BookingHandler{service: service}
 }
 
+// currentUser: user_id dari AuthMiddleware, menulis 401 bila tidak ada
+func (h *BookingHandler) currentUser(c *gin.Context) (uint, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		h.unauthorized(c, "user not authenticated")
+		return 0, false
+	}
+	uid, ok := userID.(uint)
+	if !ok {
+		h.unauthorized(c, "invalid user id")
+		return 0, false
+	}
+	return uid, true
+}
+
 func (h *BookingHandler) Create(c *gin.Context) {
+	uid, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
 	var req hotel.CreateBookingRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.badRequest(c, "invalid request body: "+err.Error())
 		return
 	}
 
-	resp, err := h.service.Create(req)
+	resp, err := h.service.Create(uid, req)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -45,14 +65,8 @@ func (h *BookingHandler) Create(c *gin.Context) {
 }
 
 func (h *BookingHandler) GuestBook(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		h.unauthorized(c, "user not authenticated")
-		return
-	}
-	uid, ok := userID.(uint)
+	uid, ok := h.currentUser(c)
 	if !ok {
-		h.unauthorized(c, "invalid user id")
 		return
 	}
 
@@ -71,6 +85,28 @@ func (h *BookingHandler) GuestBook(c *gin.Context) {
 	h.created(c, resp)
 }
 
+// POST /public/waitlist/claim (guest, token dari email waitlist)
+func (h *BookingHandler) ClaimWaitlist(c *gin.Context) {
+	uid, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req hotel.ClaimWaitlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.badRequest(c, "invalid request body: "+err.Error())
+		return
+	}
+
+	resp, err := h.service.ClaimWaitlist(req.Token, uid)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.created(c, resp)
+}
+
 func (h *BookingHandler) CheckAvailability(c *gin.Context) {
 	var req hotel.AvailabilityRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
@@ -149,12 +185,92 @@ func (h *BookingHandler) Cancel(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.Cancel(id); err != nil {
+	// Body opsional: {"reason": "..."}
+	var req hotel.CancelBookingRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.badRequest(c, "invalid request body: "+err.Error())
+			return
+		}
+	}
+
+	quote, err := h.service.Cancel(id, req.Reason)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.ok(c, gin.H{"message": "Booking dibatalkan", "cancellation": quote})
+}
+
+// GET /api/bookings/:id/cancellation-quote
+func (h *BookingHandler) CancellationQuote(c *gin.Context) {
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		h.badRequest(c, err.Error())
+		return
+	}
+
+	quote, err := h.service.CancellationQuote(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.ok(c, quote)
+}
+
+// PATCH /api/bookings/:id
+func (h *BookingHandler) Modify(c *gin.Context) {
+	h.modify(c, hotel.BookingChangeByAdmin)
+}
+
+// PATCH /public/bookings/:id (guest, hanya booking miliknya)
+func (h *BookingHandler) GuestModify(c *gin.Context) {
+	h.modify(c, hotel.BookingChangeByGuest)
+}
+
+func (h *BookingHandler) modify(c *gin.Context, source string) {
+	uid, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		h.badRequest(c, err.Error())
+		return
+	}
+
+	var req hotel.ModifyBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.badRequest(c, "invalid request body: "+err.Error())
+		return
+	}
+
+	booking, err := h.service.Modify(id, uid, source, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.ok(c, booking)
+}
+
+// GET /api/bookings/:id/changes
+func (h *BookingHandler) Changes(c *gin.Context) {
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		h.badRequest(c, err.Error())
+		return
+	}
+
+	changes, err := h.service.ListChanges(id)
+	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	h.ok(c, gin.H{"message": "Booking dibatalkan"})
+	h.ok(c, changes)
 }
 
 // === Helper Methods ===
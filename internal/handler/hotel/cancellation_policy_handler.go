@@ -0,0 +1,83 @@
+// internal/handler/hotel/cancellation_policy_handler.go
+package hotel
+
+import (
+	"net/http"
+	"strconv"
+
+	"backend/internal/models/hotel"
+	"backend/internal/service/hotelservice"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CancellationPolicyHandler struct {
+	service hotelservice.CancellationPolicyService
+}
+
+func NewCancellationPolicyHandler(service hotelservice.CancellationPolicyService) *CancellationPolicyHandler {
+	return &CancellationPolicyHandler{service: service}
+}
+
+// POST /api/cancellation-policies
+func (h *CancellationPolicyHandler) Create(c *gin.Context) {
+	var req hotel.CreateCancellationPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy, err := h.service.Create(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": policy})
+}
+
+// GET /api/cancellation-policies
+func (h *CancellationPolicyHandler) List(c *gin.Context) {
+	policies, err := h.service.List()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": policies})
+}
+
+// PUT /api/cancellation-policies/:id
+func (h *CancellationPolicyHandler) Update(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req hotel.UpdateCancellationPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy, err := h.service.Update(uint(id), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": policy})
+}
+
+// DELETE /api/cancellation-policies/:id
+func (h *CancellationPolicyHandler) Delete(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.service.Delete(uint(id)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "cancellation policy deleted"})
+}
@@ -0,0 +1,127 @@
+// internal/handler/hotel/folio_handler.go
+package hotel
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"backend/internal/models/hotel"
+	"backend/internal/service/hotelservice"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type FolioHandler struct {
+	service hotelservice.FolioService
+}
+
+func NewFolioHandler(service hotelservice.FolioService) *FolioHandler {
+	return &FolioHandler{service: service}
+}
+
+// GET /api/bookings/:id/folio
+func (h *FolioHandler) Get(c *gin.Context) {
+	id, ok := h.bookingID(c)
+	if !ok {
+		return
+	}
+	folio, err := h.service.Get(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": folio})
+}
+
+// GET /public/me/bookings/:id/folio
+func (h *FolioHandler) GetMine(c *gin.Context) {
+	id, ok := h.bookingID(c)
+	if !ok {
+		return
+	}
+	folio, err := h.service.GetMine(id, c.GetUint("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": folio})
+}
+
+// POST /api/bookings/:id/folio/charges
+func (h *FolioHandler) Charge(c *gin.Context) {
+	id, ok := h.bookingID(c)
+	if !ok {
+		return
+	}
+	var req hotel.FolioChargeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	folio, err := h.service.Charge(id, c.GetUint("user_id"), req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Tagihan diposting", "data": folio})
+}
+
+// POST /api/bookings/:id/folio/payments
+func (h *FolioHandler) Pay(c *gin.Context) {
+	id, ok := h.bookingID(c)
+	if !ok {
+		return
+	}
+	var req hotel.FolioPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	folio, err := h.service.Pay(id, c.GetUint("user_id"), req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Pembayaran dicatat", "data": folio})
+}
+
+// POST /api/bookings/:id/folio/adjustments
+func (h *FolioHandler) Adjust(c *gin.Context) {
+	id, ok := h.bookingID(c)
+	if !ok {
+		return
+	}
+	var req hotel.FolioAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	folio, err := h.service.Adjust(id, c.GetUint("user_id"), req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Koreksi dicatat", "data": folio})
+}
+
+func (h *FolioHandler) bookingID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *FolioHandler) handleError(c *gin.Context, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "booking not found"})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,71 @@
+// internal/handler/hotel/front_desk_handler.go
+package hotel
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"backend/internal/models/hotel"
+	"backend/internal/service/hotelservice"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type FrontDeskHandler struct {
+	service hotelservice.FrontDeskService
+}
+
+func NewFrontDeskHandler(service hotelservice.FrontDeskService) *FrontDeskHandler {
+	return &FrontDeskHandler{service: service}
+}
+
+// PATCH /api/bookings/:id/check-in
+func (h *FrontDeskHandler) CheckIn(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	booking, err := h.service.CheckIn(uint(id), c.GetUint("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Tamu berhasil check-in", "data": booking})
+}
+
+// PATCH /api/bookings/:id/check-out
+// Body opsional: {"late_fee": 0, "refund_unused_nights": true}
+func (h *FrontDeskHandler) CheckOut(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	var req hotel.CheckOutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	booking, err := h.service.CheckOut(uint(id), c.GetUint("user_id"), req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Tamu berhasil check-out", "data": booking})
+}
+
+func (h *FrontDeskHandler) handleError(c *gin.Context, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "booking not found"})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
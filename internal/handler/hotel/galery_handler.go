@@ -78,8 +78,7 @@ func (h *GalleryHandler) List(c *gin.Context) {
 	}
 
 	roomType := strings.ToLower(strings.TrimSpace(c.Query("room_type")))
-	if roomType != "" && roomType != "superior" && roomType != "deluxe" && roomType != "executive" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "room_type must be one of: superior|deluxe|executive"})
+	if !h.checkRoomType(c, roomType) {
 		return
 	}
 
@@ -95,6 +94,15 @@ func (h *GalleryHandler) List(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": items, "total": total})
 }
 
+// checkRoomType: filter room_type harus tipe kamar yang terdaftar di katalog
+func (h *GalleryHandler) checkRoomType(c *gin.Context, roomType string) bool {
+	if roomType == "" || h.service.RoomTypeExists(roomType) {
+		return true
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": "unknown room_type: " + roomType})
+	return false
+}
+
 // GET /public/galleries (versi publik; default include_global=true, limit<=24)
 func (h *GalleryHandler) ListPublic(c *gin.Context) {
 	var roomIDPtr *uint
@@ -109,8 +117,7 @@ func (h *GalleryHandler) ListPublic(c *gin.Context) {
 	}
 
 	roomType := strings.ToLower(strings.TrimSpace(c.Query("room_type")))
-	if roomType != "" && roomType != "superior" && roomType != "deluxe" && roomType != "executive" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "room_type must be one of: superior|deluxe|executive"})
+	if !h.checkRoomType(c, roomType) {
 		return
 	}
 
@@ -159,8 +166,7 @@ func (h *GalleryHandler) ListByRoom(c *gin.Context) {
 	u := uint(rid)
 
 	roomType := strings.ToLower(strings.TrimSpace(c.Query("room_type")))
-	if roomType != "" && roomType != "superior" && roomType != "deluxe" && roomType != "executive" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "room_type must be one of: superior|deluxe|executive"})
+	if !h.checkRoomType(c, roomType) {
 		return
 	}
 
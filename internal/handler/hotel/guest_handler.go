@@ -0,0 +1,89 @@
+// internal/handler/hotel/guest_handler.go
+package hotel
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"backend/internal/models/auth"
+	"backend/internal/service/hotelservice"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type GuestHandler struct {
+	service hotelservice.GuestService
+}
+
+func NewGuestHandler(service hotelservice.GuestService) *GuestHandler {
+	return &GuestHandler{service: service}
+}
+
+// GET /public/me/profile
+func (h *GuestHandler) MyProfile(c *gin.Context) {
+	h.profile(c, c.GetUint("user_id"))
+}
+
+// PATCH /public/me/profile
+func (h *GuestHandler) UpdateMyProfile(c *gin.Context) {
+	var req auth.UpdateGuestProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	g, err := h.service.UpdateProfile(c.GetUint("user_id"), req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Profil diperbarui", "data": g})
+}
+
+// GET /api/guests?search=&limit=&offset=
+func (h *GuestHandler) List(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit <= 0 {
+		limit = 20
+	}
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+
+	guests, total, err := h.service.List(c.Query("search"), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": guests, "total": total, "limit": limit, "offset": offset})
+}
+
+// GET /api/guests/:id
+func (h *GuestHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+	h.profile(c, uint(id))
+}
+
+func (h *GuestHandler) profile(c *gin.Context, id uint) {
+	p, err := h.service.Profile(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": p})
+}
+
+func (h *GuestHandler) handleError(c *gin.Context, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "guest tidak ditemukan"})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,148 @@
+// internal/handler/hotel/housekeeping_handler.go
+package hotel
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"backend/internal/models/hotel"
+	"backend/internal/repository/repohotel"
+	"backend/internal/service/hotelservice"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type HousekeepingHandler struct {
+	service hotelservice.HousekeepingService
+}
+
+func NewHousekeepingHandler(service hotelservice.HousekeepingService) *HousekeepingHandler {
+	return &HousekeepingHandler{service: service}
+}
+
+// POST /api/housekeeping/tasks
+func (h *HousekeepingHandler) Create(c *gin.Context) {
+	var req hotel.CreateHousekeepingTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, err := h.service.Create(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": task})
+}
+
+// GET /api/housekeeping/tasks?status=&room_id=&assigned_to= (assigned_to=me untuk task sendiri)
+func (h *HousekeepingHandler) List(c *gin.Context) {
+	f := repohotel.HousekeepingFilter{Status: c.Query("status")}
+	if roomID, _ := strconv.ParseUint(c.Query("room_id"), 10, 32); roomID != 0 {
+		f.RoomID = uint(roomID)
+	}
+	switch assigned := c.Query("assigned_to"); assigned {
+	case "":
+	case "me":
+		uid := c.GetUint("user_id")
+		f.AssignedTo = &uid
+	default:
+		if id, _ := strconv.ParseUint(assigned, 10, 32); id != 0 {
+			uid := uint(id)
+			f.AssignedTo = &uid
+		}
+	}
+
+	tasks, err := h.service.List(f)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": tasks})
+}
+
+// GET /api/housekeeping/board
+func (h *HousekeepingHandler) Board(c *gin.Context) {
+	board, err := h.service.Board()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": board})
+}
+
+// PATCH /api/housekeeping/tasks/:id/assign
+func (h *HousekeepingHandler) Assign(c *gin.Context) {
+	id, ok := h.taskID(c)
+	if !ok {
+		return
+	}
+	var req hotel.AssignHousekeepingTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, err := h.service.Assign(id, req.AssignedTo)
+	h.respond(c, task, err)
+}
+
+// PATCH /api/housekeeping/tasks/:id/start
+func (h *HousekeepingHandler) Start(c *gin.Context) {
+	id, ok := h.taskID(c)
+	if !ok {
+		return
+	}
+	task, err := h.service.Start(id, c.GetUint("user_id"))
+	h.respond(c, task, err)
+}
+
+// PATCH /api/housekeeping/tasks/:id/clean
+func (h *HousekeepingHandler) Clean(c *gin.Context) {
+	id, ok := h.taskID(c)
+	if !ok {
+		return
+	}
+	task, err := h.service.MarkCleaned(id, c.GetUint("user_id"))
+	h.respond(c, task, err)
+}
+
+// PATCH /api/housekeeping/tasks/:id/inspect
+func (h *HousekeepingHandler) Inspect(c *gin.Context) {
+	id, ok := h.taskID(c)
+	if !ok {
+		return
+	}
+	var req hotel.InspectHousekeepingTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	task, err := h.service.Inspect(id, c.GetUint("user_id"), req)
+	h.respond(c, task, err)
+}
+
+func (h *HousekeepingHandler) taskID(c *gin.Context) (uint, bool) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *HousekeepingHandler) respond(c *gin.Context, task *hotel.HousekeepingTask, err error) {
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": task})
+}
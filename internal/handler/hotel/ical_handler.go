@@ -0,0 +1,172 @@
+// internal/handler/hotel/ical_handler.go
+package hotel
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"backend/internal/models/hotel"
+	"backend/internal/service/hotelservice"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ICalHandler struct {
+	service hotelservice.ICalService
+	token   string
+}
+
+func NewICalHandler(service hotelservice.ICalService) *ICalHandler {
+	// Feed publik hanya bisa dibaca dengan ?token=<ICAL_FEED_TOKEN>
+	token := os.Getenv("ICAL_FEED_TOKEN")
+	if token == "" {
+		log.Println("ICAL_FEED_TOKEN kosong, feed iCal publik dinonaktifkan")
+	}
+	return &ICalHandler{service: service, token: token}
+}
+
+func (h *ICalHandler) authorized(c *gin.Context) bool {
+	// Tanpa token feed tidak dibuka, ketersediaan kamar tidak boleh terbaca siapa saja
+	if h.token == "" {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "ical feed is not configured"})
+		return false
+	}
+	if subtle.ConstantTimeCompare([]byte(c.Query("token")), []byte(h.token)) == 1 {
+		return true
+	}
+	c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid feed token"})
+	return false
+}
+
+func (h *ICalHandler) writeCalendar(c *gin.Context, name string, data []byte, err error) {
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s.ics"`, name))
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", data)
+}
+
+// GET /public/ical/rooms/:id (boleh /public/ical/rooms/12.ics)
+func (h *ICalHandler) RoomFeed(c *gin.Context) {
+	if !h.authorized(c) {
+		return
+	}
+	// OTA biasanya minta URL berakhiran .ics
+	id, _ := strconv.ParseUint(strings.TrimSuffix(c.Param("id"), ".ics"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	data, err := h.service.RoomCalendar(uint(id))
+	h.writeCalendar(c, fmt.Sprintf("room-%d", id), data, err)
+}
+
+// GET /public/ical/room-types/:id (boleh /public/ical/room-types/3.ics)
+func (h *ICalHandler) RoomTypeFeed(c *gin.Context) {
+	if !h.authorized(c) {
+		return
+	}
+	// OTA biasanya minta URL berakhiran .ics
+	id, _ := strconv.ParseUint(strings.TrimSuffix(c.Param("id"), ".ics"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	data, err := h.service.RoomTypeCalendar(uint(id))
+	h.writeCalendar(c, fmt.Sprintf("room-type-%d", id), data, err)
+}
+
+// POST /api/ical-feeds
+func (h *ICalHandler) CreateFeed(c *gin.Context) {
+	var req hotel.CreateICalFeedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	feed, err := h.service.CreateFeed(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": feed})
+}
+
+// GET /api/ical-feeds
+func (h *ICalHandler) ListFeeds(c *gin.Context) {
+	feeds, err := h.service.ListFeeds()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": feeds})
+}
+
+// PUT /api/ical-feeds/:id
+func (h *ICalHandler) UpdateFeed(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req hotel.UpdateICalFeedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	feed, err := h.service.UpdateFeed(uint(id), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": feed})
+}
+
+// DELETE /api/ical-feeds/:id
+func (h *ICalHandler) DeleteFeed(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.service.DeleteFeed(uint(id)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "ical feed deleted"})
+}
+
+// POST /api/ical-feeds/:id/sync
+func (h *ICalHandler) SyncFeed(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	result, err := h.service.SyncFeed(uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "ical feed not found"})
+			return
+		}
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
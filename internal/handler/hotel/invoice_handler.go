@@ -0,0 +1,84 @@
+// internal/handler/hotel/invoice_handler.go
+package hotel
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"backend/internal/service/hotelservice"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type InvoiceHandler struct {
+	service hotelservice.InvoiceService
+}
+
+func NewInvoiceHandler(service hotelservice.InvoiceService) *InvoiceHandler {
+	return &InvoiceHandler{service: service}
+}
+
+// GET /api/bookings/:id/invoice
+func (h *InvoiceHandler) Download(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, response{Error: "invalid ID"})
+		return
+	}
+
+	doc, filename, err := h.service.Render(uint(id))
+	h.send(c, doc, filename, err)
+}
+
+// GET /public/me/bookings/:id/invoice
+func (h *InvoiceHandler) DownloadMine(c *gin.Context) {
+	uid, ok := c.Get("user_id")
+	userID, valid := uid.(uint)
+	if !ok || !valid {
+		c.JSON(http.StatusUnauthorized, response{Error: "user not authenticated"})
+		return
+	}
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, response{Error: "invalid ID"})
+		return
+	}
+
+	doc, filename, err := h.service.RenderForUser(uint(id), userID)
+	h.send(c, doc, filename, err)
+}
+
+// POST /api/bookings/:id/invoice/email
+func (h *InvoiceHandler) Email(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, response{Error: "invalid ID"})
+		return
+	}
+
+	if err := h.service.Email(uint(id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, response{Error: "booking not found"})
+			return
+		}
+		c.JSON(http.StatusBadGateway, response{Error: "gagal mengirim invoice: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response{Message: "Invoice terkirim"})
+}
+
+func (h *InvoiceHandler) send(c *gin.Context, doc []byte, filename string, err error) {
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, response{Error: "booking not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response{Error: "internal server error: " + err.Error()})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Data(http.StatusOK, "application/pdf", doc)
+}
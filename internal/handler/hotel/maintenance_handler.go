@@ -0,0 +1,97 @@
+// internal/handler/hotel/maintenance_handler.go
+package hotel
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/internal/models/hotel"
+	"backend/internal/service/hotelservice"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type MaintenanceHandler struct {
+	service hotelservice.MaintenanceService
+}
+
+func NewMaintenanceHandler(service hotelservice.MaintenanceService) *MaintenanceHandler {
+	return &MaintenanceHandler{service: service}
+}
+
+// POST /api/maintenance-blocks
+// Body: {"room_id": 3, "reason": "Perbaikan AC", "start_date": "2025-03-01", "end_date": "2025-03-04", "force": false}
+// Bentrok dengan booking aktif tanpa force → 409 beserta laporan relokasi
+func (h *MaintenanceHandler) Create(c *gin.Context) {
+	var req hotel.CreateMaintenanceBlockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.Create(c.GetUint("user_id"), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, hotelservice.ErrMaintenanceConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "data": result})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "room not found"})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": result})
+}
+
+// GET /api/maintenance-blocks?room_id=&start=2025-03-01&end=2025-04-01
+func (h *MaintenanceHandler) List(c *gin.Context) {
+	roomID, _ := strconv.ParseUint(c.Query("room_id"), 10, 32)
+
+	var start, end *time.Time
+	if v := c.Query("start"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "format start harus YYYY-MM-DD"})
+			return
+		}
+		start = &t
+	}
+	if v := c.Query("end"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "format end harus YYYY-MM-DD"})
+			return
+		}
+		end = &t
+	}
+
+	blocks, err := h.service.List(uint(roomID), start, end)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": blocks})
+}
+
+// DELETE /api/maintenance-blocks/:id (kamar kembali bisa dijual)
+func (h *MaintenanceHandler) Delete(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.service.Delete(uint(id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "maintenance block not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "maintenance block deleted"})
+}
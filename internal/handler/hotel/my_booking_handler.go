@@ -0,0 +1,139 @@
+// internal/handler/hotel/my_booking_handler.go
+package hotel
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"backend/internal/models/hotel"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Portal guest: semua endpoint di sini dibatasi ke booking milik user_id dari JWT.
+// Booking milik user lain dijawab 404 supaya id booking orang lain tidak bisa ditebak.
+
+// GET /public/me/bookings?status=&limit=&offset=
+func (h *BookingHandler) MyBookings(c *gin.Context) {
+	uid, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	status := c.Query("status")
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if limit <= 0 {
+		limit = 10
+	}
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+
+	bookings, total, err := h.service.ListMine(uid, status, limit, offset)
+	if err != nil {
+		h.internalError(c, err.Error())
+		return
+	}
+
+	h.ok(c, gin.H{
+		"data":   bookings,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// GET /public/me/bookings/:id
+func (h *BookingHandler) MyBooking(c *gin.Context) {
+	uid, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		h.badRequest(c, err.Error())
+		return
+	}
+
+	booking, err := h.service.GetMine(id, uid)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.ok(c, booking)
+}
+
+// GET /public/me/bookings/:id/cancellation-quote
+func (h *BookingHandler) MyCancellationQuote(c *gin.Context) {
+	uid, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		h.badRequest(c, err.Error())
+		return
+	}
+
+	quote, err := h.service.CancellationQuoteMine(id, uid)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.ok(c, quote)
+}
+
+// PATCH /public/me/bookings/:id/cancel
+func (h *BookingHandler) MyCancel(c *gin.Context) {
+	uid, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		h.badRequest(c, err.Error())
+		return
+	}
+
+	var req hotel.CancelBookingRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.badRequest(c, "invalid request body: "+err.Error())
+			return
+		}
+	}
+
+	quote, err := h.service.CancelMine(id, uid, req.Reason)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.ok(c, gin.H{"message": "Booking dibatalkan", "cancellation": quote})
+}
+
+// GET /public/me/bookings/:id/summary
+func (h *BookingHandler) MySummary(c *gin.Context) {
+	uid, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		h.badRequest(c, err.Error())
+		return
+	}
+
+	summary, err := h.service.SummaryMine(id, uid)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="booking-%d.txt"`, id))
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(summary))
+}
@@ -0,0 +1,52 @@
+// internal/handler/hotel/occupancy_handler.go
+package hotel
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/internal/service/hotelservice"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OccupancyHandler struct {
+	service hotelservice.OccupancyService
+}
+
+func NewOccupancyHandler(service hotelservice.OccupancyService) *OccupancyHandler {
+	return &OccupancyHandler{service: service}
+}
+
+// GET /api/occupancy?start=2025-01-01&end=2025-03-01&room_type_id=
+// end eksklusif; default 30 hari mulai hari ini
+func (h *OccupancyHandler) Grid(c *gin.Context) {
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if v := c.Query("start"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "format start harus YYYY-MM-DD"})
+			return
+		}
+		start = t
+	}
+	end := start.AddDate(0, 0, 30)
+	if v := c.Query("end"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "format end harus YYYY-MM-DD"})
+			return
+		}
+		end = t
+	}
+	roomTypeID, _ := strconv.ParseUint(c.Query("room_type_id"), 10, 32)
+
+	grid, err := h.service.Grid(start, end, uint(roomTypeID))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": grid})
+}
@@ -0,0 +1,90 @@
+// internal/handler/hotel/payment_handler.go
+package hotel
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"backend/internal/payment"
+	"backend/internal/service/hotelservice"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type PaymentHandler struct {
+	service hotelservice.PaymentService
+}
+
+func NewPaymentHandler(service hotelservice.PaymentService) *PaymentHandler {
+	return &PaymentHandler{service: service}
+}
+
+// Pay: guest membuat tagihan untuk booking miliknya
+func (h *PaymentHandler) Pay(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusUnprocessableEntity, response{Error: "invalid ID"})
+		return
+	}
+	uid, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response{Error: "user not authenticated"})
+		return
+	}
+	userID := uid.(uint)
+
+	res, err := h.service.CreateForBooking(c.Request.Context(), uint(id), &userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, response{Error: "booking not found"})
+			return
+		}
+		c.JSON(http.StatusUnprocessableEntity, response{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, response{Data: res})
+}
+
+// Webhook: notifikasi dari payment gateway (tanpa JWT, diverifikasi lewat signature)
+func (h *PaymentHandler) Webhook(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response{Error: "gagal membaca body"})
+		return
+	}
+
+	if err := h.service.HandleWebhook(c.Request.Header, body); err != nil {
+		switch {
+		case errors.Is(err, payment.ErrInvalidSignature):
+			c.JSON(http.StatusUnauthorized, response{Error: err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, response{Error: "payment not found"})
+		default:
+			c.JSON(http.StatusUnprocessableEntity, response{Error: err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response{Message: "ok"})
+}
+
+// ListByBooking: admin melihat riwayat pembayaran booking
+func (h *PaymentHandler) ListByBooking(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusUnprocessableEntity, response{Error: "invalid ID"})
+		return
+	}
+
+	payments, err := h.service.ListByBooking(uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response{Error: "internal server error: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response{Data: payments})
+}
@@ -0,0 +1,85 @@
+// internal/handler/hotel/promo_handler.go
+package hotel
+
+import (
+	"net/http"
+	"strconv"
+
+	"backend/internal/models/hotel"
+	"backend/internal/service/hotelservice"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PromoHandler struct {
+	service hotelservice.PromoService
+}
+
+func NewPromoHandler(service hotelservice.PromoService) *PromoHandler {
+	return &PromoHandler{service: service}
+}
+
+// POST /api/promo-codes
+func (h *PromoHandler) Create(c *gin.Context) {
+	var req hotel.CreatePromoCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	promo, err := h.service.Create(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": promo})
+}
+
+// GET /api/promo-codes?active=true
+func (h *PromoHandler) List(c *gin.Context) {
+	activeOnly := c.Query("active") == "true"
+
+	promos, err := h.service.List(activeOnly)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": promos})
+}
+
+// PUT /api/promo-codes/:id
+func (h *PromoHandler) Update(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req hotel.UpdatePromoCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	promo, err := h.service.Update(uint(id), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": promo})
+}
+
+// DELETE /api/promo-codes/:id
+func (h *PromoHandler) Delete(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.service.Delete(uint(id)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "promo code deleted"})
+}
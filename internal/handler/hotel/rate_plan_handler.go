@@ -0,0 +1,147 @@
+// internal/handler/hotel/rate_plan_handler.go
+package hotel
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/internal/models/hotel"
+	"backend/internal/service/hotelservice"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RatePlanHandler struct {
+	service hotelservice.RatePlanService
+}
+
+func NewRatePlanHandler(service hotelservice.RatePlanService) *RatePlanHandler {
+	return &RatePlanHandler{service: service}
+}
+
+// POST /api/rate-plans
+func (h *RatePlanHandler) Create(c *gin.Context) {
+	var req hotel.CreateRatePlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plan, err := h.service.Create(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": plan})
+}
+
+// GET /api/rate-plans?room_type_id=
+func (h *RatePlanHandler) List(c *gin.Context) {
+	roomTypeID, _ := strconv.ParseUint(c.Query("room_type_id"), 10, 32)
+
+	plans, err := h.service.List(uint(roomTypeID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": plans})
+}
+
+// PUT /api/rate-plans/:id
+func (h *RatePlanHandler) Update(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req hotel.UpdateRatePlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plan, err := h.service.Update(uint(id), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": plan})
+}
+
+// DELETE /api/rate-plans/:id
+func (h *RatePlanHandler) Delete(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.service.Delete(uint(id)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "rate plan deleted"})
+}
+
+// PUT /api/rate-overrides  (buat atau timpa harga satu malam)
+func (h *RatePlanHandler) SetOverride(c *gin.Context) {
+	var req hotel.SetRateOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	o, err := h.service.SetOverride(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": o})
+}
+
+// GET /api/rate-overrides?room_type_id=&from=YYYY-MM-DD&to=YYYY-MM-DD
+func (h *RatePlanHandler) ListOverrides(c *gin.Context) {
+	roomTypeID, _ := strconv.ParseUint(c.Query("room_type_id"), 10, 32)
+
+	var from, to time.Time
+	if v := c.Query("from"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from, use YYYY-MM-DD"})
+			return
+		}
+		from = t
+	}
+	if v := c.Query("to"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to, use YYYY-MM-DD"})
+			return
+		}
+		to = t
+	}
+
+	overrides, err := h.service.ListOverrides(uint(roomTypeID), from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": overrides})
+}
+
+// DELETE /api/rate-overrides/:id
+func (h *RatePlanHandler) DeleteOverride(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.service.DeleteOverride(uint(id)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "rate override deleted"})
+}
@@ -0,0 +1,52 @@
+// internal/handler/hotel/report_handler.go
+package hotel
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/internal/service/hotelservice"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportHandler struct {
+	service hotelservice.ReportService
+}
+
+func NewReportHandler(service hotelservice.ReportService) *ReportHandler {
+	return &ReportHandler{service: service}
+}
+
+// GET /api/reports/kpi?start=2025-01-01&end=2025-04-01&group_by=day|week|month&room_type_id=
+// end eksklusif; default bulan berjalan
+func (h *ReportHandler) KPI(c *gin.Context) {
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if v := c.Query("start"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "format start harus YYYY-MM-DD"})
+			return
+		}
+		start = t
+	}
+	end := time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	if v := c.Query("end"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "format end harus YYYY-MM-DD"})
+			return
+		}
+		end = t
+	}
+	roomTypeID, _ := strconv.ParseUint(c.Query("room_type_id"), 10, 32)
+
+	report, err := h.service.KPI(start, end, c.Query("group_by"), uint(roomTypeID))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
@@ -0,0 +1,99 @@
+// internal/handler/hotel/reservation_handler.go
+package hotel
+
+import (
+	"errors"
+
+	"backend/internal/models/hotel"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Reservasi: header beberapa booking dengan kode konfirmasi (MTR-XXXXX)
+
+// GET /public/reservations/lookup?code=MTR-7K3Q9&email= (tanpa login)
+func (h *BookingHandler) LookupReservation(c *gin.Context) {
+	var req hotel.ReservationLookupRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.badRequest(c, "invalid query parameters: "+err.Error())
+		return
+	}
+
+	res, err := h.service.LookupReservation(req.Code, req.Email)
+	if err != nil {
+		h.reservationError(c, err)
+		return
+	}
+	h.ok(c, res)
+}
+
+// GET /api/reservations/:code
+func (h *BookingHandler) GetReservation(c *gin.Context) {
+	res, err := h.service.GetReservation(c.Param("code"))
+	if err != nil {
+		h.reservationError(c, err)
+		return
+	}
+	h.ok(c, res)
+}
+
+// PATCH /api/reservations/:code/confirm
+func (h *BookingHandler) ConfirmReservation(c *gin.Context) {
+	res, err := h.service.ConfirmReservation(c.Param("code"))
+	if err != nil {
+		h.reservationError(c, err)
+		return
+	}
+	h.ok(c, res)
+}
+
+// PATCH /api/reservations/:code/cancel
+func (h *BookingHandler) CancelReservation(c *gin.Context) {
+	// Body opsional: {"reason": "..."}
+	var req hotel.CancelBookingRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.badRequest(c, "invalid request body: "+err.Error())
+			return
+		}
+	}
+
+	result, err := h.service.CancelReservation(c.Param("code"), req.Reason)
+	if err != nil {
+		h.reservationError(c, err)
+		return
+	}
+	h.ok(c, result)
+}
+
+// PATCH /public/me/reservations/:code/cancel
+func (h *BookingHandler) MyCancelReservation(c *gin.Context) {
+	uid, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req hotel.CancelBookingRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.badRequest(c, "invalid request body: "+err.Error())
+			return
+		}
+	}
+
+	result, err := h.service.CancelReservationMine(c.Param("code"), uid, req.Reason)
+	if err != nil {
+		h.reservationError(c, err)
+		return
+	}
+	h.ok(c, result)
+}
+
+func (h *BookingHandler) reservationError(c *gin.Context, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		h.notFound(c, "reservation not found")
+		return
+	}
+	h.badRequest(c, err.Error())
+}
@@ -33,12 +33,12 @@ func (h *ReviewHandler) Create(c *gin.Context) {
 		return
 	}
 
-	adminIDVal, exists := c.Get("user_id")
+	guestIDVal, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Login diperlukan"})
 		return
 	}
-	adminID := adminIDVal.(uint)
+	guestID := guestIDVal.(uint)
 
 	roleVal, exists := c.Get("role")
 	if !exists {
@@ -56,7 +56,7 @@ func (h *ReviewHandler) Create(c *gin.Context) {
 		Rating:    input.Rating,
 		Comment:   input.Comment,
 		GuestName: input.GuestName,
-	}, c.ClientIP(), adminID)
+	}, c.ClientIP(), guestID)
 
 	if err != nil {
 		if err.Error() == "rate limit exceeded" {
@@ -0,0 +1,92 @@
+// internal/handler/hotel/stay_restriction_handler.go
+package hotel
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"backend/internal/models/hotel"
+	"backend/internal/service/hotelservice"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type StayRestrictionHandler struct {
+	service hotelservice.StayRestrictionService
+}
+
+func NewStayRestrictionHandler(service hotelservice.StayRestrictionService) *StayRestrictionHandler {
+	return &StayRestrictionHandler{service: service}
+}
+
+// POST /api/stay-restrictions
+// Body: {"room_type_id": 1, "name": "Tahun Baru", "start_date": "2025-12-30", "end_date": "2026-01-01", "min_nights": 3, "closed_to_arrival": false}
+func (h *StayRestrictionHandler) Create(c *gin.Context) {
+	var req hotel.CreateStayRestrictionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	restriction, err := h.service.Create(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": restriction})
+}
+
+// GET /api/stay-restrictions?room_type_id=
+func (h *StayRestrictionHandler) List(c *gin.Context) {
+	roomTypeID, _ := strconv.ParseUint(c.Query("room_type_id"), 10, 32)
+
+	restrictions, err := h.service.List(uint(roomTypeID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": restrictions})
+}
+
+// PUT /api/stay-restrictions/:id
+func (h *StayRestrictionHandler) Update(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req hotel.UpdateStayRestrictionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	restriction, err := h.service.Update(uint(id), req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "stay restriction not found"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": restriction})
+}
+
+// DELETE /api/stay-restrictions/:id
+func (h *StayRestrictionHandler) Delete(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.service.Delete(uint(id)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "stay restriction deleted"})
+}
@@ -0,0 +1,83 @@
+// internal/handler/hotel/waitlist_handler.go
+package hotel
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"backend/internal/models/hotel"
+	"backend/internal/repository/repohotel"
+	"backend/internal/service/hotelservice"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type WaitlistHandler struct {
+	service hotelservice.WaitlistService
+}
+
+func NewWaitlistHandler(service hotelservice.WaitlistService) *WaitlistHandler {
+	return &WaitlistHandler{service: service}
+}
+
+// POST /public/waitlist (guest) — hanya bila tipe kamar penuh di tanggal tersebut
+func (h *WaitlistHandler) Join(c *gin.Context) {
+	var req hotel.JoinWaitlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.service.Join(c.GetUint("user_id"), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": entry})
+}
+
+// GET /public/me/waitlist
+func (h *WaitlistHandler) ListMine(c *gin.Context) {
+	entries, err := h.service.ListMine(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": entries})
+}
+
+// DELETE /public/me/waitlist/:id
+func (h *WaitlistHandler) Leave(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	if err := h.service.Leave(uint(id), c.GetUint("user_id")); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "waitlist tidak ditemukan"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Keluar dari waitlist"})
+}
+
+// GET /api/waitlist?status=&room_type_id=
+func (h *WaitlistHandler) List(c *gin.Context) {
+	f := repohotel.WaitlistFilter{Status: c.Query("status")}
+	if rt, _ := strconv.ParseUint(c.Query("room_type_id"), 10, 32); rt != 0 {
+		f.RoomTypeID = uint(rt)
+	}
+
+	entries, err := h.service.List(f)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": entries})
+}
@@ -24,12 +24,17 @@ import (
 	"backend/internal/service/cafeservice"
 	"backend/internal/repository/repocafe"
 
-	"backend/internal/repository/admin"
+	"backend/internal/repository/guest"
+
+	"backend/internal/handler/taxhandler"
+
+	"backend/internal/handler/schedulerhandler"
+	"backend/internal/service/schedulerservice"
 	"gorm.io/gorm"
 )
 
 // TAMBAH PARAMETER db
-func SetupRoutes(r *gin.Engine, adminService serviceauth.AdminService, db *gorm.DB) {
+func SetupRoutes(r *gin.Engine, adminService serviceauth.AdminService, services *Services, sched schedulerservice.Scheduler, db *gorm.DB) {
 	r.NoRoute(func(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "route not found"})
 	})
@@ -41,11 +46,11 @@ func SetupRoutes(r *gin.Engine, adminService serviceauth.AdminService, db *gorm.
 	r.POST("/admins/login", adm.Login)
 	r.GET("/admins/profile", middleware.AuthMiddleware(), adm.GetProfile)
 
-	// INISIALISASI ADMIN REPOSITORY
-	adminRepo := admin.NewAdminRepository(db)
+	// INISIALISASI GUEST REPOSITORY (akun tamu terpisah dari admins)
+	guestRepo := guest.NewGuestRepository(db)
 
 	// === REPO & HANDLER ===
-	galleryH := hotel.NewGalleryHandler(hotelservice.NewGalleryService(repohotel.NewGalleryRepository(db)))
+	galleryH := hotel.NewGalleryHandler(hotelservice.NewGalleryService(repohotel.NewGalleryRepository(db), repohotel.NewRoomTypeRepository(db)))
 	newsH := hotel.NewNewsHandler(hotelservice.NewNewsService(repohotel.NewNewsRepository(db)))
 	visionMissionH := hotel.NewVisionMissionHandler(hotelservice.NewVisionMissionService(repohotel.NewVisionMissionRepository(db)))
 
@@ -69,13 +74,66 @@ func SetupRoutes(r *gin.Engine, adminService serviceauth.AdminService, db *gorm.
 
 	// REVIEW HOTEL
 	reviewRepo := repohotel.NewReviewRepository(db)
-	reviewService := hotelservice.NewReviewService(reviewRepo, adminRepo)
+	reviewService := hotelservice.NewReviewService(reviewRepo, guestRepo)
 	reviewH := hotel.NewReviewHandler(reviewService)
 
-	// BOOKING → PASS db
+	// RATE PLAN
+	ratePlanH := hotel.NewRatePlanHandler(services.RatePlan)
+
+	// ATURAN LAMA MENGINAP (min/max malam, CTA/CTD, stop-sell)
+	restrictionH := hotel.NewStayRestrictionHandler(services.Restriction)
+
+	// PROMO
+	promoH := hotel.NewPromoHandler(services.Promo)
+
+	// CANCELLATION POLICY
+	policyH := hotel.NewCancellationPolicyHandler(services.Policy)
+
+	// PAJAK & SERVICE CHARGE (per business unit)
+	taxH := taxhandler.NewTaxHandler(services.Tax)
+
+	// SCHEDULER (riwayat & pemicu manual job latar belakang)
+	schedulerH := schedulerhandler.NewSchedulerHandler(sched)
+
+	// BOOKING (service dibuat sekali di main, dipakai bersama background job)
 	bookingRepo := repohotel.NewBookingRepository(db)
-	bookingService := hotelservice.NewBookingService(bookingRepo, repohotel.NewRoomRepository(db), db)
-	bookingH := hotel.NewBookingHandler(bookingService)
+	paymentRepo := repohotel.NewPaymentRepository(db)
+	waitlistH := hotel.NewWaitlistHandler(services.Waitlist)
+	addOnH := hotel.NewAddOnHandler(services.AddOn)
+	bookingH := hotel.NewBookingHandler(services.Booking)
+
+	// PAYMENT
+	paymentH := hotel.NewPaymentHandler(services.Payment)
+
+	// ICAL (sinkronisasi OTA)
+	icalH := hotel.NewICalHandler(services.ICal)
+
+	// HOUSEKEEPING
+	housekeepingH := hotel.NewHousekeepingHandler(services.Housekeeping)
+
+	// FOLIO (tagihan cafe/souvenir yang dibebankan ke kamar)
+	folioH := hotel.NewFolioHandler(services.Folio)
+	cafeRoomChargeH := cafehandler.NewRoomChargeHandler(cafeservice.NewRoomChargeService(cafeProductRepo, services.Folio, db))
+	souvenirRoomChargeH := souvenirhandler.NewRoomChargeHandler(souvenirservice.NewRoomChargeService(reposouvenir.NewProductRepository(db), services.Folio, db))
+	bookRoomChargeH := bookhandler.NewRoomChargeHandler(bookservice.NewRoomChargeService(bookProductRepo, services.Folio, db))
+
+	// FRONT DESK (check-in/check-out manual)
+	frontDeskH := hotel.NewFrontDeskHandler(services.FrontDesk)
+
+	// GUEST (profil tamu & riwayat menginap)
+	guestH := hotel.NewGuestHandler(hotelservice.NewGuestService(guestRepo, bookingRepo))
+
+	// OCCUPANCY (tape chart)
+	occupancyH := hotel.NewOccupancyHandler(hotelservice.NewOccupancyService(repohotel.NewOccupancyRepository(db)))
+
+	// MAINTENANCE (kamar out of order)
+	maintenanceH := hotel.NewMaintenanceHandler(hotelservice.NewMaintenanceService(repohotel.NewMaintenanceRepository(db), bookingRepo, db))
+
+	// LAPORAN KPI (okupansi, ADR, RevPAR)
+	reportH := hotel.NewReportHandler(hotelservice.NewReportService(repohotel.NewReportRepository(db)))
+
+	// INVOICE PDF
+	invoiceH := hotel.NewInvoiceHandler(hotelservice.NewInvoiceService(bookingRepo, paymentRepo))
 
 
 		// Di dalam SetupRoutes
@@ -91,6 +149,7 @@ func SetupRoutes(r *gin.Engine, adminService serviceauth.AdminService, db *gorm.
 	public := r.Group("/public")
 	{
 		public.GET("/rooms", roomH.ListPublic)
+		public.GET("/room-types", roomTypeH.List)
 		public.GET("/gallery", galleryH.ListPublic)
 		public.GET("/gallery/:id", galleryH.GetByID)
 		public.GET("/rooms/:id/gallery", galleryH.ListByRoom)
@@ -119,6 +178,32 @@ func SetupRoutes(r *gin.Engine, adminService serviceauth.AdminService, db *gorm.
 		public.POST("/bookings", middleware.AuthMiddleware(), middleware.RoleMiddleware(auth.RoleGuest), bookingH.Create)
 		public.POST("/guest-bookings", middleware.AuthMiddleware(), middleware.RoleMiddleware(auth.RoleGuest), bookingH.GuestBook)
 		public.GET("/availability", bookingH.CheckAvailability)
+		public.GET("/add-ons", addOnH.ListPublic)
+		public.GET("/reservations/lookup", bookingH.LookupReservation)
+		public.POST("/waitlist", middleware.AuthMiddleware(), middleware.RoleMiddleware(auth.RoleGuest), waitlistH.Join)
+		public.POST("/waitlist/claim", middleware.AuthMiddleware(), middleware.RoleMiddleware(auth.RoleGuest), bookingH.ClaimWaitlist)
+		public.PATCH("/bookings/:id", middleware.AuthMiddleware(), middleware.RoleMiddleware(auth.RoleGuest), bookingH.GuestModify)
+		public.POST("/bookings/:id/pay", middleware.AuthMiddleware(), middleware.RoleMiddleware(auth.RoleGuest), paymentH.Pay)
+		public.POST("/payments/webhook", paymentH.Webhook)
+		public.GET("/ical/rooms/:id", icalH.RoomFeed)
+		public.GET("/ical/room-types/:id", icalH.RoomTypeFeed)
+
+		// Portal guest: booking milik sendiri
+		me := public.Group("/me", middleware.AuthMiddleware(), middleware.RoleMiddleware(auth.RoleGuest))
+		me.GET("/bookings", bookingH.MyBookings)
+		me.GET("/bookings/:id", bookingH.MyBooking)
+		me.GET("/bookings/:id/cancellation-quote", bookingH.MyCancellationQuote)
+		me.PATCH("/bookings/:id/cancel", bookingH.MyCancel)
+		me.GET("/bookings/:id/summary", bookingH.MySummary)
+		me.GET("/bookings/:id/invoice", invoiceH.DownloadMine)
+		me.GET("/bookings/:id/folio", folioH.GetMine)
+		me.POST("/bookings/:id/add-ons", addOnH.AttachMine)
+		me.DELETE("/bookings/:id/add-ons/:item_id", addOnH.RemoveMine)
+		me.PATCH("/reservations/:code/cancel", bookingH.MyCancelReservation)
+		me.GET("/profile", guestH.MyProfile)
+		me.PATCH("/profile", guestH.UpdateMyProfile)
+		me.GET("/waitlist", waitlistH.ListMine)
+		me.DELETE("/waitlist/:id", waitlistH.Leave)
 	}
 
 	// === ADMIN API ===
@@ -129,6 +214,24 @@ func SetupRoutes(r *gin.Engine, adminService serviceauth.AdminService, db *gorm.
 	{
 		super.GET("/pending-admins", adm.GetPending)
 		super.PATCH("/admins/approve/:id", adm.ApproveUser)
+
+		// Pajak & service charge
+		super.POST("/tax-rates", taxH.Create)
+		super.GET("/tax-rates", taxH.List)
+		super.PUT("/tax-rates/:id", taxH.Update)
+		super.DELETE("/tax-rates/:id", taxH.Delete)
+		super.GET("/reports/taxes", taxH.Report)
+
+		// Job latar belakang
+		super.GET("/jobs", schedulerH.List)
+		super.GET("/jobs/runs", schedulerH.Runs)
+		super.POST("/jobs/:name/run", schedulerH.Trigger)
+	}
+
+	// SEMUA ADMIN (simulasi pajak untuk kasir outlet)
+	staff := adminGroup.Group("", middleware.RoleMiddleware(auth.RoleAdminHotel, auth.RoleAdminSouvenir, auth.RoleAdminBuku, auth.RoleAdminCafe, auth.RoleSuperAdmin))
+	{
+		staff.GET("/tax-rates/calculate", taxH.Calculate)
 	}
 
 	// HOTEL
@@ -148,6 +251,78 @@ func SetupRoutes(r *gin.Engine, adminService serviceauth.AdminService, db *gorm.
 		hotelGroup.PUT("/room-types/:id", roomTypeH.Update)
 		hotelGroup.DELETE("/room-types/:id", roomTypeH.Delete)
 
+		// Rate plan & harga per malam
+		hotelGroup.POST("/rate-plans", ratePlanH.Create)
+		hotelGroup.GET("/rate-plans", ratePlanH.List)
+		hotelGroup.PUT("/rate-plans/:id", ratePlanH.Update)
+		hotelGroup.DELETE("/rate-plans/:id", ratePlanH.Delete)
+		hotelGroup.PUT("/rate-overrides", ratePlanH.SetOverride)
+		hotelGroup.GET("/rate-overrides", ratePlanH.ListOverrides)
+		hotelGroup.DELETE("/rate-overrides/:id", ratePlanH.DeleteOverride)
+
+		// Aturan lama menginap
+		hotelGroup.POST("/stay-restrictions", restrictionH.Create)
+		hotelGroup.GET("/stay-restrictions", restrictionH.List)
+		hotelGroup.PUT("/stay-restrictions/:id", restrictionH.Update)
+		hotelGroup.DELETE("/stay-restrictions/:id", restrictionH.Delete)
+
+		// Kebijakan pembatalan
+		hotelGroup.POST("/cancellation-policies", policyH.Create)
+		hotelGroup.GET("/cancellation-policies", policyH.List)
+		hotelGroup.PUT("/cancellation-policies/:id", policyH.Update)
+		hotelGroup.DELETE("/cancellation-policies/:id", policyH.Delete)
+
+		// Guest
+		hotelGroup.GET("/guests", guestH.List)
+		hotelGroup.GET("/guests/:id", guestH.Get)
+
+		// Reservasi (grup booking)
+		hotelGroup.GET("/reservations/:code", bookingH.GetReservation)
+		hotelGroup.PATCH("/reservations/:code/confirm", bookingH.ConfirmReservation)
+		hotelGroup.PATCH("/reservations/:code/cancel", bookingH.CancelReservation)
+
+		// Waitlist
+		hotelGroup.GET("/waitlist", waitlistH.List)
+
+		// Occupancy grid (tape chart)
+		hotelGroup.GET("/occupancy", occupancyH.Grid)
+
+		// Maintenance / out of order
+		hotelGroup.POST("/maintenance-blocks", maintenanceH.Create)
+		hotelGroup.GET("/maintenance-blocks", maintenanceH.List)
+		hotelGroup.DELETE("/maintenance-blocks/:id", maintenanceH.Delete)
+
+		// Laporan KPI
+		hotelGroup.GET("/reports/kpi", reportH.KPI)
+
+		// Housekeeping
+		hotelGroup.GET("/housekeeping/board", housekeepingH.Board)
+		hotelGroup.GET("/housekeeping/tasks", housekeepingH.List)
+		hotelGroup.POST("/housekeeping/tasks", housekeepingH.Create)
+		hotelGroup.PATCH("/housekeeping/tasks/:id/assign", housekeepingH.Assign)
+		hotelGroup.PATCH("/housekeeping/tasks/:id/start", housekeepingH.Start)
+		hotelGroup.PATCH("/housekeeping/tasks/:id/clean", housekeepingH.Clean)
+		hotelGroup.PATCH("/housekeeping/tasks/:id/inspect", housekeepingH.Inspect)
+
+		// Feed iCal OTA
+		hotelGroup.POST("/ical-feeds", icalH.CreateFeed)
+		hotelGroup.GET("/ical-feeds", icalH.ListFeeds)
+		hotelGroup.PUT("/ical-feeds/:id", icalH.UpdateFeed)
+		hotelGroup.DELETE("/ical-feeds/:id", icalH.DeleteFeed)
+		hotelGroup.POST("/ical-feeds/:id/sync", icalH.SyncFeed)
+
+		// Kode promo / voucher
+		hotelGroup.POST("/promo-codes", promoH.Create)
+		hotelGroup.GET("/promo-codes", promoH.List)
+		hotelGroup.PUT("/promo-codes/:id", promoH.Update)
+		hotelGroup.DELETE("/promo-codes/:id", promoH.Delete)
+
+		// Katalog add-on (sarapan, extra bed, airport pickup)
+		hotelGroup.POST("/add-ons", addOnH.Create)
+		hotelGroup.GET("/add-ons", addOnH.List)
+		hotelGroup.PUT("/add-ons/:id", addOnH.Update)
+		hotelGroup.DELETE("/add-ons/:id", addOnH.Delete)
+
 		hotelGroup.POST("/galleries", galleryH.Create)
 		hotelGroup.GET("/galleries", galleryH.List)
 		hotelGroup.GET("/galleries/:id", galleryH.GetByID)
@@ -172,8 +347,22 @@ func SetupRoutes(r *gin.Engine, adminService serviceauth.AdminService, db *gorm.
 
 		// Admin
 		hotelGroup.GET("/bookings", bookingH.List)
+		hotelGroup.PATCH("/bookings/:id", bookingH.Modify)
+		hotelGroup.GET("/bookings/:id/changes", bookingH.Changes)
 		hotelGroup.PATCH("/bookings/:id/confirm", bookingH.Confirm)
+		hotelGroup.PATCH("/bookings/:id/check-in", frontDeskH.CheckIn)
+		hotelGroup.PATCH("/bookings/:id/check-out", frontDeskH.CheckOut)
 		hotelGroup.PATCH("/bookings/:id/cancel", bookingH.Cancel)
+		hotelGroup.GET("/bookings/:id/cancellation-quote", bookingH.CancellationQuote)
+		hotelGroup.GET("/bookings/:id/invoice", invoiceH.Download)
+		hotelGroup.POST("/bookings/:id/invoice/email", invoiceH.Email)
+		hotelGroup.GET("/bookings/:id/payments", paymentH.ListByBooking)
+		hotelGroup.GET("/bookings/:id/folio", folioH.Get)
+		hotelGroup.POST("/bookings/:id/folio/charges", folioH.Charge)
+		hotelGroup.POST("/bookings/:id/folio/payments", folioH.Pay)
+		hotelGroup.POST("/bookings/:id/folio/adjustments", folioH.Adjust)
+		hotelGroup.POST("/bookings/:id/add-ons", addOnH.Attach)
+		hotelGroup.DELETE("/bookings/:id/add-ons/:item_id", addOnH.Remove)
 	}
 
 	
@@ -192,6 +381,9 @@ func SetupRoutes(r *gin.Engine, adminService serviceauth.AdminService, db *gorm.
 		souvenirGroup.PUT("/products/:id", souvenirProductH.UpdateProduct)
 		souvenirGroup.DELETE("/products/:id", souvenirProductH.DeleteProduct)
 		souvenirGroup.GET("/products/category/:category_id", souvenirProductH.GetProductsByCategory)
+
+		// Beli souvenir, bayar saat check-out (folio kamar)
+		souvenirGroup.POST("/souvenir-room-charges", souvenirRoomChargeH.Create)
 	}
 
 	// BOOK
@@ -207,6 +399,9 @@ func SetupRoutes(r *gin.Engine, adminService serviceauth.AdminService, db *gorm.
 		bookGroup.GET("/books", bookProductH.ListBooks)
 		bookGroup.PUT("/books/:id", bookProductH.UpdateProduct)
 		bookGroup.DELETE("/books/:id", bookProductH.DeleteProduct)
+
+		// Beli buku, bayar saat check-out (folio kamar)
+		bookGroup.POST("/book-room-charges", bookRoomChargeH.Create)
 	}
 
 	// CAFE
@@ -223,5 +418,8 @@ func SetupRoutes(r *gin.Engine, adminService serviceauth.AdminService, db *gorm.
 		cafeGroup.GET("/cafe-products", cafeProductH.ListProducts)
 		cafeGroup.GET("/cafe-products/:id", cafeProductH.GetProduct)
 		cafeGroup.GET("/cafe-categories/:category_id/products", cafeProductH.GetProductsByCategory)
+
+		// Pesanan cafe yang dibebankan ke kamar (folio)
+		cafeGroup.POST("/cafe-room-charges", cafeRoomChargeH.Create)
 	}
 }
\ No newline at end of file
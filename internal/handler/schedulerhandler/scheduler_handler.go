@@ -0,0 +1,78 @@
+// internal/handler/schedulerhandler/scheduler_handler.go
+package schedulerhandler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"backend/internal/repository/reposcheduler"
+	"backend/internal/service/schedulerservice"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SchedulerHandler struct {
+	service schedulerservice.Scheduler
+}
+
+func NewSchedulerHandler(service schedulerservice.Scheduler) *SchedulerHandler {
+	return &SchedulerHandler{service: service}
+}
+
+// GET /api/jobs (job terdaftar, jadwal, run berikutnya & terakhir)
+func (h *SchedulerHandler) List(c *gin.Context) {
+	jobs, err := h.service.Jobs()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": jobs})
+}
+
+// GET /api/jobs/runs?job=hold-expiry&status=failed&limit=20&offset=0
+func (h *SchedulerHandler) Runs(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit <= 0 {
+		limit = 20
+	}
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+
+	runs, total, err := h.service.ListRuns(reposcheduler.RunFilter{
+		Job:    c.Query("job"),
+		Status: c.Query("status"),
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data":   runs,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// POST /api/jobs/:name/run
+// Job dijalankan di latar belakang; status akhirnya dilihat di /api/jobs/runs
+func (h *SchedulerHandler) Trigger(c *gin.Context) {
+	run, err := h.service.Trigger(c.Param("name"), c.GetUint("user_id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, schedulerservice.ErrJobNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, schedulerservice.ErrJobLocked):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"data": run})
+}
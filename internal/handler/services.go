@@ -0,0 +1,59 @@
+// internal/handler/services.go
+package handler
+
+import (
+	"backend/internal/payment"
+	"backend/internal/repository/repohotel"
+	"backend/internal/repository/repotax"
+	"backend/internal/service/hotelservice"
+	"backend/internal/service/taxservice"
+
+	"gorm.io/gorm"
+)
+
+// Services: service hotel yang dipakai bersama oleh route API dan background job.
+// Dibuat sekali di main supaya keduanya berbagi instance & konfigurasi yang sama.
+type Services struct {
+	Tax          taxservice.TaxService
+	RatePlan     hotelservice.RatePlanService
+	Restriction  hotelservice.StayRestrictionService
+	Promo        hotelservice.PromoService
+	Policy       hotelservice.CancellationPolicyService
+	Waitlist     hotelservice.WaitlistService
+	AddOn        hotelservice.AddOnService
+	Booking      hotelservice.BookingService
+	Payment      hotelservice.PaymentService
+	ICal         hotelservice.ICalService
+	Housekeeping hotelservice.HousekeepingService
+	Folio        hotelservice.FolioService
+	FrontDesk    hotelservice.FrontDeskService
+}
+
+func NewServices(db *gorm.DB) (*Services, error) {
+	provider, err := payment.NewProviderFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	roomRepo := repohotel.NewRoomRepository(db)
+	roomTypeRepo := repohotel.NewRoomTypeRepository(db)
+	ratePlanRepo := repohotel.NewRatePlanRepository(db)
+	bookingRepo := repohotel.NewBookingRepository(db)
+	paymentRepo := repohotel.NewPaymentRepository(db)
+
+	s := &Services{}
+	s.Tax = taxservice.NewTaxService(repotax.NewTaxRepository(db))
+	s.RatePlan = hotelservice.NewRatePlanService(ratePlanRepo, roomTypeRepo)
+	s.Restriction = hotelservice.NewStayRestrictionService(repohotel.NewStayRestrictionRepository(db), roomTypeRepo)
+	s.Promo = hotelservice.NewPromoService(repohotel.NewPromoRepository(db), roomTypeRepo)
+	s.Policy = hotelservice.NewCancellationPolicyService(repohotel.NewCancellationPolicyRepository(db), ratePlanRepo, roomTypeRepo)
+	s.Waitlist = hotelservice.NewWaitlistService(repohotel.NewWaitlistRepository(db), bookingRepo, roomTypeRepo, db)
+	s.AddOn = hotelservice.NewAddOnService(repohotel.NewAddOnRepository(db), bookingRepo, s.Tax, db)
+	s.Booking = hotelservice.NewBookingService(bookingRepo, roomRepo, paymentRepo, s.RatePlan, s.Promo, s.Policy, s.Restriction, s.AddOn, s.Tax, s.Waitlist, db)
+	s.Payment = hotelservice.NewPaymentService(paymentRepo, bookingRepo, provider, db)
+	s.ICal = hotelservice.NewICalService(repohotel.NewICalRepository(db), bookingRepo, roomRepo, roomTypeRepo, db)
+	s.Housekeeping = hotelservice.NewHousekeepingService(repohotel.NewHousekeepingRepository(db), roomRepo, db)
+	s.Folio = hotelservice.NewFolioService(repohotel.NewFolioRepository(db), bookingRepo, s.Tax, db)
+	s.FrontDesk = hotelservice.NewFrontDeskService(bookingRepo, paymentRepo, s.Housekeeping, s.Folio, s.Promo, s.AddOn, s.Tax, db)
+	return s, nil
+}
@@ -0,0 +1,35 @@
+// backend/internal/handler/souvenirhandler/room_charge_handler.go
+package souvenirhandler
+
+import (
+	"net/http"
+
+	"backend/internal/models/souvenir"
+	"backend/internal/service/souvenirservice"
+	"github.com/gin-gonic/gin"
+)
+
+type RoomChargeHandler struct {
+	service souvenirservice.RoomChargeService
+}
+
+func NewRoomChargeHandler(service souvenirservice.RoomChargeService) *RoomChargeHandler {
+	return &RoomChargeHandler{service}
+}
+
+// POST /api/souvenir-room-charges
+// Body: {"room_number": "101", "items": [{"product_id": 1, "quantity": 2}]}
+func (h *RoomChargeHandler) Create(c *gin.Context) {
+	var input souvenir.RoomChargeRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	folio, err := h.service.ChargeToRoom(input, c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Tagihan diposting ke kamar", "data": folio})
+}
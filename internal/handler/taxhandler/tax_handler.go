@@ -0,0 +1,139 @@
+// internal/handler/taxhandler/tax_handler.go
+package taxhandler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/internal/models/tax"
+	"backend/internal/service/taxservice"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TaxHandler struct {
+	service taxservice.TaxService
+}
+
+func NewTaxHandler(service taxservice.TaxService) *TaxHandler {
+	return &TaxHandler{service: service}
+}
+
+// POST /api/tax-rates
+// Body: {"business_unit": "cafe", "code": "PB1", "name": "Pajak Restoran", "rate": 10, "inclusive": false}
+func (h *TaxHandler) Create(c *gin.Context) {
+	var req tax.CreateTaxRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rate, err := h.service.Create(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": rate})
+}
+
+// GET /api/tax-rates?business_unit=hotel
+func (h *TaxHandler) List(c *gin.Context) {
+	rates, err := h.service.List(c.Query("business_unit"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": rates})
+}
+
+// PUT /api/tax-rates/:id
+func (h *TaxHandler) Update(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req tax.UpdateTaxRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rate, err := h.service.Update(uint(id), req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "tax rate not found"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": rate})
+}
+
+// DELETE /api/tax-rates/:id
+func (h *TaxHandler) Delete(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.service.Delete(uint(id)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "tax rate deleted"})
+}
+
+// GET /api/tax-rates/calculate?business_unit=cafe&amount=50000
+// Simulasi pajak untuk harga setelah diskon, dipakai kasir outlet
+func (h *TaxHandler) Calculate(c *gin.Context) {
+	amount, err := strconv.ParseInt(c.Query("amount"), 10, 64)
+	if err != nil || amount < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "amount tidak valid"})
+		return
+	}
+
+	breakdown, err := h.service.Calculate(c.Query("business_unit"), amount)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": breakdown})
+}
+
+// GET /api/reports/taxes?start=2025-01-01&end=2025-02-01&business_unit=
+// end eksklusif; default bulan berjalan
+func (h *TaxHandler) Report(c *gin.Context) {
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if v := c.Query("start"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "format start harus YYYY-MM-DD"})
+			return
+		}
+		start = t
+	}
+	end := time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	if v := c.Query("end"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "format end harus YYYY-MM-DD"})
+			return
+		}
+		end = t
+	}
+
+	report, err := h.service.Report(start, end, c.Query("business_unit"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
@@ -0,0 +1,189 @@
+// internal/ical/ical.go
+// Encode & parse iCalendar (RFC 5545) secukupnya untuk sinkronisasi ketersediaan
+// kamar dengan OTA: hanya VEVENT all-day dengan UID, SUMMARY, DTSTART, DTEND, STATUS.
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const dateLayout = "20060102"
+
+// Event: satu rentang tanggal terpakai. End eksklusif (tanggal check-out).
+type Event struct {
+	UID       string
+	Summary   string
+	Start     time.Time
+	End       time.Time
+	Cancelled bool
+}
+
+// Encode: VCALENDAR berisi events, baris diakhiri CRLF dan dilipat di 75 oktet
+func Encode(calName string, events []Event) []byte {
+	var b strings.Builder
+	write := func(line string) {
+		for len(line) > 75 {
+			// jangan memotong di tengah karakter UTF-8
+			cut := 75
+			for cut > 1 && !utf8.RuneStart(line[cut]) {
+				cut--
+			}
+			b.WriteString(line[:cut] + "\r\n")
+			line = " " + line[cut:]
+		}
+		b.WriteString(line + "\r\n")
+	}
+
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	write("BEGIN:VCALENDAR")
+	write("VERSION:2.0")
+	write("PRODID:-//Mutiara Hotel//Availability//ID")
+	write("CALSCALE:GREGORIAN")
+	write("METHOD:PUBLISH")
+	write("X-WR-CALNAME:" + escapeText(calName))
+	for _, e := range events {
+		write("BEGIN:VEVENT")
+		write("UID:" + e.UID)
+		write("DTSTAMP:" + stamp)
+		write("DTSTART;VALUE=DATE:" + e.Start.Format(dateLayout))
+		write("DTEND;VALUE=DATE:" + e.End.Format(dateLayout))
+		write("SUMMARY:" + escapeText(e.Summary))
+		write("TRANSP:OPAQUE")
+		write("END:VEVENT")
+	}
+	write("END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// Parse: baca semua VEVENT. Event tanpa DTSTART dilewati.
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var current *Event
+	sawCalendar := false
+	for _, line := range lines {
+		name, params, value := splitProperty(line)
+		switch {
+		case name == "BEGIN" && value == "VCALENDAR":
+			sawCalendar = true
+		case name == "BEGIN" && value == "VEVENT":
+			current = &Event{}
+		case name == "END" && value == "VEVENT":
+			if current != nil && !current.Start.IsZero() {
+				if current.End.IsZero() || !current.End.After(current.Start) {
+					current.End = current.Start.AddDate(0, 0, 1)
+				}
+				events = append(events, *current)
+			}
+			current = nil
+		case current == nil:
+			continue
+		case name == "UID":
+			current.UID = value
+		case name == "SUMMARY":
+			current.Summary = unescapeText(value)
+		case name == "STATUS":
+			current.Cancelled = strings.EqualFold(value, "CANCELLED")
+		case name == "DTSTART":
+			current.Start, err = parseDate(value, params)
+			if err != nil {
+				return nil, err
+			}
+		case name == "DTEND":
+			current.End, err = parseDate(value, params)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if !sawCalendar {
+		return nil, errors.New("bukan file iCalendar (BEGIN:VCALENDAR tidak ditemukan)")
+	}
+	return events, nil
+}
+
+// unfold: gabungkan baris lanjutan (diawali spasi/tab) ke baris sebelumnya
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, sc.Err()
+}
+
+// splitProperty: "DTSTART;VALUE=DATE:20250101" → ("DTSTART", {"VALUE":"DATE"}, "20250101")
+func splitProperty(line string) (string, map[string]string, string) {
+	idx := strings.Index(line, ":")
+	if idx < 0 {
+		return strings.ToUpper(line), nil, ""
+	}
+	head, value := line[:idx], line[idx+1:]
+	parts := strings.Split(head, ";")
+	params := make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		if kv := strings.SplitN(p, "=", 2); len(kv) == 2 {
+			params[strings.ToUpper(kv[0])] = kv[1]
+		}
+	}
+	return strings.ToUpper(parts[0]), params, value
+}
+
+// parseDate: hanya tanggalnya yang dipakai, jam (bila ada) diabaikan karena
+// ketersediaan dihitung per malam
+func parseDate(value string, params map[string]string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("tanggal iCal tidak valid: %q", value)
+	}
+	loc := time.UTC
+	if tz := params["TZID"]; tz != "" {
+		if l, err := time.LoadLocation(tz); err == nil {
+			loc = l
+		}
+	}
+	if len(value) >= 15 && value[8] == 'T' {
+		layout := "20060102T150405"
+		if strings.HasSuffix(value, "Z") {
+			t, err := time.Parse(layout+"Z", value)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("tanggal iCal tidak valid: %q", value)
+			}
+			value = t.In(loc).Format(dateLayout)
+		} else {
+			value = value[:8]
+		}
+	}
+	t, err := time.Parse(dateLayout, value[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("tanggal iCal tidak valid: %q", value)
+	}
+	return t, nil
+}
+
+func escapeText(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+func unescapeText(s string) string {
+	r := strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+	return r.Replace(s)
+}
@@ -0,0 +1,54 @@
+// internal/models/auth/guest.go
+package auth
+
+import "time"
+
+// Jenis identitas tamu
+const (
+	GuestIDTypeKTP      = "ktp"
+	GuestIDTypePassport = "passport"
+	GuestIDTypeSIM      = "sim"
+)
+
+// Guest: akun tamu/pelanggan, terpisah dari akun admin.
+// Login tetap lewat /admins/login dengan role "guest".
+type Guest struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	FullName    string     `gorm:"size:100;not null" json:"full_name"`
+	Email       string     `gorm:"size:100;uniqueIndex;not null" json:"email"`
+	PhoneNumber string     `gorm:"size:20;not null" json:"phone_number"`
+	Password    string     `gorm:"not null" json:"-"`
+	IDType      string     `gorm:"size:20" json:"id_type,omitempty"`
+	IDNumber    string     `gorm:"size:50" json:"id_number,omitempty"`
+	Nationality string     `gorm:"size:50" json:"nationality,omitempty"`
+	DateOfBirth *time.Time `gorm:"type:date" json:"date_of_birth,omitempty"`
+	Address     string     `gorm:"type:text" json:"address,omitempty"`
+	// Preferensi menginap, dipakai front desk saat alokasi kamar
+	Preferences GuestPreferences `gorm:"serializer:json;type:text" json:"preferences"`
+	// Persetujuan menerima promo/newsletter
+	MarketingConsent   bool       `gorm:"default:false" json:"marketing_consent"`
+	MarketingConsentAt *time.Time `json:"marketing_consent_at,omitempty"`
+	LastLoginAt        *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+type GuestPreferences struct {
+	BedType   string `json:"bed_type,omitempty"` // king, twin
+	Smoking   bool   `json:"smoking"`
+	HighFloor bool   `json:"high_floor"`
+	Notes     string `json:"notes,omitempty"`
+}
+
+// Request: field nil berarti tidak diubah
+type UpdateGuestProfileRequest struct {
+	FullName         *string           `json:"full_name" binding:"omitempty,min=1"`
+	PhoneNumber      *string           `json:"phone_number" binding:"omitempty,min=6"`
+	IDType           *string           `json:"id_type" binding:"omitempty,oneof=ktp passport sim"`
+	IDNumber         *string           `json:"id_number"`
+	Nationality      *string           `json:"nationality"`
+	DateOfBirth      *string           `json:"date_of_birth"` // YYYY-MM-DD
+	Address          *string           `json:"address"`
+	Preferences      *GuestPreferences `json:"preferences"`
+	MarketingConsent *bool             `json:"marketing_consent"`
+}
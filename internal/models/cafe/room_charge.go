@@ -0,0 +1,15 @@
+// backend/internal/models/cafe/room_charge.go
+package cafe
+
+// RoomChargeItem: produk cafe yang dibebankan ke kamar tamu
+type RoomChargeItem struct {
+	ProductID uint `json:"product_id" binding:"required"`
+	Quantity  int  `json:"quantity" binding:"required,gt=0"`
+}
+
+// RoomChargeRequest: tujuan lewat booking_id atau nomor kamar tamu yang sedang menginap
+type RoomChargeRequest struct {
+	BookingID  uint             `json:"booking_id"`
+	RoomNumber string           `json:"room_number"`
+	Items      []RoomChargeItem `json:"items" binding:"required,min=1,dive"`
+}
@@ -0,0 +1,88 @@
+// internal/models/hotel/addon.go
+package hotel
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Cara menghitung harga add-on
+type AddOnPricing string
+
+const (
+	AddOnPerStay       AddOnPricing = "per_stay"        // sekali per booking, contoh: airport pickup
+	AddOnPerNight      AddOnPricing = "per_night"       // setiap malam, contoh: extra bed
+	AddOnPerGuest      AddOnPricing = "per_guest"       // setiap tamu sekali, contoh: welcome drink
+	AddOnPerGuestNight AddOnPricing = "per_guest_night" // setiap tamu setiap malam, contoh: sarapan
+)
+
+// Label: keterangan satuan harga di pesan WhatsApp & invoice
+func (p AddOnPricing) Label() string {
+	switch p {
+	case AddOnPerNight:
+		return "per malam"
+	case AddOnPerGuest:
+		return "per tamu"
+	case AddOnPerGuestNight:
+		return "per tamu/malam"
+	default:
+		return "per menginap"
+	}
+}
+
+// AddOn: katalog layanan tambahan yang bisa ditagihkan ke booking
+type AddOn struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	Name        string         `gorm:"size:100;not null" json:"name"`
+	Description string         `gorm:"type:text" json:"description,omitempty"`
+	Pricing     AddOnPricing   `gorm:"type:varchar(20);not null" json:"pricing"`
+	Price       int64          `gorm:"not null" json:"price"`
+	MaxQuantity int            `gorm:"default:0" json:"max_quantity"` // per booking, 0 = tanpa batas
+	Active      bool           `gorm:"default:true" json:"active"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// BookingAddOn: add-on yang ditagihkan ke satu booking.
+// Nama & harga disalin dari katalog supaya tidak berubah bila katalog diedit.
+type BookingAddOn struct {
+	ID        uint         `gorm:"primaryKey" json:"id"`
+	BookingID uint         `gorm:"not null;index" json:"booking_id"`
+	AddOnID   uint         `gorm:"not null;index" json:"add_on_id"`
+	Name      string       `gorm:"size:100;not null" json:"name"`
+	Pricing   AddOnPricing `gorm:"type:varchar(20);not null" json:"pricing"`
+	UnitPrice int64        `gorm:"not null" json:"unit_price"`
+	Quantity  int          `gorm:"not null;default:1" json:"quantity"`
+	Total     int64        `gorm:"not null" json:"total"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// Request
+type CreateAddOnRequest struct {
+	Name        string       `json:"name" binding:"required,max=100"`
+	Description string       `json:"description"`
+	Pricing     AddOnPricing `json:"pricing" binding:"required,oneof=per_stay per_night per_guest per_guest_night"`
+	Price       int64        `json:"price" binding:"required,gt=0"`
+	MaxQuantity int          `json:"max_quantity" binding:"gte=0"`
+}
+
+type UpdateAddOnRequest struct {
+	Name        *string       `json:"name" binding:"omitempty,max=100"`
+	Description *string       `json:"description"`
+	Pricing     *AddOnPricing `json:"pricing" binding:"omitempty,oneof=per_stay per_night per_guest per_guest_night"`
+	Price       *int64        `json:"price" binding:"omitempty,gt=0"`
+	MaxQuantity *int          `json:"max_quantity" binding:"omitempty,gte=0"`
+	Active      *bool         `json:"active"`
+}
+
+// AddOnSelection: add-on yang dipilih saat booking, quantity kosong = 1
+type AddOnSelection struct {
+	AddOnID  uint `json:"add_on_id" binding:"required"`
+	Quantity int  `json:"quantity" binding:"gte=0"`
+}
+
+type AttachAddOnsRequest struct {
+	AddOns []AddOnSelection `json:"add_ons" binding:"required,min=1,dive"`
+}
@@ -5,41 +5,99 @@ import (
 	"fmt"
 	"time"
 
+	"backend/internal/models/auth"
+	"backend/internal/models/tax"
 	"gorm.io/gorm"
 )
 
 type BookingStatus string
 
 const (
-	BookingStatusPending     BookingStatus = "pending"
-	BookingStatusConfirmed   BookingStatus = "confirmed"
-	BookingStatusCancelled   BookingStatus = "cancelled"
-	BookingStatusCheckedIn   BookingStatus = "checked_in"
-	BookingStatusCheckedOut  BookingStatus = "checked_out"
+	BookingStatusPending    BookingStatus = "pending"
+	BookingStatusConfirmed  BookingStatus = "confirmed"
+	BookingStatusPaid       BookingStatus = "paid" // dibayar lewat payment gateway, otomatis terkonfirmasi
+	BookingStatusCancelled  BookingStatus = "cancelled"
+	BookingStatusCheckedIn  BookingStatus = "checked_in"
+	BookingStatusCheckedOut BookingStatus = "checked_out"
+	BookingStatusExpired    BookingStatus = "expired" // hold pending habis sebelum dikonfirmasi/dibayar
+	BookingStatusBlocked    BookingStatus = "blocked" // terjual di OTA, diimpor dari feed iCal
+	BookingStatusNoShow     BookingStatus = "no_show" // tamu tidak datang sampai lewat tanggal check-in
 )
 
 func (s BookingStatus) String() string {
 	return string(s)
 }
 
+// ActiveBookingStatuses: status booking yang selalu memakan inventori kamar.
+// Booking pending hanya ikut memblokir selama hold-nya (HoldExpiresAt) masih berlaku.
+// Dipakai bersama oleh CountOverlapping, CheckAvailability dan alokasi kamar
+// supaya hasil cek ketersediaan selalu sama dengan kamar yang benar-benar bisa dipesan.
+var ActiveBookingStatuses = []string{
+	BookingStatusConfirmed.String(),
+	BookingStatusPaid.String(),
+	BookingStatusCheckedIn.String(),
+	BookingStatusBlocked.String(),
+}
+
+// Asal booking
+const (
+	BookingSourceDirect = "direct"
+	BookingSourceICal   = "ical"
+)
+
 type Booking struct {
-	ID          uint            `gorm:"primaryKey" json:"id"`
-	RoomID      uint            `gorm:"not null" json:"room_id"`
-	UserID      *uint           `json:"user_id,omitempty"`
-	Room        Room            `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"room,omitempty"`
-	Name        string          `gorm:"size:100;not null" json:"name"`
-	Phone       string          `gorm:"size:20;not null" json:"phone"`
-	Email       string          `gorm:"size:100" json:"email"`
-	CheckIn     time.Time       `gorm:"not null" json:"check_in"`
-	CheckOut    time.Time       `gorm:"not null" json:"check_out"`
-	Guests      int             `gorm:"not null" json:"guests"`
-	TotalNights int             `gorm:"not null" json:"total_nights"`
-	TotalPrice  int64           `gorm:"not null" json:"total_price"`
-	Status      BookingStatus   `gorm:"type:varchar(20);default:'pending'" json:"status"`
-	Notes       string          `gorm:"type:text" json:"notes,omitempty"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt  `gorm:"index" json:"-"`
+	ID            uint        `gorm:"primaryKey" json:"id"`
+	RoomID        uint        `gorm:"not null" json:"room_id"`
+	UserID        *uint       `json:"user_id,omitempty"` // id akun tamu (tabel guests)
+	Guest         *auth.Guest `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"guest,omitempty"`
+	ReservationID *uint       `gorm:"index" json:"reservation_id,omitempty"` // header reservasi (kode MTR-XXXXX)
+	Room          Room        `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"room,omitempty"`
+	Name          string      `gorm:"size:100;not null" json:"name"`
+	Phone         string      `gorm:"size:20;not null" json:"phone"`
+	Email         string      `gorm:"size:100" json:"email"`
+	CheckIn       time.Time   `gorm:"not null" json:"check_in"`
+	CheckOut      time.Time   `gorm:"not null" json:"check_out"`
+	Guests        int         `gorm:"not null" json:"guests"` // dewasa + anak
+	Adults        int         `gorm:"not null;default:0" json:"adults"`
+	Children      int         `gorm:"not null;default:0" json:"children"`
+	TotalNights   int         `gorm:"not null" json:"total_nights"`
+	Subtotal      int64       `gorm:"not null;default:0" json:"subtotal"` // harga kamar sebelum diskon
+	PromoCode     string      `gorm:"size:30" json:"promo_code,omitempty"`
+	Discount      int64       `gorm:"not null;default:0" json:"discount"`
+	AddOnTotal    int64       `gorm:"not null;default:0" json:"add_on_total"` // add-on tidak ikut diskon promo
+	// Biaya tamu di atas kapasitas dasar tipe kamar, tidak ikut diskon promo
+	ExtraGuestCharge int64         `gorm:"not null;default:0" json:"extra_guest_charge"` // per malam
+	ExtraGuestTotal  int64         `gorm:"not null;default:0" json:"extra_guest_total"`
+	Tax              int64         `gorm:"not null;default:0" json:"tax"` // pajak & service, inclusive maupun exclusive
+	TotalPrice       int64         `gorm:"not null" json:"total_price"`
+	Status           BookingStatus `gorm:"type:varchar(20);default:'pending'" json:"status"`
+	Notes            string        `gorm:"type:text" json:"notes,omitempty"`
+	PaidAt           *time.Time    `json:"paid_at,omitempty"`
+	HoldExpiresAt    *time.Time    `gorm:"index" json:"hold_expires_at,omitempty"`
+	// Pelunasan manual sebelum pembayaran dicatat per transaksi; hanya diisi sekali oleh migrasi
+	LegacyPaidAmount int64 `gorm:"not null;default:0" json:"legacy_paid_amount,omitempty"`
+	// Kebijakan pembatalan yang berlaku saat booking dibuat/diubah, serta hasil pembatalannya
+	CancellationPolicyID *uint      `json:"cancellation_policy_id,omitempty"`
+	CancellationFee      int64      `gorm:"not null;default:0" json:"cancellation_fee"`
+	RefundAmount         int64      `gorm:"not null;default:0" json:"refund_amount"`
+	CancelledAt          *time.Time `json:"cancelled_at,omitempty"`
+	CancelReason         string     `gorm:"type:text" json:"cancel_reason,omitempty"`
+	// Front desk: waktu check-in/out sebenarnya, denda late check-out & pulang lebih awal
+	ActualCheckIn    *time.Time `json:"actual_check_in,omitempty"`
+	ActualCheckOut   *time.Time `json:"actual_check_out,omitempty"`
+	LateCheckoutFee  int64      `gorm:"not null;default:0" json:"late_checkout_fee"`
+	EarlyDeparture   bool       `gorm:"default:false" json:"early_departure"`
+	OriginalCheckOut *time.Time `json:"original_check_out,omitempty"` // tanggal check-out sebelum pulang lebih awal
+	// Booking hasil impor iCal: feed asal & UID event-nya
+	Source      string         `gorm:"size:20;default:'direct'" json:"source"`
+	FeedID      *uint          `gorm:"index" json:"feed_id,omitempty"`
+	ExternalUID string         `gorm:"size:255;index" json:"external_uid,omitempty"`
+	Nights      []BookingNight `gorm:"foreignKey:BookingID;constraint:OnDelete:CASCADE" json:"nights,omitempty"`
+	AddOns      []BookingAddOn `gorm:"foreignKey:BookingID;constraint:OnDelete:CASCADE" json:"add_ons,omitempty"`
+	Taxes       []tax.TaxLine  `gorm:"polymorphic:Source;polymorphicValue:booking" json:"taxes,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // Hook: Validasi sebelum create
@@ -53,7 +111,9 @@ func (b *Booking) BeforeCreate(tx *gorm.DB) error {
 	if b.TotalNights <= 0 {
 		return fmt.Errorf("total_nights must be greater than 0")
 	}
-	if b.TotalPrice <= 0 {
+	// Total 0 hanya boleh bila seluruh harga tertutup diskon promo,
+	// atau untuk blok OTA yang harganya tidak kita ketahui
+	if b.TotalPrice < 0 || (b.TotalPrice == 0 && b.Discount == 0 && b.Status != BookingStatusBlocked) {
 		return fmt.Errorf("total_price must be greater than 0")
 	}
 	return nil
@@ -61,51 +121,103 @@ func (b *Booking) BeforeCreate(tx *gorm.DB) error {
 
 // Request structs
 type CreateBookingRequest struct {
-	RoomID   uint   `json:"room_id" binding:"required"`
-	Name     string `json:"name" binding:"required"`
-	Phone    string `json:"phone" binding:"required"`
-	Email    string `json:"email" binding:"required,email"`
-	CheckIn  string `json:"check_in" binding:"required"`
-	CheckOut string `json:"check_out" binding:"required"`
-	Guests   int    `json:"guests" binding:"required,gt=0"`
-	Notes    string `json:"notes,omitempty"`
+	RoomID    uint   `json:"room_id" binding:"required"`
+	Name      string `json:"name" binding:"required"`
+	Phone     string `json:"phone" binding:"required"`
+	Email     string `json:"email" binding:"required,email"`
+	CheckIn   string `json:"check_in" binding:"required"`
+	CheckOut  string `json:"check_out" binding:"required"`
+	Guests    int    `json:"guests" binding:"omitempty,gt=0"` // boleh diganti adults + children
+	Adults    int    `json:"adults" binding:"omitempty,gt=0"`
+	Children  int    `json:"children" binding:"omitempty,gte=0"`
+	Notes     string `json:"notes,omitempty"`
+	PromoCode string `json:"promo_code,omitempty"`
+
+	AddOns []AddOnSelection `json:"add_ons,omitempty" binding:"omitempty,dive"`
 }
 
 type GuestBookingRequest struct {
-	RoomType   string `json:"room_type" binding:"required,oneof=superior deluxe executive"`
+	RoomType   string `json:"room_type" binding:"required,max=50"` // slug tipe kamar
 	TotalRooms int    `json:"total_rooms" binding:"required,gt=0"`
 	Name       string `json:"name" binding:"required"`
 	Phone      string `json:"phone" binding:"required"`
 	Email      string `json:"email" binding:"required,email"`
 	CheckIn    string `json:"check_in" binding:"required"`
 	CheckOut   string `json:"check_out" binding:"required"`
-	Guests     int    `json:"guests" binding:"required,gt=0"`
+	Guests     int    `json:"guests" binding:"omitempty,gt=0"` // per kamar, boleh diganti adults + children
+	Adults     int    `json:"adults" binding:"omitempty,gt=0"` // per kamar
+	Children   int    `json:"children" binding:"omitempty,gte=0"`
 	Notes      string `json:"notes,omitempty"`
+	PromoCode  string `json:"promo_code,omitempty"`
+
+	// Add-on berlaku untuk setiap kamar
+	AddOns []AddOnSelection `json:"add_ons,omitempty" binding:"omitempty,dive"`
 }
 
 type BookingResponse struct {
-	ID          uint   `json:"id"`
-	WhatsAppURL string `json:"whatsapp_url"`
+	ID              uint           `json:"id"`
+	ReservationCode string         `json:"reservation_code"`
+	Nights          []NightlyRate  `json:"nights"`
+	Subtotal        int64          `json:"subtotal"`
+	PromoCode       string         `json:"promo_code,omitempty"`
+	Discount        int64          `json:"discount"`
+	AddOns          []BookingAddOn `json:"add_ons,omitempty"`
+	AddOnTotal      int64          `json:"add_on_total"`
+	ExtraGuestTotal int64          `json:"extra_guest_total"`
+	Tax             int64          `json:"tax"`
+	Taxes           []tax.TaxLine  `json:"taxes,omitempty"`
+	TotalPrice      int64          `json:"total_price"`
+	WhatsAppURL     string         `json:"whatsapp_url"`
 }
 
 type GuestBookingResponse struct {
-	BookingIDs  []uint `json:"booking_ids"`
-	WhatsAppURL string `json:"whatsapp_url"`
+	ReservationCode string         `json:"reservation_code"`
+	BookingIDs      []uint         `json:"booking_ids"`
+	NightsPerRoom   []NightlyRate  `json:"nights_per_room"`
+	Subtotal        int64          `json:"subtotal"`
+	PromoCode       string         `json:"promo_code,omitempty"`
+	Discount        int64          `json:"discount"`
+	AddOnsPerRoom   []BookingAddOn `json:"add_ons_per_room,omitempty"`
+	AddOnTotal      int64          `json:"add_on_total"`      // semua kamar
+	ExtraGuestTotal int64          `json:"extra_guest_total"` // semua kamar
+	Tax             int64          `json:"tax"`               // semua kamar
+	TotalPrice      int64          `json:"total_price"`
+	WhatsAppURL     string         `json:"whatsapp_url"`
 }
 
 type AvailabilityRequest struct {
-	CheckIn string `form:"check_in" binding:"required"`
+	CheckIn  string `form:"check_in" binding:"required"`
 	CheckOut string `form:"check_out" binding:"required"`
 	Type     string `form:"type,omitempty"`
 }
 
 type AvailabilityResponse struct {
-	Type           string `json:"type"`
-	PricePerNight  int64  `json:"price_per_night"`
-	AvailableRooms int    `json:"available_rooms"`
-	TotalRooms     int    `json:"total_rooms"`
+	RoomTypeID       uint          `json:"room_type_id"`
+	Type             string        `json:"type"`
+	Name             string        `json:"name"`
+	MaxOccupancy     int           `json:"max_occupancy"`
+	BaseOccupancy    int           `json:"base_occupancy"`
+	ExtraAdultPrice  int64         `json:"extra_adult_price"`
+	ExtraChildPrice  int64         `json:"extra_child_price"`
+	BedConfiguration string        `json:"bed_configuration"`
+	Size             float64       `json:"size"`
+	Nights           []NightlyRate `json:"nights"`
+	TotalPrice       int64         `json:"total_price"` // per kamar untuk seluruh malam
+	AvailableRooms   int           `json:"available_rooms"`
+	TotalRooms       int           `json:"total_rooms"`
+	// Aturan lama menginap yang menolak tanggal ini; AvailableRooms dibuat 0
+	Restriction *StayRestrictionViolation `json:"restriction,omitempty"`
 }
 
+type CheckOutRequest struct {
+	// nil = dihitung otomatis dari jam check-out standar, 0 = dibebaskan
+	LateFee *int64 `json:"late_fee" binding:"omitempty,gte=0"`
+	// Pulang lebih awal: malam yang tidak dipakai dikembalikan/tidak ditagih
+	RefundUnusedNights bool `json:"refund_unused_nights"`
+}
 
-
-
+// FrontDeskRunResult: hasil background job fallback front desk
+type FrontDeskRunResult struct {
+	AutoCheckedOut int `json:"auto_checked_out"`
+	NoShows        int `json:"no_shows"`
+}
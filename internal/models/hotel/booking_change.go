@@ -0,0 +1,39 @@
+// internal/models/hotel/booking_change.go
+package hotel
+
+import "time"
+
+// Sumber perubahan booking
+const (
+	BookingChangeByAdmin = "admin"
+	BookingChangeByGuest = "guest"
+)
+
+// BookingFieldChange: satu field yang berubah (nilai lama → baru)
+type BookingFieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// BookingChange: riwayat perubahan booking (tanggal, kamar, jumlah tamu, harga)
+type BookingChange struct {
+	ID        uint                 `gorm:"primaryKey" json:"id"`
+	BookingID uint                 `gorm:"not null;index" json:"booking_id"`
+	ChangedBy *uint                `json:"changed_by,omitempty"`
+	Source    string               `gorm:"size:20;not null" json:"source"`
+	Changes   []BookingFieldChange `gorm:"serializer:json;type:text" json:"changes"`
+	Reason    string               `gorm:"type:text" json:"reason,omitempty"`
+	CreatedAt time.Time            `json:"created_at"`
+}
+
+// Request: field kosong (nil) berarti tidak diubah
+type ModifyBookingRequest struct {
+	CheckIn  *string `json:"check_in"`
+	CheckOut *string `json:"check_out"`
+	RoomID   *uint   `json:"room_id"`
+	Guests   *int    `json:"guests" binding:"omitempty,gt=0"`
+	Adults   *int    `json:"adults" binding:"omitempty,gt=0"`
+	Children *int    `json:"children" binding:"omitempty,gte=0"`
+	Reason   string  `json:"reason"`
+}
@@ -0,0 +1,55 @@
+// internal/models/hotel/cancellation_policy.go
+package hotel
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CancellationPolicy: aturan pembatalan yang dipasang di tipe kamar atau rate plan.
+// Gratis bila dibatalkan paling lambat FreeCancelDays hari sebelum check-in,
+// setelah itu dikenakan PenaltyPercent dari total harga. NonRefundable = selalu 100%.
+type CancellationPolicy struct {
+	ID             uint           `gorm:"primaryKey" json:"id"`
+	Name           string         `gorm:"size:100;not null" json:"name"`
+	Description    string         `gorm:"type:text" json:"description,omitempty"`
+	FreeCancelDays int            `gorm:"not null;default:0" json:"free_cancel_days"`
+	PenaltyPercent int            `gorm:"not null;default:0" json:"penalty_percent"`
+	NonRefundable  bool           `gorm:"default:false" json:"non_refundable"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// CancellationQuote: hasil perhitungan denda & refund untuk satu booking
+type CancellationQuote struct {
+	BookingID         uint   `json:"booking_id"`
+	PolicyID          *uint  `json:"policy_id,omitempty"`
+	PolicyName        string `json:"policy_name,omitempty"`
+	DaysBeforeArrival int    `json:"days_before_arrival"`
+	Fee               int64  `json:"fee"`
+	PaidAmount        int64  `json:"paid_amount"`
+	RefundAmount      int64  `json:"refund_amount"`
+}
+
+// Request
+type CreateCancellationPolicyRequest struct {
+	Name           string `json:"name" binding:"required"`
+	Description    string `json:"description"`
+	FreeCancelDays int    `json:"free_cancel_days" binding:"gte=0"`
+	PenaltyPercent int    `json:"penalty_percent" binding:"gte=0,lte=100"`
+	NonRefundable  bool   `json:"non_refundable"`
+}
+
+type UpdateCancellationPolicyRequest struct {
+	Name           *string `json:"name"`
+	Description    *string `json:"description"`
+	FreeCancelDays *int    `json:"free_cancel_days" binding:"omitempty,gte=0"`
+	PenaltyPercent *int    `json:"penalty_percent" binding:"omitempty,gte=0,lte=100"`
+	NonRefundable  *bool   `json:"non_refundable"`
+}
+
+type CancelBookingRequest struct {
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,117 @@
+// internal/models/hotel/folio.go
+package hotel
+
+import (
+	"time"
+
+	"backend/internal/models/tax"
+)
+
+type FolioStatus string
+
+const (
+	FolioStatusOpen   FolioStatus = "open"
+	FolioStatusClosed FolioStatus = "closed" // ditutup saat check-out, tidak menerima posting lagi
+)
+
+type FolioEntryType string
+
+const (
+	FolioEntryCharge     FolioEntryType = "charge"
+	FolioEntryPayment    FolioEntryType = "payment"
+	FolioEntryAdjustment FolioEntryType = "adjustment" // koreksi, bisa positif atau negatif
+)
+
+// Asal posting folio
+const (
+	FolioSourceFrontDesk = "front_desk"
+	FolioSourceCafe      = "cafe"
+	FolioSourceSouvenir  = "souvenir"
+	FolioSourceBook      = "book"
+)
+
+// Folio: rekening tagihan tambahan tamu selama menginap (cafe, souvenir, buku, biaya lain
+// dari front desk), satu per booking. Tagihan kamar tetap tercatat di booking & payment.
+type Folio struct {
+	ID        uint         `gorm:"primaryKey" json:"id"`
+	BookingID uint         `gorm:"not null;uniqueIndex" json:"booking_id"`
+	Status    FolioStatus  `gorm:"type:varchar(20);default:'open'" json:"status"`
+	ClosedAt  *time.Time   `json:"closed_at,omitempty"`
+	Entries   []FolioEntry `gorm:"foreignKey:FolioID;constraint:OnDelete:CASCADE" json:"entries"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+
+	// Ringkasan dari entries, tidak disimpan
+	Charges  int64 `gorm:"-" json:"charges"`  // charge + adjustment
+	Payments int64 `gorm:"-" json:"payments"` // total pembayaran
+	Balance  int64 `gorm:"-" json:"balance"`  // > 0 = masih harus dibayar tamu
+}
+
+// FolioEntry: satu posting. Amount bertanda: charge positif, payment negatif,
+// adjustment sesuai arah koreksinya, sehingga saldo = jumlah seluruh amount.
+type FolioEntry struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	FolioID     uint           `gorm:"not null;index" json:"folio_id"`
+	Type        FolioEntryType `gorm:"type:varchar(20);not null" json:"type"`
+	Source      string         `gorm:"size:20;not null" json:"source"`
+	Description string         `gorm:"size:255;not null" json:"description"`
+	Quantity    int            `gorm:"not null;default:1" json:"quantity"`
+	UnitPrice   int64          `gorm:"not null;default:0" json:"unit_price"`
+	Amount      int64          `gorm:"not null" json:"amount"` // termasuk pajak exclusive
+	Tax         int64          `gorm:"not null;default:0" json:"tax"`
+	Method      string         `gorm:"size:20" json:"method,omitempty"`     // metode pembayaran
+	Reference   string         `gorm:"size:100" json:"reference,omitempty"` // contoh: cafe-product:12, no. struk EDC
+	PostedBy    *uint          `json:"posted_by,omitempty"`
+	Taxes       []tax.TaxLine  `gorm:"polymorphic:Source;polymorphicValue:folio_entry" json:"taxes,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+
+	RunningBalance int64 `gorm:"-" json:"running_balance"`
+}
+
+// Summarize: isi total & saldo berjalan, entries harus urut dari yang paling lama
+func (f *Folio) Summarize() {
+	f.Charges, f.Payments, f.Balance = 0, 0, 0
+	for i := range f.Entries {
+		e := &f.Entries[i]
+		if e.Type == FolioEntryPayment {
+			f.Payments -= e.Amount
+		} else {
+			f.Charges += e.Amount
+		}
+		f.Balance += e.Amount
+		e.RunningBalance = f.Balance
+	}
+}
+
+// FolioLine: satu baris tagihan yang diposting ke folio
+type FolioLine struct {
+	Description string
+	Quantity    int
+	UnitPrice   int64
+	Reference   string
+}
+
+// RoomChargeTarget: booking tujuan posting dari outlet, lewat id booking atau nomor kamar
+type RoomChargeTarget struct {
+	BookingID  uint   `json:"booking_id"`
+	RoomNumber string `json:"room_number"`
+}
+
+// Request
+type FolioChargeRequest struct {
+	Description string `json:"description" binding:"required,max=255"`
+	UnitPrice   int64  `json:"unit_price" binding:"required,gt=0"`
+	Quantity    int    `json:"quantity" binding:"gte=0"` // kosong = 1
+	Reference   string `json:"reference" binding:"max=100"`
+}
+
+type FolioPaymentRequest struct {
+	Amount    int64  `json:"amount" binding:"required,gt=0"`
+	Method    string `json:"method" binding:"required,oneof=cash card transfer qris"`
+	Reference string `json:"reference" binding:"max=100"`
+}
+
+type FolioAdjustmentRequest struct {
+	Amount int64  `json:"amount" binding:"required"` // negatif = mengurangi tagihan
+	Reason string `json:"reason" binding:"required,max=255"`
+}
@@ -0,0 +1,25 @@
+// internal/models/hotel/guest_profile.go
+package hotel
+
+import (
+	"time"
+
+	"backend/internal/models/auth"
+)
+
+// GuestStayStats: ringkasan riwayat menginap tamu
+type GuestStayStats struct {
+	CompletedStays int64      `json:"completed_stays"`
+	UpcomingStays  int64      `json:"upcoming_stays"`
+	CancelledStays int64      `json:"cancelled_stays"`
+	TotalNights    int64      `json:"total_nights"`
+	TotalSpent     int64      `json:"total_spent"`
+	LastStayAt     *time.Time `json:"last_stay_at,omitempty"`
+}
+
+// GuestProfile: profil tamu beserta riwayat menginap terakhir
+type GuestProfile struct {
+	Guest       auth.Guest     `json:"guest"`
+	Stats       GuestStayStats `json:"stats"`
+	RecentStays []Booking      `json:"recent_stays"`
+}
@@ -0,0 +1,67 @@
+// internal/models/hotel/housekeeping.go
+package hotel
+
+import "time"
+
+type HousekeepingStatus string
+
+const (
+	HousekeepingPending    HousekeepingStatus = "pending"
+	HousekeepingInProgress HousekeepingStatus = "in_progress"
+	HousekeepingCleaned    HousekeepingStatus = "cleaned"   // menunggu inspeksi
+	HousekeepingInspected  HousekeepingStatus = "inspected" // selesai, kamar boleh dijual lagi
+)
+
+// OpenHousekeepingStatuses: task yang masih menahan kamar di status cleaning
+var OpenHousekeepingStatuses = []string{
+	string(HousekeepingPending),
+	string(HousekeepingInProgress),
+	string(HousekeepingCleaned),
+}
+
+// Jenis task
+const (
+	HousekeepingTypeCheckout = "checkout" // otomatis saat tamu check-out
+	HousekeepingTypeRequest  = "request"  // dibuat manual (permintaan tamu, stayover, dll)
+)
+
+type HousekeepingTask struct {
+	ID          uint               `gorm:"primaryKey" json:"id"`
+	RoomID      uint               `gorm:"not null;index" json:"room_id"`
+	Room        Room               `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"room,omitempty"`
+	BookingID   *uint              `gorm:"index" json:"booking_id,omitempty"`
+	Type        string             `gorm:"size:20;not null" json:"type"`
+	Status      HousekeepingStatus `gorm:"type:varchar(20);default:'pending';index" json:"status"`
+	AssignedTo  *uint              `gorm:"index" json:"assigned_to,omitempty"` // id admin hotel (staf)
+	Notes       string             `gorm:"type:text" json:"notes,omitempty"`
+	StartedAt   *time.Time         `json:"started_at,omitempty"`
+	CleanedAt   *time.Time         `json:"cleaned_at,omitempty"`
+	InspectedAt *time.Time         `json:"inspected_at,omitempty"`
+	InspectedBy *uint              `json:"inspected_by,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// HousekeepingBoard: task terbuka dikelompokkan per status untuk papan housekeeping
+type HousekeepingBoard struct {
+	Pending    []HousekeepingTask `json:"pending"`
+	InProgress []HousekeepingTask `json:"in_progress"`
+	Cleaned    []HousekeepingTask `json:"cleaned"`
+	// Kamar berstatus cleaning tanpa task terbuka (misalnya diubah manual)
+	UntrackedRooms []Room `json:"untracked_rooms"`
+}
+
+// Request
+type CreateHousekeepingTaskRequest struct {
+	RoomID uint   `json:"room_id" binding:"required"`
+	Notes  string `json:"notes"`
+}
+
+type AssignHousekeepingTaskRequest struct {
+	AssignedTo uint `json:"assigned_to" binding:"required"`
+}
+
+type InspectHousekeepingTaskRequest struct {
+	Passed bool   `json:"passed"`
+	Notes  string `json:"notes"`
+}
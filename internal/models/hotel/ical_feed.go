@@ -0,0 +1,46 @@
+// internal/models/hotel/ical_feed.go
+package hotel
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ICalFeed: kalender iCal eksternal (OTA) untuk satu kamar. Event di dalamnya
+// diimpor berkala menjadi booking berstatus blocked supaya kamar tidak dijual dua kali.
+type ICalFeed struct {
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	RoomID       uint           `gorm:"not null;index" json:"room_id"`
+	Room         Room           `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Name         string         `gorm:"size:100;not null" json:"name"` // contoh: Traveloka, Booking.com
+	URL          string         `gorm:"size:500;not null" json:"url"`
+	Active       bool           `gorm:"default:true" json:"active"`
+	LastSyncedAt *time.Time     `json:"last_synced_at,omitempty"`
+	LastError    string         `gorm:"type:text" json:"last_error,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// ICalSyncResult: ringkasan satu kali sinkronisasi feed
+type ICalSyncResult struct {
+	FeedID    uint     `json:"feed_id"`
+	Created   int      `json:"created"`
+	Updated   int      `json:"updated"`
+	Released  int      `json:"released"`
+	Conflicts []string `json:"conflicts,omitempty"`
+}
+
+// Request
+type CreateICalFeedRequest struct {
+	RoomID uint   `json:"room_id" binding:"required"`
+	Name   string `json:"name" binding:"required"`
+	URL    string `json:"url" binding:"required,url"`
+}
+
+type UpdateICalFeedRequest struct {
+	Name   *string `json:"name"`
+	URL    *string `json:"url" binding:"omitempty,url"`
+	Active *bool   `json:"active"`
+}
@@ -0,0 +1,52 @@
+// internal/models/hotel/maintenance.go
+package hotel
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MaintenanceBlock: kamar tidak dijual (out of order) pada [StartDate, EndDate).
+// Blok yang dihapus berarti kamar sudah kembali bisa dipakai sebelum waktunya.
+type MaintenanceBlock struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	RoomID    uint           `gorm:"not null;index" json:"room_id"`
+	Room      *Room          `gorm:"foreignKey:RoomID;constraint:OnDelete:CASCADE" json:"room,omitempty"`
+	Reason    string         `gorm:"size:255;not null" json:"reason"`
+	StartDate time.Time      `gorm:"not null;index" json:"start_date"`
+	EndDate   time.Time      `gorm:"not null;index" json:"end_date"` // eksklusif, sama seperti check_out
+	Forced    bool           `gorm:"default:false" json:"forced"`    // dibuat walau masih ada booking yang harus dipindah
+	CreatedBy *uint          `json:"created_by,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// MaintenanceRelocation: booking aktif yang bentrok dengan blok beserta kamar
+// pengganti sejenis yang masih kosong. SuggestedRoomID nil = tidak ada kamar pengganti.
+type MaintenanceRelocation struct {
+	BookingID           uint          `json:"booking_id"`
+	Name                string        `json:"name"`
+	Status              BookingStatus `json:"status"`
+	CheckIn             time.Time     `json:"check_in"`
+	CheckOut            time.Time     `json:"check_out"`
+	SuggestedRoomID     *uint         `json:"suggested_room_id,omitempty"`
+	SuggestedRoomNumber string        `json:"suggested_room_number,omitempty"`
+}
+
+type MaintenanceBlockResult struct {
+	Block       *MaintenanceBlock       `json:"block,omitempty"`
+	Relocations []MaintenanceRelocation `json:"relocations"`
+}
+
+// Request
+type CreateMaintenanceBlockRequest struct {
+	RoomID    uint   `json:"room_id" binding:"required"`
+	Reason    string `json:"reason" binding:"required,max=255"`
+	StartDate string `json:"start_date" binding:"required"`
+	EndDate   string `json:"end_date" binding:"required"`
+	// Force: tetap buat blok walau ada booking aktif, booking tersebut
+	// dikembalikan sebagai laporan relokasi untuk dipindah front desk
+	Force bool `json:"force"`
+}
@@ -0,0 +1,45 @@
+// internal/models/hotel/occupancy.go
+package hotel
+
+import "time"
+
+// OccupancyGrid: tape chart kamar × malam. Nights[i] pada setiap baris adalah
+// ID booking yang menempati kamar pada Dates[i] (0 = kosong), Blocks[i] adalah
+// ID blok maintenance; detail booking & blok cukup dikirim sekali.
+type OccupancyGrid struct {
+	Start       string             `json:"start"`
+	End         string             `json:"end"` // eksklusif, sama seperti check_out
+	Dates       []string           `json:"dates"`
+	Rooms       []OccupancyRow     `json:"rooms"`
+	Bookings    []OccupancyBooking `json:"bookings"`
+	Maintenance []OccupancyBlock   `json:"maintenance"`
+}
+
+type OccupancyRow struct {
+	RoomID     uint       `json:"room_id"`
+	Number     string     `json:"number"`
+	RoomTypeID uint       `json:"room_type_id"`
+	RoomType   string     `json:"room_type"`
+	Status     RoomStatus `json:"status"`
+	Nights     []uint     `gorm:"-" json:"nights"`
+	Blocks     []uint     `gorm:"-" json:"blocks"`
+}
+
+type OccupancyBooking struct {
+	ID       uint          `json:"id"`
+	RoomID   uint          `json:"room_id"`
+	Name     string        `json:"name"`
+	Status   BookingStatus `json:"status"`
+	Source   string        `json:"source"`
+	Guests   int           `json:"guests"`
+	CheckIn  time.Time     `json:"check_in"`
+	CheckOut time.Time     `json:"check_out"`
+}
+
+type OccupancyBlock struct {
+	ID        uint      `json:"id"`
+	RoomID    uint      `json:"room_id"`
+	Reason    string    `json:"reason"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
@@ -0,0 +1,49 @@
+// internal/models/hotel/payment.go
+package hotel
+
+import (
+	"time"
+)
+
+type PaymentStatus string
+
+const (
+	PaymentStatusPending PaymentStatus = "pending"
+	PaymentStatusPaid    PaymentStatus = "paid"
+	PaymentStatusFailed  PaymentStatus = "failed"
+	PaymentStatusExpired PaymentStatus = "expired"
+	// Pengembalian dana ke tamu yang harus diproses front desk (amount positif)
+	PaymentStatusRefund PaymentStatus = "refund"
+)
+
+func (s PaymentStatus) String() string {
+	return string(s)
+}
+
+type Payment struct {
+	ID          uint          `gorm:"primaryKey" json:"id"`
+	BookingID   uint          `gorm:"not null;index" json:"booking_id"`
+	Booking     Booking       `gorm:"foreignKey:BookingID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
+	Provider    string        `gorm:"size:30;not null" json:"provider"`
+	OrderID     string        `gorm:"size:64;uniqueIndex;not null" json:"order_id"`
+	ExternalID  string        `gorm:"size:100" json:"external_id,omitempty"`
+	Amount      int64         `gorm:"not null" json:"amount"`
+	Status      PaymentStatus `gorm:"type:varchar(20);default:'pending'" json:"status"`
+	RedirectURL string        `gorm:"size:500" json:"redirect_url,omitempty"`
+	RawPayload  string        `gorm:"type:text" json:"-"`
+	PaidAt      *time.Time    `json:"paid_at,omitempty"`
+	// Dana sudah diterima tapi booking tidak bisa dilunasi (batal, kamar terjual lagi,
+	// atau sudah lunas lewat pembayaran lain); harus dikembalikan manual
+	RefundRequired bool      `gorm:"default:false;index" json:"refund_required"`
+	Note           string    `gorm:"size:255" json:"note,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type PaymentResponse struct {
+	PaymentID   uint          `json:"payment_id"`
+	OrderID     string        `json:"order_id"`
+	Amount      int64         `json:"amount"`
+	Status      PaymentStatus `json:"status"`
+	RedirectURL string        `json:"redirect_url"`
+}
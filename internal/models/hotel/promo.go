@@ -0,0 +1,75 @@
+// internal/models/hotel/promo.go
+package hotel
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PromoDiscountType string
+
+const (
+	PromoDiscountPercentage PromoDiscountType = "percentage"
+	PromoDiscountFixed      PromoDiscountType = "fixed"
+)
+
+type PromoCode struct {
+	ID             uint              `gorm:"primaryKey" json:"id"`
+	Code           string            `gorm:"size:30;uniqueIndex;not null" json:"code"`
+	Description    string            `gorm:"type:text" json:"description,omitempty"`
+	DiscountType   PromoDiscountType `gorm:"type:varchar(20);not null" json:"discount_type"`
+	Value          int64             `gorm:"not null" json:"value"`         // persen (1-100) atau nominal Rupiah
+	MaxDiscount    int64             `gorm:"default:0" json:"max_discount"` // batas diskon persentase, 0 = tanpa batas
+	ValidFrom      time.Time         `gorm:"type:date;not null" json:"valid_from"`
+	ValidUntil     time.Time         `gorm:"type:date;not null" json:"valid_until"` // inklusif
+	MaxUses        int               `gorm:"default:0" json:"max_uses"`             // 0 = tanpa batas
+	MaxUsesPerUser int               `gorm:"default:0" json:"max_uses_per_user"`    // 0 = tanpa batas
+	MinNights      int               `gorm:"default:0" json:"min_nights"`
+	RoomTypes      []RoomType        `gorm:"many2many:promo_code_room_types" json:"room_types"` // kosong = semua tipe
+	Active         bool              `gorm:"default:true" json:"active"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt    `gorm:"index" json:"-"`
+}
+
+// PromoRedemption: satu pemakaian kode promo (satu checkout, bisa banyak kamar).
+// Tetap terhitung selama masih ada booking aktif di reservasinya.
+type PromoRedemption struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	PromoCodeID   uint      `gorm:"not null;index" json:"promo_code_id"`
+	BookingID     uint      `gorm:"not null;index" json:"booking_id"`
+	ReservationID *uint     `gorm:"index" json:"reservation_id,omitempty"`
+	UserID        *uint     `gorm:"index" json:"user_id,omitempty"`
+	Email         string    `gorm:"size:100;index" json:"email"`
+	Amount        int64     `gorm:"not null" json:"amount"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Request
+type CreatePromoCodeRequest struct {
+	Code           string            `json:"code" binding:"required,max=30"`
+	Description    string            `json:"description"`
+	DiscountType   PromoDiscountType `json:"discount_type" binding:"required,oneof=percentage fixed"`
+	Value          int64             `json:"value" binding:"required,gt=0"`
+	MaxDiscount    int64             `json:"max_discount" binding:"gte=0"`
+	ValidFrom      string            `json:"valid_from" binding:"required"`
+	ValidUntil     string            `json:"valid_until" binding:"required"`
+	MaxUses        int               `json:"max_uses" binding:"gte=0"`
+	MaxUsesPerUser int               `json:"max_uses_per_user" binding:"gte=0"`
+	MinNights      int               `json:"min_nights" binding:"gte=0"`
+	RoomTypeIDs    []uint            `json:"room_type_ids"`
+}
+
+type UpdatePromoCodeRequest struct {
+	Description    *string `json:"description"`
+	Value          *int64  `json:"value" binding:"omitempty,gt=0"`
+	MaxDiscount    *int64  `json:"max_discount" binding:"omitempty,gte=0"`
+	ValidFrom      *string `json:"valid_from"`
+	ValidUntil     *string `json:"valid_until"`
+	MaxUses        *int    `json:"max_uses" binding:"omitempty,gte=0"`
+	MaxUsesPerUser *int    `json:"max_uses_per_user" binding:"omitempty,gte=0"`
+	MinNights      *int    `json:"min_nights" binding:"omitempty,gte=0"`
+	RoomTypeIDs    []uint  `json:"room_type_ids"` // nil = tidak diubah, [] = semua tipe
+	Active         *bool   `json:"active"`
+}
@@ -0,0 +1,95 @@
+// internal/models/hotel/rate_plan.go
+package hotel
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RatePlan: harga per malam untuk rentang tanggal tertentu (high season, Lebaran, Tahun Baru).
+// Bila beberapa rate plan beririsan, Priority tertinggi yang dipakai.
+type RatePlan struct {
+	ID                   uint           `gorm:"primaryKey" json:"id"`
+	RoomTypeID           uint           `gorm:"not null;index" json:"room_type_id"`
+	RoomType             RoomType       `gorm:"foreignKey:RoomTypeID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Name                 string         `gorm:"size:100;not null" json:"name"`
+	StartDate            time.Time      `gorm:"type:date;not null" json:"start_date"`
+	EndDate              time.Time      `gorm:"type:date;not null" json:"end_date"` // inklusif
+	Price                int64          `gorm:"not null" json:"price"`
+	WeekendSurcharge     *int64         `json:"weekend_surcharge,omitempty"` // nil = pakai surcharge tipe kamar
+	Priority             int            `gorm:"not null;default:0" json:"priority"`
+	CancellationPolicyID *uint          `gorm:"index" json:"cancellation_policy_id,omitempty"` // nil = ikut tipe kamar
+	Active               bool           `gorm:"default:true" json:"active"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// RateOverride: harga khusus untuk satu malam, mengalahkan rate plan & surcharge
+type RateOverride struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	RoomTypeID uint      `gorm:"not null;uniqueIndex:idx_rate_override_night" json:"room_type_id"`
+	RoomType   RoomType  `gorm:"foreignKey:RoomTypeID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Date       time.Time `gorm:"type:date;not null;uniqueIndex:idx_rate_override_night" json:"date"`
+	Price      int64     `gorm:"not null" json:"price"`
+	Note       string    `gorm:"size:255" json:"note,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Sumber harga satu malam
+const (
+	RateSourceBase     = "base"
+	RateSourcePlan     = "plan"
+	RateSourceOverride = "override"
+)
+
+// NightlyRate: rincian harga satu malam
+type NightlyRate struct {
+	Date       string `json:"date"`
+	Price      int64  `json:"price"`
+	Source     string `json:"source"`
+	RatePlan   string `json:"rate_plan,omitempty"`
+	RatePlanID uint   `json:"rate_plan_id,omitempty"`
+	Surcharge  int64  `json:"weekend_surcharge,omitempty"`
+}
+
+// BookingNight: harga per malam yang dikunci saat booking dibuat
+type BookingNight struct {
+	ID        uint      `gorm:"primaryKey" json:"-"`
+	BookingID uint      `gorm:"not null;index" json:"-"`
+	Date      time.Time `gorm:"type:date;not null" json:"date"`
+	Price     int64     `gorm:"not null" json:"price"`
+	Source    string    `gorm:"size:20" json:"source"`
+}
+
+// Request
+type CreateRatePlanRequest struct {
+	RoomTypeID           uint   `json:"room_type_id" binding:"required"`
+	Name                 string `json:"name" binding:"required"`
+	StartDate            string `json:"start_date" binding:"required"`
+	EndDate              string `json:"end_date" binding:"required"`
+	Price                int64  `json:"price" binding:"required,gt=0"`
+	WeekendSurcharge     *int64 `json:"weekend_surcharge" binding:"omitempty,gte=0"`
+	Priority             int    `json:"priority"`
+	CancellationPolicyID *uint  `json:"cancellation_policy_id"`
+}
+
+type UpdateRatePlanRequest struct {
+	Name                 *string `json:"name"`
+	StartDate            *string `json:"start_date"`
+	EndDate              *string `json:"end_date"`
+	Price                *int64  `json:"price" binding:"omitempty,gt=0"`
+	WeekendSurcharge     *int64  `json:"weekend_surcharge" binding:"omitempty,gte=0"`
+	Priority             *int    `json:"priority"`
+	CancellationPolicyID *uint   `json:"cancellation_policy_id"` // 0 = lepas kebijakan
+	Active               *bool   `json:"active"`
+}
+
+type SetRateOverrideRequest struct {
+	RoomTypeID uint   `json:"room_type_id" binding:"required"`
+	Date       string `json:"date" binding:"required"`
+	Price      int64  `json:"price" binding:"required,gt=0"`
+	Note       string `json:"note"`
+}
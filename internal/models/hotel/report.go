@@ -0,0 +1,93 @@
+// internal/models/hotel/report.go
+package hotel
+
+import "time"
+
+// Pengelompokan periode laporan
+const (
+	ReportGroupDay   = "day"
+	ReportGroupWeek  = "week" // minggu dimulai hari Senin
+	ReportGroupMonth = "month"
+)
+
+// KPIMetrics: indikator kinerja kamar dalam satu periode.
+// Revenue adalah pendapatan kamar per malam setelah diskon promo; add-on dilaporkan
+// terpisah di AddOnRevenue dan tidak ikut dihitung ke ADR/RevPAR.
+type KPIMetrics struct {
+	AvailableRoomNights int     `json:"available_room_nights"`
+	RoomNightsSold      int     `json:"room_nights_sold"`
+	OccupancyRate       float64 `json:"occupancy_rate"` // persen
+	Revenue             int64   `json:"revenue"`
+	ADR                 int64   `json:"adr"`    // average daily rate = revenue / room-nights terjual
+	RevPAR              int64   `json:"revpar"` // revenue / room-nights tersedia
+	AddOnRevenue        int64   `json:"add_on_revenue"`
+	Arrivals            int     `json:"arrivals"`
+	Cancellations       int     `json:"cancellations"`
+	AvgLeadTimeDays     float64 `json:"avg_lead_time_days"` // jarak booking dibuat → check-in
+}
+
+type KPIRoomTypeMetrics struct {
+	RoomTypeID uint   `json:"room_type_id"`
+	RoomType   string `json:"room_type"`
+	KPIMetrics
+}
+
+type KPIPeriod struct {
+	Period string `json:"period"` // tanggal awal periode
+	KPIMetrics
+	RoomTypes []KPIRoomTypeMetrics `json:"room_types"`
+}
+
+// KPIAddOnRevenue: pendapatan per add-on selama rentang laporan
+type KPIAddOnRevenue struct {
+	AddOnID  uint   `json:"add_on_id"`
+	Name     string `json:"name"`
+	Bookings int    `json:"bookings"`
+	Revenue  int64  `json:"revenue"`
+}
+
+type KPIReport struct {
+	Start   string            `json:"start"`
+	End     string            `json:"end"` // eksklusif
+	GroupBy string            `json:"group_by"`
+	Summary KPIPeriod         `json:"summary"`
+	Periods []KPIPeriod       `json:"periods"`
+	AddOns  []KPIAddOnRevenue `json:"add_ons"`
+}
+
+// Baris mentah dari repository laporan
+type ReportRoomCount struct {
+	RoomTypeID uint
+	RoomType   string
+	Rooms      int
+}
+
+type ReportStay struct {
+	ID          uint
+	RoomTypeID  uint
+	CheckIn     time.Time
+	CheckOut    time.Time
+	TotalNights int
+	Subtotal    int64
+	Discount    int64
+	AddOnTotal  int64
+	CreatedAt   time.Time
+}
+
+type ReportNight struct {
+	BookingID uint
+	Date      time.Time
+	Price     int64
+}
+
+type ReportAddOn struct {
+	BookingID uint
+	AddOnID   uint
+	Name      string
+	Total     int64
+}
+
+type ReportCancellation struct {
+	RoomTypeID  uint
+	CancelledAt time.Time
+}
@@ -0,0 +1,64 @@
+// internal/models/hotel/reservation.go
+package hotel
+
+import "time"
+
+// Reservation: header satu transaksi pemesanan yang bisa berisi beberapa kamar
+// (booking). Kode konfirmasi dipakai tamu untuk mencari reservasinya.
+type Reservation struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Code      string    `gorm:"size:12;uniqueIndex;not null" json:"code"` // contoh: MTR-7K3Q9
+	UserID    *uint     `gorm:"index" json:"user_id,omitempty"`
+	Name      string    `gorm:"size:100;not null" json:"name"`
+	Phone     string    `gorm:"size:20;not null" json:"phone"`
+	Email     string    `gorm:"size:100;index" json:"email"`
+	CheckIn   time.Time `gorm:"type:date;not null" json:"check_in"`
+	CheckOut  time.Time `gorm:"type:date;not null" json:"check_out"`
+	Bookings  []Booking `gorm:"foreignKey:ReservationID" json:"bookings,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Ringkasan dari booking di dalamnya, tidak disimpan
+	Status     string `gorm:"-" json:"status"`
+	Subtotal   int64  `gorm:"-" json:"subtotal"`
+	Discount   int64  `gorm:"-" json:"discount"`
+	AddOnTotal int64  `gorm:"-" json:"add_on_total"`
+	Tax        int64  `gorm:"-" json:"tax"`
+	TotalPrice int64  `gorm:"-" json:"total_price"`
+}
+
+// Status reservasi bila booking di dalamnya berbeda-beda status
+const ReservationStatusMixed = "mixed"
+
+// Summarize: isi ringkasan status & harga dari booking
+func (r *Reservation) Summarize() {
+	r.Status, r.Subtotal, r.Discount, r.AddOnTotal, r.Tax, r.TotalPrice = "", 0, 0, 0, 0, 0
+	for _, b := range r.Bookings {
+		r.Subtotal += b.Subtotal
+		r.Discount += b.Discount
+		r.AddOnTotal += b.AddOnTotal
+		r.Tax += b.Tax
+		r.TotalPrice += b.TotalPrice
+		switch r.Status {
+		case "":
+			r.Status = b.Status.String()
+		case b.Status.String():
+		default:
+			r.Status = ReservationStatusMixed
+		}
+	}
+}
+
+// ReservationCancellation: hasil pembatalan seluruh booking dalam satu reservasi
+type ReservationCancellation struct {
+	Code         string              `json:"code"`
+	Quotes       []CancellationQuote `json:"quotes"`
+	Fee          int64               `json:"fee"`
+	RefundAmount int64               `json:"refund_amount"`
+}
+
+// Request: cari reservasi tanpa login
+type ReservationLookupRequest struct {
+	Code  string `form:"code" binding:"required"`
+	Email string `form:"email" binding:"required,email"`
+}
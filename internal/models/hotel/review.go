@@ -12,7 +12,7 @@ type GuestReview struct {
 	GuestName  string    `gorm:"size:100" json:"guest_name,omitempty"`
 	IPAddress  string    `gorm:"size:45" json:"ip_address,omitempty"`
 	IsApproved bool      `gorm:"default:false" json:"is_approved"`
-	AdminID    uint      `gorm:"index" json:"admin_id"` // <-- Pakai Admin
-	Admin      auth.Admin     `gorm:"foreignKey:AdminID"`
+	GuestID    uint      `gorm:"index" json:"guest_id"`
+	Guest      auth.Guest     `gorm:"foreignKey:GuestID"`
 	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
 }
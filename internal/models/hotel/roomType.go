@@ -2,35 +2,71 @@
 package hotel
 
 import (
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
 )
 
-var ValidRoomTypes = map[string]bool{
-	"superior":  true,
-	"deluxe":    true,
-	"executive": true,
+// RoomType: katalog tipe kamar, dikelola admin tanpa perubahan kode.
+// Type adalah slug unik yang dipakai di URL & filter, contoh: family-suite.
+type RoomType struct {
+	ID                   uint           `gorm:"primaryKey" json:"id"`
+	Type                 string         `gorm:"size:50;uniqueIndex;not null" json:"type"`
+	Name                 string         `gorm:"size:100" json:"name"`
+	MaxOccupancy         int            `gorm:"not null;default:2" json:"max_occupancy"`     // batas keras termasuk tamu tambahan
+	BaseOccupancy        int            `gorm:"not null;default:2" json:"base_occupancy"`    // tamu yang sudah termasuk harga kamar
+	ExtraAdultPrice      int64          `gorm:"not null;default:0" json:"extra_adult_price"` // per malam, per dewasa di atas BaseOccupancy
+	ExtraChildPrice      int64          `gorm:"not null;default:0" json:"extra_child_price"` // per malam, per anak di atas BaseOccupancy
+	BedConfiguration     string         `gorm:"size:100" json:"bed_configuration"`           // contoh: 1 King, 2 Twin
+	Size                 float64        `gorm:"default:0" json:"size"`                       // luas kamar (m²)
+	SortOrder            int            `gorm:"default:0;index" json:"sort_order"`
+	Price                int64          `gorm:"not null;column:price"`
+	WeekendSurcharge     int64          `gorm:"not null;default:0" json:"weekend_surcharge"` // tambahan malam Jumat & Sabtu
+	Description          string         `gorm:"type:text" json:"description"`
+	CancellationPolicyID *uint          `gorm:"index" json:"cancellation_policy_id,omitempty"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
-type RoomType struct {
-	ID          uint           `gorm:"primaryKey" json:"id"`
-	Type        string         `gorm:"size:20;uniqueIndex;not null" json:"type"`
-	Price       int64          `gorm:"not null;column:price"`
-	Description string         `gorm:"type:text" json:"description"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+// DisplayName: nama tampilan, data lama tanpa nama memakai slug-nya
+func (rt RoomType) DisplayName() string {
+	if rt.Name != "" {
+		return rt.Name
+	}
+	return strings.Title(strings.ReplaceAll(rt.Type, "-", " "))
 }
 
 // Request
 type CreateRoomTypeRequest struct {
-	Type        string `json:"type" binding:"required,oneof=superior deluxe executive"`
-	Price       int64  `json:"price" binding:"required,gte=0"`
-	Description string `json:"description" binding:"required"`
+	Type                 string  `json:"type" binding:"required,max=50"` // slug
+	Name                 string  `json:"name" binding:"max=100"`
+	MaxOccupancy         int     `json:"max_occupancy" binding:"omitempty,gt=0"`
+	BaseOccupancy        int     `json:"base_occupancy" binding:"omitempty,gt=0"`
+	ExtraAdultPrice      int64   `json:"extra_adult_price" binding:"gte=0"`
+	ExtraChildPrice      int64   `json:"extra_child_price" binding:"gte=0"`
+	BedConfiguration     string  `json:"bed_configuration" binding:"max=100"`
+	Size                 float64 `json:"size" binding:"gte=0"`
+	SortOrder            int     `json:"sort_order"`
+	Price                int64   `json:"price" binding:"required,gte=0"`
+	WeekendSurcharge     int64   `json:"weekend_surcharge" binding:"gte=0"`
+	Description          string  `json:"description" binding:"required"`
+	CancellationPolicyID *uint   `json:"cancellation_policy_id"`
 }
 
 type UpdateRoomTypeRequest struct {
-	Price       *int64  `json:"price" binding:"omitempty,gte=0"`
-	Description *string `json:"description" binding:"omitempty"`
-}
\ No newline at end of file
+	Type                 *string  `json:"type" binding:"omitempty,max=50"`
+	Name                 *string  `json:"name" binding:"omitempty,max=100"`
+	MaxOccupancy         *int     `json:"max_occupancy" binding:"omitempty,gt=0"`
+	BaseOccupancy        *int     `json:"base_occupancy" binding:"omitempty,gt=0"`
+	ExtraAdultPrice      *int64   `json:"extra_adult_price" binding:"omitempty,gte=0"`
+	ExtraChildPrice      *int64   `json:"extra_child_price" binding:"omitempty,gte=0"`
+	BedConfiguration     *string  `json:"bed_configuration" binding:"omitempty,max=100"`
+	Size                 *float64 `json:"size" binding:"omitempty,gte=0"`
+	SortOrder            *int     `json:"sort_order"`
+	Price                *int64   `json:"price" binding:"omitempty,gte=0"`
+	WeekendSurcharge     *int64   `json:"weekend_surcharge" binding:"omitempty,gte=0"`
+	Description          *string  `json:"description" binding:"omitempty"`
+	CancellationPolicyID *uint    `json:"cancellation_policy_id"` // 0 = lepas kebijakan
+}
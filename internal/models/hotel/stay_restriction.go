@@ -0,0 +1,72 @@
+// internal/models/hotel/stay_restriction.go
+package hotel
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Jenis aturan lama menginap
+const (
+	RestrictionMinNights         = "min_nights"
+	RestrictionMaxNights         = "max_nights"
+	RestrictionClosedToArrival   = "closed_to_arrival"
+	RestrictionClosedToDeparture = "closed_to_departure"
+	RestrictionStopSell          = "stop_sell"
+)
+
+// StayRestriction: aturan lama menginap per tipe kamar untuk rentang tanggal (peak season).
+// Min/MaxNights berlaku untuk kedatangan di dalam rentang, ClosedToArrival/Departure untuk
+// tanggal check-in/check-out, StopSell untuk setiap malam yang diinapi.
+type StayRestriction struct {
+	ID                uint           `gorm:"primaryKey" json:"id"`
+	RoomTypeID        uint           `gorm:"not null;index" json:"room_type_id"`
+	RoomType          RoomType       `gorm:"foreignKey:RoomTypeID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Name              string         `gorm:"size:100;not null" json:"name"`
+	StartDate         time.Time      `gorm:"type:date;not null" json:"start_date"`
+	EndDate           time.Time      `gorm:"type:date;not null" json:"end_date"`   // inklusif
+	MinNights         int            `gorm:"not null;default:0" json:"min_nights"` // 0 = tanpa batas
+	MaxNights         int            `gorm:"not null;default:0" json:"max_nights"` // 0 = tanpa batas
+	ClosedToArrival   bool           `gorm:"default:false" json:"closed_to_arrival"`
+	ClosedToDeparture bool           `gorm:"default:false" json:"closed_to_departure"`
+	StopSell          bool           `gorm:"default:false" json:"stop_sell"`
+	Active            bool           `gorm:"default:true" json:"active"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// StayRestrictionViolation: aturan yang membuat tanggal menginap ditolak
+type StayRestrictionViolation struct {
+	RestrictionID uint   `json:"restriction_id"`
+	Name          string `json:"name"`
+	Rule          string `json:"rule"`
+	Date          string `json:"date"`
+	Message       string `json:"message"`
+}
+
+// Request
+type CreateStayRestrictionRequest struct {
+	RoomTypeID        uint   `json:"room_type_id" binding:"required"`
+	Name              string `json:"name" binding:"required,max=100"`
+	StartDate         string `json:"start_date" binding:"required"`
+	EndDate           string `json:"end_date" binding:"required"`
+	MinNights         int    `json:"min_nights" binding:"gte=0"`
+	MaxNights         int    `json:"max_nights" binding:"gte=0"`
+	ClosedToArrival   bool   `json:"closed_to_arrival"`
+	ClosedToDeparture bool   `json:"closed_to_departure"`
+	StopSell          bool   `json:"stop_sell"`
+}
+
+type UpdateStayRestrictionRequest struct {
+	Name              *string `json:"name" binding:"omitempty,max=100"`
+	StartDate         *string `json:"start_date"`
+	EndDate           *string `json:"end_date"`
+	MinNights         *int    `json:"min_nights" binding:"omitempty,gte=0"`
+	MaxNights         *int    `json:"max_nights" binding:"omitempty,gte=0"`
+	ClosedToArrival   *bool   `json:"closed_to_arrival"`
+	ClosedToDeparture *bool   `json:"closed_to_departure"`
+	StopSell          *bool   `json:"stop_sell"`
+	Active            *bool   `json:"active"`
+}
@@ -0,0 +1,56 @@
+// internal/models/hotel/waitlist.go
+package hotel
+
+import "time"
+
+type WaitlistStatus string
+
+const (
+	WaitlistWaiting   WaitlistStatus = "waiting"
+	WaitlistNotified  WaitlistStatus = "notified" // link klaim sudah dikirim, menunggu diklaim
+	WaitlistClaimed   WaitlistStatus = "claimed"
+	WaitlistExpired   WaitlistStatus = "expired" // tanggal lewat atau link klaim kedaluwarsa
+	WaitlistCancelled WaitlistStatus = "cancelled"
+)
+
+// WaitlistEntry: antrean guest untuk tipe kamar & tanggal yang sedang penuh.
+// Diproses FIFO (urut ID) oleh job waitlist-queue. Link klaim tidak menahan kamar;
+// yang lebih dulu booking (termasuk tamu di luar antrean) yang mendapat kamar.
+type WaitlistEntry struct {
+	ID             uint           `gorm:"primaryKey" json:"id"`
+	UserID         uint           `gorm:"not null;index" json:"user_id"`
+	RoomTypeID     uint           `gorm:"not null;index" json:"room_type_id"`
+	RoomType       RoomType       `gorm:"foreignKey:RoomTypeID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"room_type,omitempty"`
+	Name           string         `gorm:"size:100;not null" json:"name"`
+	Phone          string         `gorm:"size:20;not null" json:"phone"`
+	Email          string         `gorm:"size:100;not null" json:"email"`
+	CheckIn        time.Time      `gorm:"type:date;not null" json:"check_in"`
+	CheckOut       time.Time      `gorm:"type:date;not null" json:"check_out"`
+	Guests         int            `gorm:"not null" json:"guests"` // dewasa + anak
+	Adults         int            `gorm:"not null;default:0" json:"adults"`
+	Children       int            `gorm:"not null;default:0" json:"children"`
+	Status         WaitlistStatus `gorm:"type:varchar(20);default:'waiting';index" json:"status"`
+	ClaimToken     *string        `gorm:"size:64;uniqueIndex" json:"-"`
+	NotifiedAt     *time.Time     `json:"notified_at,omitempty"`
+	ClaimExpiresAt *time.Time     `json:"claim_expires_at,omitempty"`
+	BookingID      *uint          `json:"booking_id,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// Request
+type JoinWaitlistRequest struct {
+	RoomTypeID uint   `json:"room_type_id" binding:"required"`
+	Name       string `json:"name" binding:"required"`
+	Phone      string `json:"phone" binding:"required"`
+	Email      string `json:"email" binding:"required,email"`
+	CheckIn    string `json:"check_in" binding:"required"`
+	CheckOut   string `json:"check_out" binding:"required"`
+	Guests     int    `json:"guests" binding:"omitempty,gt=0"` // boleh diganti adults + children
+	Adults     int    `json:"adults" binding:"omitempty,gt=0"`
+	Children   int    `json:"children" binding:"omitempty,gte=0"`
+}
+
+type ClaimWaitlistRequest struct {
+	Token string `json:"token" binding:"required"`
+}
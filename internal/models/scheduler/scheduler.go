@@ -0,0 +1,52 @@
+// internal/models/scheduler/scheduler.go
+package scheduler
+
+import "time"
+
+// Status job run
+const (
+	RunRunning = "running"
+	RunSuccess = "success"
+	RunFailed  = "failed"
+)
+
+// Pemicu job run
+const (
+	TriggerSchedule = "schedule"
+	TriggerManual   = "manual"
+)
+
+// JobLease: kunci per job di database supaya hanya satu instance (replica) yang
+// menjalankan job. LastSlot = jadwal terakhir yang sudah diambil, mencegah replica
+// lain menjalankan slot jadwal yang sama setelah lease dilepas.
+type JobLease struct {
+	Name        string     `gorm:"primaryKey;size:100" json:"name"`
+	Owner       string     `gorm:"size:150" json:"owner"`
+	LockedUntil time.Time  `gorm:"not null" json:"locked_until"`
+	LastSlot    *time.Time `json:"last_slot,omitempty"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// JobRun: riwayat satu kali eksekusi job
+type JobRun struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	Job         string     `gorm:"size:100;not null;index" json:"job"`
+	Trigger     string     `gorm:"size:20;not null" json:"trigger"`
+	TriggeredBy *uint      `json:"triggered_by,omitempty"` // admin yang menjalankan manual
+	Owner       string     `gorm:"size:150" json:"owner"`  // instance yang menjalankan
+	Status      string     `gorm:"size:20;not null;index" json:"status"`
+	Message     string     `gorm:"type:text" json:"message,omitempty"` // ringkasan hasil
+	Error       string     `gorm:"type:text" json:"error,omitempty"`
+	StartedAt   time.Time  `gorm:"not null;index" json:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+	DurationMs  int64      `gorm:"not null;default:0" json:"duration_ms"`
+}
+
+// JobInfo: job terdaftar beserta jadwal & run terakhirnya
+type JobInfo struct {
+	Name     string    `json:"name"`
+	Schedule string    `json:"schedule"`
+	Timeout  string    `json:"timeout"`
+	NextRun  time.Time `json:"next_run"`
+	LastRun  *JobRun   `json:"last_run,omitempty"`
+}
@@ -0,0 +1,107 @@
+// internal/models/tax/tax.go
+package tax
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Business unit yang punya konfigurasi pajak sendiri
+const (
+	UnitHotel    = "hotel"
+	UnitCafe     = "cafe"
+	UnitSouvenir = "souvenir"
+	UnitBook     = "book"
+)
+
+// Jenis transaksi pemilik baris pajak
+const (
+	SourceBooking    = "booking"
+	SourceFolioEntry = "folio_entry"
+)
+
+// TaxRate: satu komponen pajak/biaya layanan, contoh: PB1 10% atau service 5%.
+// Inclusive = sudah termasuk di harga jual, exclusive = ditambahkan di atas harga.
+// Compound = dihitung dari harga + komponen sebelumnya (urut Priority),
+// contoh: PB1 dihitung dari harga + service charge.
+type TaxRate struct {
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	BusinessUnit string         `gorm:"size:20;not null;index" json:"business_unit"`
+	Code         string         `gorm:"size:20;not null" json:"code"`
+	Name         string         `gorm:"size:100;not null" json:"name"`
+	Rate         float64        `gorm:"not null" json:"rate"` // persen
+	Inclusive    bool           `gorm:"default:false" json:"inclusive"`
+	Compound     bool           `gorm:"default:false" json:"compound"`
+	Priority     int            `gorm:"default:0" json:"priority"` // kecil = dihitung lebih dulu
+	Active       bool           `gorm:"default:true" json:"active"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TaxLine: pajak yang dikenakan pada satu transaksi, disimpan untuk laporan.
+// Kode, tarif & sifatnya disalin supaya tidak berubah bila konfigurasi diedit.
+type TaxLine struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	SourceType    string    `gorm:"size:20;not null;index:idx_tax_lines_source" json:"source_type"`
+	SourceID      uint      `gorm:"not null;index:idx_tax_lines_source" json:"source_id"`
+	BusinessUnit  string    `gorm:"size:20;not null;index" json:"business_unit"`
+	TaxRateID     uint      `gorm:"not null" json:"tax_rate_id"`
+	Code          string    `gorm:"size:20;not null" json:"code"`
+	Name          string    `gorm:"size:100;not null" json:"name"`
+	Rate          float64   `gorm:"not null" json:"rate"`
+	Inclusive     bool      `json:"inclusive"`
+	TaxableAmount int64     `gorm:"not null" json:"taxable_amount"` // dasar pengenaan pajak
+	Amount        int64     `gorm:"not null" json:"amount"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Breakdown: hasil perhitungan pajak atas satu harga
+type Breakdown struct {
+	Net       int64     `json:"net"`       // harga sebelum pajak
+	Tax       int64     `json:"tax"`       // semua pajak, inclusive maupun exclusive
+	Exclusive int64     `json:"exclusive"` // pajak yang ditambahkan di atas harga
+	Gross     int64     `json:"gross"`     // yang dibayar pelanggan = harga + pajak exclusive
+	Lines     []TaxLine `json:"lines"`
+}
+
+// ReportRow: total pajak per business unit & komponen
+type ReportRow struct {
+	BusinessUnit  string  `json:"business_unit"`
+	Code          string  `json:"code"`
+	Name          string  `json:"name"`
+	Rate          float64 `json:"rate"`
+	Inclusive     bool    `json:"inclusive"`
+	Transactions  int     `json:"transactions"`
+	TaxableAmount int64   `json:"taxable_amount"`
+	Amount        int64   `json:"amount"`
+}
+
+type Report struct {
+	Start string      `json:"start"`
+	End   string      `json:"end"` // eksklusif
+	Rows  []ReportRow `json:"rows"`
+	Total int64       `json:"total"`
+}
+
+// Request
+type CreateTaxRateRequest struct {
+	BusinessUnit string  `json:"business_unit" binding:"required,oneof=hotel cafe souvenir book"`
+	Code         string  `json:"code" binding:"required,max=20"`
+	Name         string  `json:"name" binding:"required,max=100"`
+	Rate         float64 `json:"rate" binding:"required,gt=0,lte=100"`
+	Inclusive    bool    `json:"inclusive"`
+	Compound     bool    `json:"compound"`
+	Priority     int     `json:"priority"`
+}
+
+type UpdateTaxRateRequest struct {
+	Code      *string  `json:"code" binding:"omitempty,max=20"`
+	Name      *string  `json:"name" binding:"omitempty,max=100"`
+	Rate      *float64 `json:"rate" binding:"omitempty,gt=0,lte=100"`
+	Inclusive *bool    `json:"inclusive"`
+	Compound  *bool    `json:"compound"`
+	Priority  *int     `json:"priority"`
+	Active    *bool    `json:"active"`
+}
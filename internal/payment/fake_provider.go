@@ -0,0 +1,67 @@
+// internal/payment/fake_provider.go
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// FakeProvider: provider lokal untuk development & test, tanpa panggilan HTTP.
+// Webhook ditandatangani dengan header X-Signature = hex(HMAC-SHA256(secret, body)).
+// Tidak ada halaman pembayaran; pelunasan disimulasikan dengan mengirim webhook bertanda tangan.
+type FakeProvider struct {
+	secret string
+}
+
+func NewFakeProvider(secret string) *FakeProvider {
+	return &FakeProvider{secret: secret}
+}
+
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+func (p *FakeProvider) CreateCharge(ctx context.Context, req ChargeRequest) (*ChargeResult, error) {
+	if req.Amount <= 0 {
+		return nil, fmt.Errorf("amount harus lebih dari 0")
+	}
+	return &ChargeResult{
+		ExternalID: "FAKE-" + req.OrderID,
+		Status:     StatusPending,
+	}, nil
+}
+
+// Sign: buat signature untuk body webhook palsu (dipakai test / simulasi)
+func (p *FakeProvider) Sign(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(p.secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (p *FakeProvider) ParseWebhook(header http.Header, body []byte) (*WebhookEvent, error) {
+	if !hmac.Equal([]byte(p.Sign(body)), []byte(header.Get("X-Signature"))) {
+		return nil, ErrInvalidSignature
+	}
+
+	var n struct {
+		OrderID string `json:"order_id"`
+		Status  string `json:"status"`
+		Amount  int64  `json:"amount"`
+	}
+	if err := json.Unmarshal(body, &n); err != nil {
+		return nil, fmt.Errorf("payload webhook tidak valid: %w", err)
+	}
+
+	return &WebhookEvent{
+		OrderID:    n.OrderID,
+		ExternalID: "FAKE-" + n.OrderID,
+		Status:     Status(n.Status),
+		Amount:     n.Amount,
+		Raw:        string(body),
+	}, nil
+}
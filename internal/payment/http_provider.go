@@ -0,0 +1,151 @@
+// internal/payment/http_provider.go
+package payment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HTTPProvider: implementasi gaya Midtrans Snap.
+// Charge dibuat lewat POST /snap/v1/transactions dan notifikasi diverifikasi dengan
+// signature_key = SHA512(order_id + status_code + gross_amount + server_key).
+type HTTPProvider struct {
+	baseURL   string
+	serverKey string
+	client    *http.Client
+}
+
+func NewHTTPProvider(baseURL, serverKey string, client *http.Client) *HTTPProvider {
+	if client == nil {
+		client = &http.Client{Timeout: 15 * time.Second}
+	}
+	return &HTTPProvider{
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		serverKey: serverKey,
+		client:    client,
+	}
+}
+
+func (p *HTTPProvider) Name() string {
+	return "midtrans"
+}
+
+func (p *HTTPProvider) CreateCharge(ctx context.Context, req ChargeRequest) (*ChargeResult, error) {
+	payload := map[string]interface{}{
+		"transaction_details": map[string]interface{}{
+			"order_id":     req.OrderID,
+			"gross_amount": req.Amount,
+		},
+		"customer_details": map[string]interface{}{
+			"first_name": req.CustomerName,
+			"email":      req.CustomerEmail,
+			"phone":      req.CustomerPhone,
+		},
+		"item_details": []map[string]interface{}{{
+			"id":       req.OrderID,
+			"price":    req.Amount,
+			"quantity": 1,
+			"name":     req.Description,
+		}},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/snap/v1/transactions", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.SetBasicAuth(p.serverKey, "")
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("gagal menghubungi payment gateway: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("payment gateway menolak transaksi (%d): %s", resp.StatusCode, string(raw))
+	}
+
+	var out struct {
+		Token       string `json:"token"`
+		RedirectURL string `json:"redirect_url"`
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("respon payment gateway tidak valid: %w", err)
+	}
+
+	return &ChargeResult{
+		ExternalID:  out.Token,
+		RedirectURL: out.RedirectURL,
+		Status:      StatusPending,
+		Raw:         string(raw),
+	}, nil
+}
+
+func (p *HTTPProvider) ParseWebhook(header http.Header, body []byte) (*WebhookEvent, error) {
+	var n struct {
+		OrderID           string `json:"order_id"`
+		StatusCode        string `json:"status_code"`
+		GrossAmount       string `json:"gross_amount"`
+		SignatureKey      string `json:"signature_key"`
+		TransactionID     string `json:"transaction_id"`
+		TransactionStatus string `json:"transaction_status"`
+		FraudStatus       string `json:"fraud_status"`
+	}
+	if err := json.Unmarshal(body, &n); err != nil {
+		return nil, fmt.Errorf("payload webhook tidak valid: %w", err)
+	}
+
+	sum := sha512.Sum512([]byte(n.OrderID + n.StatusCode + n.GrossAmount + p.serverKey))
+	expected := hex.EncodeToString(sum[:])
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(strings.ToLower(n.SignatureKey))) != 1 {
+		return nil, ErrInvalidSignature
+	}
+
+	// gross_amount dikirim sebagai "150000.00"
+	amount, err := strconv.ParseFloat(n.GrossAmount, 64)
+	if err != nil {
+		return nil, fmt.Errorf("gross_amount tidak valid: %w", err)
+	}
+
+	status := StatusPending
+	switch n.TransactionStatus {
+	case "settlement":
+		status = StatusPaid
+	case "capture":
+		if n.FraudStatus == "" || n.FraudStatus == "accept" {
+			status = StatusPaid
+		}
+	case "deny", "cancel", "failure":
+		status = StatusFailed
+	case "expire":
+		status = StatusExpired
+	}
+
+	return &WebhookEvent{
+		OrderID:    n.OrderID,
+		ExternalID: n.TransactionID,
+		Status:     status,
+		Amount:     int64(amount),
+		Raw:        string(body),
+	}, nil
+}
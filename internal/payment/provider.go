@@ -0,0 +1,90 @@
+// internal/payment/provider.go
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Status pembayaran versi gateway (sudah dinormalisasi)
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusPaid    Status = "paid"
+	StatusFailed  Status = "failed"
+	StatusExpired Status = "expired"
+)
+
+var ErrInvalidSignature = errors.New("signature webhook tidak valid")
+
+// PaymentProvider: kontrak payment gateway (Midtrans, Xendit, fake, dll)
+type PaymentProvider interface {
+	Name() string
+	CreateCharge(ctx context.Context, req ChargeRequest) (*ChargeResult, error)
+	// ParseWebhook memverifikasi signature lalu menerjemahkan payload notifikasi.
+	// Mengembalikan ErrInvalidSignature bila signature tidak cocok.
+	ParseWebhook(header http.Header, body []byte) (*WebhookEvent, error)
+}
+
+type ChargeRequest struct {
+	OrderID       string
+	Amount        int64
+	Description   string
+	CustomerName  string
+	CustomerEmail string
+	CustomerPhone string
+}
+
+type ChargeResult struct {
+	ExternalID  string
+	RedirectURL string
+	Status      Status
+	Raw         string
+}
+
+type WebhookEvent struct {
+	OrderID    string
+	ExternalID string
+	Status     Status
+	Amount     int64
+	Raw        string
+}
+
+// NewProviderFromEnv: pilih provider dari PAYMENT_PROVIDER (midtrans | fake).
+// Provider kosong/tidak dikenal dan secret kosong adalah error startup. Fake provider
+// hanya boleh dipakai bila APP_ENV=development atau test, karena siapa pun yang tahu
+// secret-nya bisa menandai pembayaran sebagai lunas.
+func NewProviderFromEnv() (PaymentProvider, error) {
+	switch name := strings.ToLower(os.Getenv("PAYMENT_PROVIDER")); name {
+	case "midtrans":
+		serverKey := os.Getenv("PAYMENT_SERVER_KEY")
+		if serverKey == "" {
+			return nil, errors.New("PAYMENT_SERVER_KEY wajib diisi untuk provider midtrans")
+		}
+		baseURL := os.Getenv("PAYMENT_BASE_URL")
+		if baseURL == "" {
+			baseURL = "https://app.sandbox.midtrans.com"
+		}
+		return NewHTTPProvider(baseURL, serverKey, nil), nil
+	case "fake":
+		switch env := strings.ToLower(os.Getenv("APP_ENV")); env {
+		case "development", "test":
+		default:
+			return nil, fmt.Errorf("provider fake hanya untuk APP_ENV=development atau test (APP_ENV=%q)", env)
+		}
+		secret := os.Getenv("PAYMENT_WEBHOOK_SECRET")
+		if secret == "" {
+			return nil, errors.New("PAYMENT_WEBHOOK_SECRET wajib diisi untuk provider fake")
+		}
+		return NewFakeProvider(secret), nil
+	case "":
+		return nil, errors.New("PAYMENT_PROVIDER wajib diisi (midtrans | fake)")
+	default:
+		return nil, fmt.Errorf("PAYMENT_PROVIDER %q tidak dikenal (midtrans | fake)", name)
+	}
+}
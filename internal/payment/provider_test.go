@@ -0,0 +1,70 @@
+// internal/payment/provider_test.go
+package payment
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestFakeProviderWebhookSignature(t *testing.T) {
+	p := NewFakeProvider("secret")
+	body := []byte(`{"order_id":"BK-1-1","status":"paid","amount":500000}`)
+
+	header := http.Header{}
+	header.Set("X-Signature", p.Sign(body))
+	event, err := p.ParseWebhook(header, body)
+	if err != nil {
+		t.Fatalf("ParseWebhook: %v", err)
+	}
+	if event.OrderID != "BK-1-1" || event.Status != StatusPaid || event.Amount != 500000 {
+		t.Fatalf("event = %+v", event)
+	}
+
+	tampered := []byte(`{"order_id":"BK-1-1","status":"paid","amount":1}`)
+	if _, err := p.ParseWebhook(header, tampered); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("tampered body: err = %v, want ErrInvalidSignature", err)
+	}
+	if _, err := p.ParseWebhook(http.Header{}, body); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("missing signature: err = %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestNewProviderFromEnv(t *testing.T) {
+	cases := []struct {
+		name     string
+		provider string
+		env      string
+		secret   string
+		key      string
+		wantErr  bool
+	}{
+		{name: "empty provider", wantErr: true},
+		{name: "unknown provider", provider: "stripe", wantErr: true},
+		{name: "fake in production", provider: "fake", env: "production", secret: "s", wantErr: true},
+		{name: "fake without APP_ENV", provider: "fake", secret: "s", wantErr: true},
+		{name: "fake without secret", provider: "fake", env: "development", wantErr: true},
+		{name: "fake in development", provider: "fake", env: "development", secret: "s"},
+		{name: "midtrans without key", provider: "midtrans", wantErr: true},
+		{name: "midtrans", provider: "midtrans", key: "k"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("PAYMENT_PROVIDER", tc.provider)
+			t.Setenv("APP_ENV", tc.env)
+			t.Setenv("PAYMENT_WEBHOOK_SECRET", tc.secret)
+			t.Setenv("PAYMENT_SERVER_KEY", tc.key)
+
+			p, err := NewProviderFromEnv()
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got provider %s", p.Name())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// internal/pdf/pdf.go
+// Penulis PDF sederhana tanpa dependensi luar: teks (Helvetica / Helvetica-Bold),
+// garis dan kotak pada halaman A4. Cukup untuk invoice & voucher booking.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Ukuran A4 dalam point (1/72 inch)
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+type Font int
+
+const (
+	Regular Font = iota
+	Bold
+)
+
+type Align int
+
+const (
+	Left Align = iota
+	Right
+	Center
+)
+
+// Document: koordinat memakai titik kiri-atas halaman sebagai (0,0)
+type Document struct {
+	pages []*bytes.Buffer
+	title string
+}
+
+func New(title string) *Document {
+	d := &Document{title: title}
+	d.AddPage()
+	return d
+}
+
+func (d *Document) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+func (d *Document) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// Text: tulis teks dengan baseline di y. Untuk Right/Center, x adalah tepi kanan/titik tengah.
+func (d *Document) Text(x, y float64, size float64, font Font, align Align, s string) {
+	switch align {
+	case Right:
+		x -= TextWidth(s, size, font)
+	case Center:
+		x -= TextWidth(s, size, font) / 2
+	}
+	fontName := "F1"
+	if font == Bold {
+		fontName = "F2"
+	}
+	fmt.Fprintf(d.page(), "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", fontName, size, x, PageHeight-y, escape(s))
+}
+
+// Line: garis tipis dari (x1,y1) ke (x2,y2)
+func (d *Document) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(d.page(), "%.2f w %.2f %.2f m %.2f %.2f l S\n", width, x1, PageHeight-y1, x2, PageHeight-y2)
+}
+
+// FillRect: kotak terisi warna abu-abu (0 = hitam, 1 = putih)
+func (d *Document) FillRect(x, y, w, h, gray float64) {
+	fmt.Fprintf(d.page(), "q %.2f g %.2f %.2f %.2f %.2f re f Q\n", gray, x, PageHeight-y-h, w, h)
+}
+
+// Bytes: susun objek, xref dan trailer PDF 1.4
+func (d *Document) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1 catalog, 2 pages, 3-4 font, 5 info, lalu (page, content) per halaman
+	const firstPage = 6
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+i*2)
+	}
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	obj(fmt.Sprintf("<< /Title (%s) /Producer (Mutiara Hotel) >>", escape(d.title)))
+
+	for i, content := range d.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			PageWidth, PageHeight, firstPage+i*2+1))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// escape: font standar hanya mendukung Latin-1, karakter lain diganti '?'
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n' || r == '\r' || r == '\t':
+			b.WriteByte(' ')
+		case r < 32 || r > 255:
+			b.WriteByte('?')
+		default:
+			b.WriteByte(byte(r))
+		}
+	}
+	return b.String()
+}
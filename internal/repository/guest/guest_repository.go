@@ -0,0 +1,72 @@
+// internal/repository/guest/guest_repository.go
+package guest
+
+import (
+	"errors"
+
+	"backend/internal/models/auth"
+	"gorm.io/gorm"
+)
+
+type GuestRepository interface {
+	Create(g *auth.Guest) error
+	FindByEmail(email string) (*auth.Guest, error)
+	FindByID(id uint) (*auth.Guest, error)
+	Update(g *auth.Guest) error
+	List(search string, limit, offset int) ([]auth.Guest, int64, error)
+}
+
+type guestRepository struct {
+	db *gorm.DB
+}
+
+func NewGuestRepository(db *gorm.DB) GuestRepository {
+	return &guestRepository{db}
+}
+
+func (r *guestRepository) Create(g *auth.Guest) error {
+	return r.db.Create(g).Error
+}
+
+// FindByEmail: nil, nil bila tidak ada (sama seperti AdminRepository)
+func (r *guestRepository) FindByEmail(email string) (*auth.Guest, error) {
+	var g auth.Guest
+	if err := r.db.Where("email = ?", email).First(&g).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (r *guestRepository) FindByID(id uint) (*auth.Guest, error) {
+	var g auth.Guest
+	if err := r.db.First(&g, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (r *guestRepository) Update(g *auth.Guest) error {
+	return r.db.Save(g).Error
+}
+
+func (r *guestRepository) List(search string, limit, offset int) ([]auth.Guest, int64, error) {
+	var guests []auth.Guest
+	var count int64
+
+	q := r.db.Model(&auth.Guest{})
+	if search != "" {
+		like := "%" + search + "%"
+		q = q.Where("full_name LIKE ? OR email LIKE ? OR phone_number LIKE ?", like, like, like)
+	}
+	if err := q.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+	err := q.Order("id DESC").Limit(limit).Offset(offset).Find(&guests).Error
+	return guests, count, err
+}
@@ -3,6 +3,7 @@ package repobook
 import (
     "backend/internal/models/book"
     "gorm.io/gorm"
+    "gorm.io/gorm/clause"
 )
 
 type ProductRepository interface {
@@ -11,6 +12,10 @@ type ProductRepository interface {
     FindByID(id uint) (*book.ProductBook, error)
     Update(product *book.ProductBook) error
     Delete(id uint) error
+
+    // LockByID: kunci baris produk (FOR UPDATE) saat stok dikurangi, harus lewat WithTx
+    LockByID(id uint) (*book.ProductBook, error)
+    WithTx(tx *gorm.DB) ProductRepository
 }
 
 type productRepository struct {
@@ -46,4 +51,17 @@ func (r *productRepository) Update(product *book.ProductBook) error {
 
 func (r *productRepository) Delete(id uint) error {
     return r.db.Delete(&book.ProductBook{}, id).Error
-}
\ No newline at end of file
+}
+
+func (r *productRepository) WithTx(tx *gorm.DB) ProductRepository {
+    return &productRepository{tx}
+}
+
+func (r *productRepository) LockByID(id uint) (*book.ProductBook, error) {
+    var product book.ProductBook
+    err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&product, id).Error
+    if err != nil {
+        return nil, err
+    }
+    return &product, nil
+}
@@ -4,6 +4,7 @@ package repocafe
 import (
     "backend/internal/models/cafe"
     "gorm.io/gorm"
+    "gorm.io/gorm/clause"
 )
 
 type ProductRepository interface {
@@ -12,6 +13,10 @@ type ProductRepository interface {
     FindByID(id uint) (*cafe.ProductCafe, error)
     Update(product *cafe.ProductCafe) error
     Delete(id uint) error
+
+    // LockByID: kunci baris produk (FOR UPDATE) saat stok dikurangi, harus lewat WithTx
+    LockByID(id uint) (*cafe.ProductCafe, error)
+    WithTx(tx *gorm.DB) ProductRepository
 }
 
 type productRepository struct {
@@ -47,4 +52,17 @@ func (r *productRepository) Update(product *cafe.ProductCafe) error {
 
 func (r *productRepository) Delete(id uint) error {
     return r.db.Delete(&cafe.ProductCafe{}, id).Error
-}
\ No newline at end of file
+}
+
+func (r *productRepository) WithTx(tx *gorm.DB) ProductRepository {
+    return &productRepository{tx}
+}
+
+func (r *productRepository) LockByID(id uint) (*cafe.ProductCafe, error) {
+    var product cafe.ProductCafe
+    err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&product, id).Error
+    if err != nil {
+        return nil, err
+    }
+    return &product, nil
+}
@@ -0,0 +1,103 @@
+// internal/repository/repohotel/addon_repository.go
+package repohotel
+
+import (
+	"backend/internal/models/hotel"
+	"gorm.io/gorm"
+)
+
+type AddOnRepository interface {
+	Create(a *hotel.AddOn) error
+	FindByID(id uint) (*hotel.AddOn, error)
+	FindByIDs(ids []uint) ([]hotel.AddOn, error)
+	List(activeOnly bool) ([]hotel.AddOn, error)
+	Update(a *hotel.AddOn) error
+	Delete(id uint) error
+
+	// Add-on yang sudah ditagihkan ke booking
+	ListByBooking(bookingID uint) ([]hotel.BookingAddOn, error)
+	CreateItems(items []hotel.BookingAddOn) error
+	SaveItem(item *hotel.BookingAddOn) error
+	DeleteItem(bookingID, itemID uint) error
+
+	WithTx(tx *gorm.DB) AddOnRepository
+}
+
+type addOnRepository struct {
+	db *gorm.DB
+}
+
+func NewAddOnRepository(db *gorm.DB) AddOnRepository {
+	return &addOnRepository{db: db}
+}
+
+func (r *addOnRepository) WithTx(tx *gorm.DB) AddOnRepository {
+	return &addOnRepository{db: tx}
+}
+
+func (r *addOnRepository) Create(a *hotel.AddOn) error {
+	return r.db.Create(a).Error
+}
+
+func (r *addOnRepository) FindByID(id uint) (*hotel.AddOn, error) {
+	var a hotel.AddOn
+	if err := r.db.First(&a, id).Error; err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *addOnRepository) FindByIDs(ids []uint) ([]hotel.AddOn, error) {
+	var addOns []hotel.AddOn
+	if len(ids) == 0 {
+		return addOns, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&addOns).Error
+	return addOns, err
+}
+
+func (r *addOnRepository) List(activeOnly bool) ([]hotel.AddOn, error) {
+	var addOns []hotel.AddOn
+	query := r.db
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	err := query.Order("name ASC").Find(&addOns).Error
+	return addOns, err
+}
+
+func (r *addOnRepository) Update(a *hotel.AddOn) error {
+	return r.db.Save(a).Error
+}
+
+func (r *addOnRepository) Delete(id uint) error {
+	return r.db.Delete(&hotel.AddOn{}, id).Error
+}
+
+func (r *addOnRepository) ListByBooking(bookingID uint) ([]hotel.BookingAddOn, error) {
+	var items []hotel.BookingAddOn
+	err := r.db.Where("booking_id = ?", bookingID).Order("id ASC").Find(&items).Error
+	return items, err
+}
+
+func (r *addOnRepository) CreateItems(items []hotel.BookingAddOn) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return r.db.Create(&items).Error
+}
+
+func (r *addOnRepository) SaveItem(item *hotel.BookingAddOn) error {
+	return r.db.Save(item).Error
+}
+
+func (r *addOnRepository) DeleteItem(bookingID, itemID uint) error {
+	res := r.db.Where("booking_id = ?", bookingID).Delete(&hotel.BookingAddOn{}, itemID)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
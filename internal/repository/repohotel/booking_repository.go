@@ -2,23 +2,46 @@
 package repohotel
 
 import (
+	"database/sql"
 	"time"
 
 	"backend/internal/models/hotel"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type BookingRepository interface {
 	Create(booking *hotel.Booking) error
 	FindByID(id uint) (*hotel.Booking, error)
+	FindForUser(id, userID uint) (*hotel.Booking, error)
+	FindDetail(id uint) (*hotel.Booking, error)
+	LockByID(id uint) (*hotel.Booking, error)
 	List(filter BookingFilter) ([]hotel.Booking, int64, error)
 	Update(booking *hotel.Booking) error
 	CountOverlapping(roomID uint, checkIn, checkOut time.Time, excludeID *uint) (int64, error)
+	// IsBlocked: kamar sedang dalam blok maintenance pada sebagian rentang tanggal
+	IsBlocked(roomID uint, checkIn, checkOut time.Time) (bool, error)
 	CheckAvailability(checkIn, checkOut time.Time, roomTypeFilter string) ([]hotel.AvailabilityResponse, error)
 	FindBookingsByDateRange(checkIn, checkOut time.Time) ([]hotel.Booking, error)
+	LockExpiredHolds(now time.Time, limit int) ([]hotel.Booking, error)
+	LockOverdueStays(today time.Time, limit int) ([]hotel.Booking, error)
+	LockNoShows(today time.Time, limit int) ([]hotel.Booking, error)
+	LockRoom(roomID uint) (*hotel.Room, error)
+	LockFreeRooms(roomType string, checkIn, checkOut time.Time, minCapacity, limit int) ([]hotel.Room, error)
+	ReplaceNights(bookingID uint, nights []hotel.BookingNight) error
+	CreateChange(change *hotel.BookingChange) error
+	ListChanges(bookingID uint) ([]hotel.BookingChange, error)
+	StayStats(userID uint) (*hotel.GuestStayStats, error)
+	CreateReservation(res *hotel.Reservation) error
+	ReservationCodeExists(code string) (bool, error)
+	FindReservation(code string) (*hotel.Reservation, error)
+	// LockReservationBookings: semua booking dalam reservasi, harus dipanggil lewat WithTx
+	LockReservationBookings(reservationID uint) ([]hotel.Booking, error)
+	WithTx(tx *gorm.DB) BookingRepository
 }
 
 type BookingFilter struct {
+	UserID *uint
 	Status string
 	Limit  int
 	Offset int
@@ -32,6 +55,20 @@ func NewBookingRepository(db *gorm.DB) BookingRepository {
 	return &bookingRepository{db: db}
 }
 
+// WithTx: repository yang sama tetapi berjalan di dalam transaksi tx
+func (r *bookingRepository) WithTx(tx *gorm.DB) BookingRepository {
+	return &bookingRepository{db: tx}
+}
+
+// activeBookingCond: kondisi SQL untuk booking yang memblokir kamar,
+// termasuk booking pending yang hold-nya belum habis.
+// alias adalah nama/alias tabel bookings di query pemanggil.
+func activeBookingCond(alias string) (string, []interface{}) {
+	return alias + ".deleted_at IS NULL AND (" + alias + ".status IN ? OR (" +
+			alias + ".status = ? AND " + alias + ".hold_expires_at > ?))",
+		[]interface{}{hotel.ActiveBookingStatuses, hotel.BookingStatusPending, time.Now()}
+}
+
 func (r *bookingRepository) Create(booking *hotel.Booking) error {
 	return r.db.Create(booking).Error
 }
@@ -45,6 +82,42 @@ func (r *bookingRepository) FindByID(id uint) (*hotel.Booking, error) {
 	return &b, err
 }
 
+// detailQuery: booking lengkap dengan kamar, tipe kamar, rincian per malam, add-on & pajak
+func (r *bookingRepository) detailQuery() *gorm.DB {
+	return r.db.
+		Preload("Room").
+		Preload("Room.RoomType").
+		Preload("Nights", func(db *gorm.DB) *gorm.DB { return db.Order("date ASC") }).
+		Preload("AddOns", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Taxes")
+}
+
+func (r *bookingRepository) FindDetail(id uint) (*hotel.Booking, error) {
+	var b hotel.Booking
+	if err := r.detailQuery().First(&b, id).Error; err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// FindForUser: detail booking milik user tertentu
+func (r *bookingRepository) FindForUser(id, userID uint) (*hotel.Booking, error) {
+	var b hotel.Booking
+	if err := r.detailQuery().Where("user_id = ?", userID).First(&b, id).Error; err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// LockByID: SELECT ... FOR UPDATE tanpa preload. Harus dipanggil lewat WithTx.
+func (r *bookingRepository) LockByID(id uint) (*hotel.Booking, error) {
+	var b hotel.Booking
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&b, id).Error; err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
 func (r *bookingRepository) List(f BookingFilter) ([]hotel.Booking, int64, error) {
 	var bookings []hotel.Booking
 	var count int64
@@ -53,6 +126,9 @@ func (r *bookingRepository) List(f BookingFilter) ([]hotel.Booking, int64, error
 		Preload("Room").
 		Preload("Room.RoomType")
 
+	if f.UserID != nil {
+		query = query.Where("user_id = ?", *f.UserID)
+	}
 	if f.Status != "" {
 		query = query.Where("status = ?", f.Status)
 	}
@@ -88,12 +164,10 @@ func (r *bookingRepository) Update(booking *hotel.Booking) error {
 func (r *bookingRepository) CountOverlapping(roomID uint, checkIn, checkOut time.Time, excludeID *uint) (int64, error) {
 	var count int64
 
+	cond, args := activeBookingCond("bookings")
 	query := r.db.Model(&hotel.Booking{}).
 		Where("room_id = ?", roomID).
-		Where("status IN ?", []string{
-			string(hotel.BookingStatusConfirmed),
-			string(hotel.BookingStatusCheckedIn),
-		}).
+		Where(cond, args...).
 		Where("check_in < ? AND check_out > ?", checkOut, checkIn)
 
 	if excludeID != nil {
@@ -104,69 +178,266 @@ func (r *bookingRepository) CountOverlapping(roomID uint, checkIn, checkOut time
 	return count, err
 }
 
-// internal/repository/repohotel/booking_repository.go
+func (r *bookingRepository) IsBlocked(roomID uint, checkIn, checkOut time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&hotel.MaintenanceBlock{}).
+		Where("room_id = ? AND start_date < ? AND end_date > ?", roomID, checkOut, checkIn).
+		Count(&count).Error
+	return count > 0, err
+}
 
+// CheckAvailability: hitung kamar per tipe yang bebas untuk seluruh malam di rentang tanggal.
+// Kamar dianggap terpakai bila punya minimal satu booking aktif atau blok maintenance
+// yang beririsan, sama persis dengan aturan LockFreeRooms.
 func (r *bookingRepository) CheckAvailability(checkIn, checkOut time.Time, roomTypeFilter string) ([]hotel.AvailabilityResponse, error) {
-    var results []hotel.AvailabilityResponse
-
-    query := r.db.Table("room_types rt").
-        Joins("JOIN rooms ON rooms.room_type_id = rt.id AND rooms.deleted_at IS NULL AND rooms.status = ?", string(hotel.RoomStatusAvailable)).
-        Joins(`LEFT JOIN bookings ON bookings.room_id = rooms.id 
-               AND bookings.check_in < ? 
-               AND bookings.check_out > ? 
-               AND bookings.status IN ?`,
-            checkOut, checkIn,
-            []string{string(hotel.BookingStatusConfirmed), string(hotel.BookingStatusCheckedIn)},
-        ).
-        Where("rooms.deleted_at IS NULL")
-
-    if roomTypeFilter != "" {
-        query = query.Where("rt.type = ?", roomTypeFilter)
-    }
-
-    query = query.
-        Select(`
-            rt.type,
-            rt.price AS price_per_night,  -- INI YANG DIPERBAIKI
-            COUNT(DISTINCT rooms.id) AS total_rooms,
-            COUNT(DISTINCT bookings.id) AS booked_rooms
-        `).
-        Group("rt.id, rt.type, rt.price")
-
-    rows, err := query.Rows()
-    if err != nil {
-        return nil, err
-    }
-    defer rows.Close()
-
-    for rows.Next() {
-        var res hotel.AvailabilityResponse
-        var total, booked int64
-        if err := rows.Scan(&res.Type, &res.PricePerNight, &total, &booked); err != nil {
-            return nil, err
-        }
-        res.TotalRooms = int(total)
-        res.AvailableRooms = int(total - booked)
-        if res.AvailableRooms < 0 {
-            res.AvailableRooms = 0
-        }
-        results = append(results, res)
-    }
-
-    return results, nil
+	var results []hotel.AvailabilityResponse
+
+	cond, args := activeBookingCond("bookings")
+	joinArgs := append(args, checkOut, checkIn)
+
+	query := r.db.Table("room_types rt").
+		Joins("JOIN rooms ON rooms.room_type_id = rt.id AND rooms.deleted_at IS NULL").
+		Joins("LEFT JOIN bookings ON bookings.room_id = rooms.id AND "+cond+
+			" AND bookings.check_in < ? AND bookings.check_out > ?", joinArgs...).
+		Joins("LEFT JOIN maintenance_blocks mb ON mb.room_id = rooms.id AND mb.deleted_at IS NULL"+
+			" AND mb.start_date < ? AND mb.end_date > ?", checkOut, checkIn).
+		Where("rt.deleted_at IS NULL")
+
+	if roomTypeFilter != "" {
+		query = query.Where("rt.type = ?", roomTypeFilter)
+	}
+
+	query = query.
+		Select(`
+			rt.id,
+			rt.type,
+			rt.name,
+			rt.max_occupancy,
+			rt.base_occupancy,
+			rt.extra_adult_price,
+			rt.extra_child_price,
+			rt.bed_configuration,
+			rt.size,
+			COUNT(DISTINCT rooms.id) AS total_rooms,
+			COUNT(DISTINCT CASE WHEN bookings.id IS NOT NULL OR mb.id IS NOT NULL THEN rooms.id END) AS booked_rooms
+		`).
+		Group("rt.id, rt.type, rt.name, rt.max_occupancy, rt.base_occupancy, rt.extra_adult_price, rt.extra_child_price, " +
+			"rt.bed_configuration, rt.size, rt.sort_order").
+		Order("rt.sort_order ASC, rt.id ASC")
+
+	rows, err := query.Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var res hotel.AvailabilityResponse
+		var total, booked int64
+		var name, beds sql.NullString
+		if err := rows.Scan(&res.RoomTypeID, &res.Type, &name, &res.MaxOccupancy, &res.BaseOccupancy,
+			&res.ExtraAdultPrice, &res.ExtraChildPrice, &beds, &res.Size, &total, &booked); err != nil {
+			return nil, err
+		}
+		res.Name = hotel.RoomType{Type: res.Type, Name: name.String}.DisplayName()
+		res.BedConfiguration = beds.String
+		res.TotalRooms = int(total)
+		res.AvailableRooms = int(total - booked)
+		if res.AvailableRooms < 0 {
+			res.AvailableRooms = 0
+		}
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+// LockRoom: ambil kamar dengan SELECT ... FOR UPDATE.
+// Harus dipanggil lewat WithTx agar kunci bertahan sampai commit.
+func (r *bookingRepository) LockRoom(roomID uint) (*hotel.Room, error) {
+	var room hotel.Room
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("RoomType").
+		First(&room, roomID).Error; err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+// LockFreeRooms: kunci semua kamar dari tipe tersebut (FOR UPDATE) lalu kembalikan
+// maksimal limit kamar berkapasitas minimal minCapacity yang tidak punya booking aktif
+// maupun blok maintenance pada rentang tanggal.
+// Karena seluruh kamar tipe itu terkunci, request paralel untuk tipe yang sama
+// akan menunggu sampai transaksi ini selesai dan melihat booking yang baru dibuat.
+// Harus dipanggil lewat WithTx.
+func (r *bookingRepository) LockFreeRooms(roomType string, checkIn, checkOut time.Time, minCapacity, limit int) ([]hotel.Room, error) {
+	var locked []hotel.Room
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Joins("JOIN room_types ON room_types.id = rooms.room_type_id AND room_types.deleted_at IS NULL").
+		Where("room_types.type = ?", roomType).
+		Order("rooms.id").
+		Find(&locked).Error; err != nil {
+		return nil, err
+	}
+	if len(locked) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint, len(locked))
+	for i, room := range locked {
+		ids[i] = room.ID
+	}
+
+	cond, args := activeBookingCond("b")
+	subArgs := append(args, checkOut, checkIn)
+	blocked, blockedArgs := roomBlockedCond("rooms", checkIn, checkOut)
+
+	var rooms []hotel.Room
+	err := r.db.Preload("RoomType").
+		Where("rooms.id IN ?", ids).
+		Where("rooms.capacity >= ?", minCapacity).
+		Where("NOT EXISTS (SELECT 1 FROM bookings b WHERE b.room_id = rooms.id AND "+cond+
+			" AND b.check_in < ? AND b.check_out > ?)", subArgs...).
+		Where("NOT "+blocked, blockedArgs...).
+		Order("rooms.number").
+		Limit(limit).
+		Find(&rooms).Error
+	return rooms, err
 }
 
+// FindBookingsByDateRange: booking yang memblokir kamar (lihat activeBookingCond) di rentang tanggal
 func (r *bookingRepository) FindBookingsByDateRange(checkIn, checkOut time.Time) ([]hotel.Booking, error) {
 	var bookings []hotel.Booking
+	cond, args := activeBookingCond("bookings")
 	err := r.db.
 		Where("check_in <= ? AND check_out >= ?", checkOut, checkIn).
-		Where("status IN ?", []string{
-			string(hotel.BookingStatusPending),
-			string(hotel.BookingStatusConfirmed),
-			string(hotel.BookingStatusCheckedIn),
-		}).
+		Where(cond, args...).
 		Preload("Room").
 		Preload("Room.RoomType").
 		Find(&bookings).Error
 	return bookings, err
-}
\ No newline at end of file
+}
+
+// LockExpiredHolds: booking pending yang hold-nya sudah lewat. Booking pending lama
+// mendapat hold dari migrasi. Dikunci FOR UPDATE, harus dipanggil lewat WithTx.
+func (r *bookingRepository) LockExpiredHolds(now time.Time, limit int) ([]hotel.Booking, error) {
+	var bookings []hotel.Booking
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("status = ?", hotel.BookingStatusPending).
+		Where("hold_expires_at <= ?", now).
+		Order("id").
+		Limit(limit).
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// LockOverdueStays: tamu checked_in yang tanggal check-out-nya sudah lewat tanpa check-out manual
+func (r *bookingRepository) LockOverdueStays(today time.Time, limit int) ([]hotel.Booking, error) {
+	var bookings []hotel.Booking
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("status = ?", hotel.BookingStatusCheckedIn).
+		Where("DATE(check_out) < ?", today).
+		Order("id").
+		Limit(limit).
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// LockNoShows: booking confirmed/paid yang tanggal check-in-nya sudah lewat tanpa check-in
+func (r *bookingRepository) LockNoShows(today time.Time, limit int) ([]hotel.Booking, error) {
+	var bookings []hotel.Booking
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("status IN ?", []hotel.BookingStatus{hotel.BookingStatusConfirmed, hotel.BookingStatusPaid}).
+		Where("actual_check_in IS NULL AND DATE(check_in) < ?", today).
+		Order("id").
+		Limit(limit).
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// ReplaceNights: ganti rincian harga per malam setelah booking diubah
+func (r *bookingRepository) ReplaceNights(bookingID uint, nights []hotel.BookingNight) error {
+	if err := r.db.Where("booking_id = ?", bookingID).Delete(&hotel.BookingNight{}).Error; err != nil {
+		return err
+	}
+	if len(nights) == 0 {
+		return nil
+	}
+	for i := range nights {
+		nights[i].BookingID = bookingID
+	}
+	return r.db.Create(&nights).Error
+}
+
+func (r *bookingRepository) CreateChange(change *hotel.BookingChange) error {
+	return r.db.Create(change).Error
+}
+
+func (r *bookingRepository) ListChanges(bookingID uint) ([]hotel.BookingChange, error) {
+	var changes []hotel.BookingChange
+	err := r.db.Where("booking_id = ?", bookingID).
+		Order("created_at DESC, id DESC").
+		Find(&changes).Error
+	return changes, err
+}
+
+func (r *bookingRepository) CreateReservation(res *hotel.Reservation) error {
+	return r.db.Create(res).Error
+}
+
+func (r *bookingRepository) ReservationCodeExists(code string) (bool, error) {
+	var count int64
+	err := r.db.Model(&hotel.Reservation{}).Where("code = ?", code).Count(&count).Error
+	return count > 0, err
+}
+
+// FindReservation: reservasi beserta booking, kamar & rincian per malam
+func (r *bookingRepository) FindReservation(code string) (*hotel.Reservation, error) {
+	var res hotel.Reservation
+	err := r.db.
+		Preload("Bookings", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Bookings.Room").
+		Preload("Bookings.Room.RoomType").
+		Preload("Bookings.Nights", func(db *gorm.DB) *gorm.DB { return db.Order("date ASC") }).
+		Preload("Bookings.AddOns", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Bookings.Taxes").
+		Where("code = ?", code).
+		First(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	res.Summarize()
+	return &res, nil
+}
+
+func (r *bookingRepository) LockReservationBookings(reservationID uint) ([]hotel.Booking, error) {
+	var bookings []hotel.Booking
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("reservation_id = ?", reservationID).
+		Order("id").
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// StayStats: ringkasan riwayat menginap satu tamu
+func (r *bookingRepository) StayStats(userID uint) (*hotel.GuestStayStats, error) {
+	var stats hotel.GuestStayStats
+	err := r.db.Model(&hotel.Booking{}).
+		Select(`
+			COUNT(CASE WHEN status = ? THEN 1 END) AS completed_stays,
+			COUNT(CASE WHEN status IN ? THEN 1 END) AS upcoming_stays,
+			COUNT(CASE WHEN status = ? THEN 1 END) AS cancelled_stays,
+			COALESCE(SUM(CASE WHEN status = ? THEN total_nights END), 0) AS total_nights,
+			COALESCE(SUM(CASE WHEN status = ? THEN total_price END), 0) AS total_spent,
+			MAX(CASE WHEN status = ? THEN check_out END) AS last_stay_at`,
+			hotel.BookingStatusCheckedOut,
+			[]hotel.BookingStatus{hotel.BookingStatusPending, hotel.BookingStatusConfirmed, hotel.BookingStatusPaid, hotel.BookingStatusCheckedIn},
+			hotel.BookingStatusCancelled,
+			hotel.BookingStatusCheckedOut,
+			hotel.BookingStatusCheckedOut,
+			hotel.BookingStatusCheckedOut,
+		).
+		Where("user_id = ?", userID).
+		Scan(&stats).Error
+	return &stats, err
+}
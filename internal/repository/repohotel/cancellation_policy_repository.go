@@ -0,0 +1,66 @@
+// internal/repository/repohotel/cancellation_policy_repository.go
+package repohotel
+
+import (
+	"backend/internal/models/hotel"
+	"gorm.io/gorm"
+)
+
+type CancellationPolicyRepository interface {
+	Create(p *hotel.CancellationPolicy) error
+	FindByID(id uint) (*hotel.CancellationPolicy, error)
+	List() ([]hotel.CancellationPolicy, error)
+	Update(p *hotel.CancellationPolicy) error
+	Delete(id uint) error
+	// IsInUse: masih dipakai tipe kamar atau rate plan
+	IsInUse(id uint) (bool, error)
+}
+
+type cancellationPolicyRepository struct {
+	db *gorm.DB
+}
+
+func NewCancellationPolicyRepository(db *gorm.DB) CancellationPolicyRepository {
+	return &cancellationPolicyRepository{db: db}
+}
+
+func (r *cancellationPolicyRepository) Create(p *hotel.CancellationPolicy) error {
+	return r.db.Create(p).Error
+}
+
+// FindByID: termasuk kebijakan yang sudah dihapus, karena booking lama masih merujuknya
+func (r *cancellationPolicyRepository) FindByID(id uint) (*hotel.CancellationPolicy, error) {
+	var p hotel.CancellationPolicy
+	if err := r.db.Unscoped().First(&p, id).Error; err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *cancellationPolicyRepository) List() ([]hotel.CancellationPolicy, error) {
+	var policies []hotel.CancellationPolicy
+	err := r.db.Order("id ASC").Find(&policies).Error
+	return policies, err
+}
+
+func (r *cancellationPolicyRepository) Update(p *hotel.CancellationPolicy) error {
+	return r.db.Save(p).Error
+}
+
+func (r *cancellationPolicyRepository) Delete(id uint) error {
+	return r.db.Delete(&hotel.CancellationPolicy{}, id).Error
+}
+
+func (r *cancellationPolicyRepository) IsInUse(id uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&hotel.RoomType{}).Where("cancellation_policy_id = ?", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+	if err := r.db.Model(&hotel.RatePlan{}).Where("cancellation_policy_id = ?", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
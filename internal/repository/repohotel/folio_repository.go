@@ -0,0 +1,96 @@
+// internal/repository/repohotel/folio_repository.go
+package repohotel
+
+import (
+	"backend/internal/models/hotel"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FolioRepository interface {
+	// FindByBooking: folio beserta entries (urut waktu posting) dan ringkasannya
+	FindByBooking(bookingID uint) (*hotel.Folio, error)
+	// LockByBooking: kunci folio (FOR UPDATE), harus dipanggil lewat WithTx
+	LockByBooking(bookingID uint) (*hotel.Folio, error)
+	Create(f *hotel.Folio) error
+	Update(f *hotel.Folio) error
+	CreateEntries(entries []hotel.FolioEntry) error
+	Balance(folioID uint) (int64, error)
+	// FindInHouseBooking: booking yang sedang check-in di kamar bernomor roomNumber
+	FindInHouseBooking(roomNumber string) (*hotel.Booking, error)
+	WithTx(tx *gorm.DB) FolioRepository
+}
+
+type folioRepository struct {
+	db *gorm.DB
+}
+
+func NewFolioRepository(db *gorm.DB) FolioRepository {
+	return &folioRepository{db: db}
+}
+
+func (r *folioRepository) WithTx(tx *gorm.DB) FolioRepository {
+	return &folioRepository{db: tx}
+}
+
+func (r *folioRepository) FindByBooking(bookingID uint) (*hotel.Folio, error) {
+	var f hotel.Folio
+	err := r.db.
+		Preload("Entries", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC, id ASC") }).
+		Preload("Entries.Taxes").
+		Where("booking_id = ?", bookingID).
+		First(&f).Error
+	if err != nil {
+		return nil, err
+	}
+	f.Summarize()
+	return &f, nil
+}
+
+func (r *folioRepository) LockByBooking(bookingID uint) (*hotel.Folio, error) {
+	var f hotel.Folio
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("booking_id = ?", bookingID).
+		First(&f).Error
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *folioRepository) Create(f *hotel.Folio) error {
+	return r.db.Create(f).Error
+}
+
+func (r *folioRepository) Update(f *hotel.Folio) error {
+	return r.db.Omit("Entries").Save(f).Error
+}
+
+func (r *folioRepository) CreateEntries(entries []hotel.FolioEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return r.db.Create(&entries).Error
+}
+
+func (r *folioRepository) Balance(folioID uint) (int64, error) {
+	var balance int64
+	err := r.db.Model(&hotel.FolioEntry{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("folio_id = ?", folioID).
+		Scan(&balance).Error
+	return balance, err
+}
+
+func (r *folioRepository) FindInHouseBooking(roomNumber string) (*hotel.Booking, error) {
+	var b hotel.Booking
+	err := r.db.
+		Joins("JOIN rooms ON rooms.id = bookings.room_id AND rooms.deleted_at IS NULL").
+		Where("rooms.number = ? AND bookings.status = ?", roomNumber, hotel.BookingStatusCheckedIn).
+		Order("bookings.actual_check_in DESC").
+		First(&b).Error
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
@@ -7,7 +7,7 @@ import (
 
 type GalleryFilter struct {
 	RoomID        *uint
-	RoomType      string // superior | deluxe | executive
+	RoomType      string // slug tipe kamar, contoh: deluxe
 	IncludeGlobal bool   // jika true + RoomType, ikutkan galleries.room_id IS NULL
 	Limit         int
 	Offset        int
@@ -53,10 +53,12 @@ func (r *galleryRepository) List(f GalleryFilter) ([]hotel.Gallery, int64, error
 		if f.IncludeGlobal {
 			// sertakan yang room_id NULL
 			q = q.Joins("LEFT JOIN rooms ON rooms.id = galleries.room_id").
-				Where("rooms.type = ? OR galleries.room_id IS NULL", f.RoomType)
+				Joins("LEFT JOIN room_types ON room_types.id = rooms.room_type_id").
+				Where("room_types.type = ? OR galleries.room_id IS NULL", f.RoomType)
 		} else {
 			q = q.Joins("JOIN rooms ON rooms.id = galleries.room_id").
-				Where("rooms.type = ?", f.RoomType).
+				Joins("JOIN room_types ON room_types.id = rooms.room_type_id").
+				Where("room_types.type = ?", f.RoomType).
 				Where("galleries.room_id IS NOT NULL")
 		}
 	}
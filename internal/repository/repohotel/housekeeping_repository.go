@@ -0,0 +1,121 @@
+// internal/repository/repohotel/housekeeping_repository.go
+package repohotel
+
+import (
+	"backend/internal/models/auth"
+	"backend/internal/models/hotel"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type HousekeepingFilter struct {
+	Status     string
+	AssignedTo *uint
+	RoomID     uint
+}
+
+type HousekeepingRepository interface {
+	Create(t *hotel.HousekeepingTask) error
+	FindByID(id uint) (*hotel.HousekeepingTask, error)
+	LockByID(id uint) (*hotel.HousekeepingTask, error)
+	List(f HousekeepingFilter) ([]hotel.HousekeepingTask, error)
+	ListOpen() ([]hotel.HousekeepingTask, error)
+	Update(t *hotel.HousekeepingTask) error
+	// CountOpenForRoom: task terbuka lain di kamar yang sama (excludeID dikecualikan)
+	CountOpenForRoom(roomID uint, excludeID uint) (int64, error)
+	// UntrackedCleaningRooms: kamar cleaning yang tidak punya task terbuka
+	UntrackedCleaningRooms() ([]hotel.Room, error)
+	// IsHotelStaff: admin hotel yang sudah disetujui
+	IsHotelStaff(adminID uint) (bool, error)
+	WithTx(tx *gorm.DB) HousekeepingRepository
+}
+
+type housekeepingRepository struct {
+	db *gorm.DB
+}
+
+func NewHousekeepingRepository(db *gorm.DB) HousekeepingRepository {
+	return &housekeepingRepository{db: db}
+}
+
+func (r *housekeepingRepository) WithTx(tx *gorm.DB) HousekeepingRepository {
+	return &housekeepingRepository{db: tx}
+}
+
+func (r *housekeepingRepository) Create(t *hotel.HousekeepingTask) error {
+	return r.db.Create(t).Error
+}
+
+func (r *housekeepingRepository) FindByID(id uint) (*hotel.HousekeepingTask, error) {
+	var t hotel.HousekeepingTask
+	if err := r.db.Preload("Room").First(&t, id).Error; err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// LockByID: harus dipanggil lewat WithTx
+func (r *housekeepingRepository) LockByID(id uint) (*hotel.HousekeepingTask, error) {
+	var t hotel.HousekeepingTask
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&t, id).Error; err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *housekeepingRepository) List(f HousekeepingFilter) ([]hotel.HousekeepingTask, error) {
+	var tasks []hotel.HousekeepingTask
+	query := r.db.Preload("Room")
+	if f.Status != "" {
+		query = query.Where("status = ?", f.Status)
+	}
+	if f.AssignedTo != nil {
+		query = query.Where("assigned_to = ?", *f.AssignedTo)
+	}
+	if f.RoomID != 0 {
+		query = query.Where("room_id = ?", f.RoomID)
+	}
+	err := query.Order("created_at DESC, id DESC").Limit(200).Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *housekeepingRepository) ListOpen() ([]hotel.HousekeepingTask, error) {
+	var tasks []hotel.HousekeepingTask
+	err := r.db.Preload("Room").
+		Where("status IN ?", hotel.OpenHousekeepingStatuses).
+		Order("created_at ASC, id ASC").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (r *housekeepingRepository) Update(t *hotel.HousekeepingTask) error {
+	return r.db.Omit("Room").Save(t).Error
+}
+
+func (r *housekeepingRepository) CountOpenForRoom(roomID uint, excludeID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&hotel.HousekeepingTask{}).
+		Where("room_id = ? AND id != ? AND status IN ?", roomID, excludeID, hotel.OpenHousekeepingStatuses).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *housekeepingRepository) UntrackedCleaningRooms() ([]hotel.Room, error) {
+	var rooms []hotel.Room
+	err := r.db.Preload("RoomType").
+		Where("status = ?", hotel.RoomStatusCleaning).
+		Where("NOT EXISTS (SELECT 1 FROM housekeeping_tasks ht WHERE ht.room_id = rooms.id AND ht.status IN ?)",
+			hotel.OpenHousekeepingStatuses).
+		Order("number ASC").
+		Find(&rooms).Error
+	return rooms, err
+}
+
+func (r *housekeepingRepository) IsHotelStaff(adminID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&auth.Admin{}).
+		Where("id = ? AND role IN ? AND is_approved = ?", adminID,
+			[]auth.Role{auth.RoleAdminHotel, auth.RoleSuperAdmin}, true).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,129 @@
+// internal/repository/repohotel/ical_repository.go
+package repohotel
+
+import (
+	"time"
+
+	"backend/internal/models/hotel"
+	"gorm.io/gorm"
+)
+
+type ICalRepository interface {
+	CreateFeed(f *hotel.ICalFeed) error
+	FindFeed(id uint) (*hotel.ICalFeed, error)
+	ListFeeds(activeOnly bool) ([]hotel.ICalFeed, error)
+	UpdateFeed(f *hotel.ICalFeed) error
+	DeleteFeed(id uint) error
+
+	// ImportedBookings: booking blocked hasil impor feed yang masih aktif
+	ImportedBookings(feedID uint) ([]hotel.Booking, error)
+
+	// Export: booking aktif yang belum lewat (check_out > from). Blok hasil impor OTA
+	// tidak ikut di feed per kamar supaya tidak dikirim balik ke OTA asalnya.
+	ActiveBookingsForRoom(roomID uint, from time.Time) ([]hotel.Booking, error)
+	ActiveBookingsForRoomType(roomTypeID uint, from, to time.Time) ([]hotel.Booking, error)
+	CountRoomsOfType(roomTypeID uint) (int64, error)
+	// Blok maintenance ikut diekspor supaya OTA tidak menjual kamar yang sedang diperbaiki
+	BlocksForRoom(roomID uint, from time.Time) ([]hotel.MaintenanceBlock, error)
+	BlocksForRoomType(roomTypeID uint, from, to time.Time) ([]hotel.MaintenanceBlock, error)
+
+	WithTx(tx *gorm.DB) ICalRepository
+}
+
+type icalRepository struct {
+	db *gorm.DB
+}
+
+func NewICalRepository(db *gorm.DB) ICalRepository {
+	return &icalRepository{db: db}
+}
+
+func (r *icalRepository) WithTx(tx *gorm.DB) ICalRepository {
+	return &icalRepository{db: tx}
+}
+
+func (r *icalRepository) CreateFeed(f *hotel.ICalFeed) error {
+	return r.db.Create(f).Error
+}
+
+func (r *icalRepository) FindFeed(id uint) (*hotel.ICalFeed, error) {
+	var f hotel.ICalFeed
+	if err := r.db.First(&f, id).Error; err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *icalRepository) ListFeeds(activeOnly bool) ([]hotel.ICalFeed, error) {
+	var feeds []hotel.ICalFeed
+	query := r.db.Model(&hotel.ICalFeed{})
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	err := query.Order("room_id ASC, id ASC").Find(&feeds).Error
+	return feeds, err
+}
+
+func (r *icalRepository) UpdateFeed(f *hotel.ICalFeed) error {
+	return r.db.Save(f).Error
+}
+
+func (r *icalRepository) DeleteFeed(id uint) error {
+	return r.db.Delete(&hotel.ICalFeed{}, id).Error
+}
+
+func (r *icalRepository) ImportedBookings(feedID uint) ([]hotel.Booking, error) {
+	var bookings []hotel.Booking
+	err := r.db.
+		Where("feed_id = ? AND status = ?", feedID, hotel.BookingStatusBlocked).
+		Find(&bookings).Error
+	return bookings, err
+}
+
+func (r *icalRepository) ActiveBookingsForRoom(roomID uint, from time.Time) ([]hotel.Booking, error) {
+	var bookings []hotel.Booking
+	cond, args := activeBookingCond("bookings")
+	err := r.db.Model(&hotel.Booking{}).
+		Where("room_id = ? AND check_out > ?", roomID, from).
+		Where("feed_id IS NULL").
+		Where(cond, args...).
+		Order("check_in ASC").
+		Find(&bookings).Error
+	return bookings, err
+}
+
+func (r *icalRepository) ActiveBookingsForRoomType(roomTypeID uint, from, to time.Time) ([]hotel.Booking, error) {
+	var bookings []hotel.Booking
+	cond, args := activeBookingCond("bookings")
+	err := r.db.Model(&hotel.Booking{}).
+		Joins("JOIN rooms ON rooms.id = bookings.room_id AND rooms.deleted_at IS NULL").
+		Where("rooms.room_type_id = ?", roomTypeID).
+		Where("bookings.check_in < ? AND bookings.check_out > ?", to, from).
+		Where(cond, args...).
+		Find(&bookings).Error
+	return bookings, err
+}
+
+func (r *icalRepository) BlocksForRoom(roomID uint, from time.Time) ([]hotel.MaintenanceBlock, error) {
+	var blocks []hotel.MaintenanceBlock
+	err := r.db.Where("room_id = ? AND end_date > ?", roomID, from).
+		Order("start_date ASC").
+		Find(&blocks).Error
+	return blocks, err
+}
+
+func (r *icalRepository) BlocksForRoomType(roomTypeID uint, from, to time.Time) ([]hotel.MaintenanceBlock, error) {
+	var blocks []hotel.MaintenanceBlock
+	err := r.db.Model(&hotel.MaintenanceBlock{}).
+		Joins("JOIN rooms ON rooms.id = maintenance_blocks.room_id AND rooms.deleted_at IS NULL").
+		Where("rooms.room_type_id = ?", roomTypeID).
+		Where("maintenance_blocks.start_date < ? AND maintenance_blocks.end_date > ?", to, from).
+		Find(&blocks).Error
+	return blocks, err
+}
+
+func (r *icalRepository) CountRoomsOfType(roomTypeID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&hotel.Room{}).Where("room_type_id = ?", roomTypeID).Count(&count).Error
+	return count, err
+}
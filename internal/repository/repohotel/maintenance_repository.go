@@ -0,0 +1,123 @@
+// internal/repository/repohotel/maintenance_repository.go
+package repohotel
+
+import (
+	"time"
+
+	"backend/internal/models/hotel"
+	"gorm.io/gorm"
+)
+
+type MaintenanceFilter struct {
+	RoomID uint
+	Start  *time.Time // blok yang masih berlaku setelah Start
+	End    *time.Time // blok yang mulai sebelum End
+}
+
+type MaintenanceRepository interface {
+	Create(b *hotel.MaintenanceBlock) error
+	FindByID(id uint) (*hotel.MaintenanceBlock, error)
+	List(f MaintenanceFilter) ([]hotel.MaintenanceBlock, error)
+	Delete(id uint) error
+
+	// CountOverlapping: blok lain pada kamar yang sama di rentang [start, end)
+	CountOverlapping(roomID uint, start, end time.Time) (int64, error)
+	// ActiveBookings: booking aktif di kamar yang beririsan dengan [start, end)
+	ActiveBookings(roomID uint, start, end time.Time) ([]hotel.Booking, error)
+	// FindFreeRoom: kamar sejenis yang kosong (tanpa booking aktif & blok) untuk relokasi
+	FindFreeRoom(roomTypeID uint, excludeIDs []uint, checkIn, checkOut time.Time) (*hotel.Room, error)
+
+	WithTx(tx *gorm.DB) MaintenanceRepository
+}
+
+type maintenanceRepository struct {
+	db *gorm.DB
+}
+
+func NewMaintenanceRepository(db *gorm.DB) MaintenanceRepository {
+	return &maintenanceRepository{db: db}
+}
+
+func (r *maintenanceRepository) WithTx(tx *gorm.DB) MaintenanceRepository {
+	return &maintenanceRepository{db: tx}
+}
+
+// roomBlockedCond: kamar alias punya blok maintenance yang beririsan dengan [start, end).
+// Dipakai bersama oleh CheckAvailability, LockFreeRooms dan daftar kamar publik.
+func roomBlockedCond(alias string, start, end time.Time) (string, []interface{}) {
+	return "EXISTS (SELECT 1 FROM maintenance_blocks mb WHERE mb.room_id = " + alias + ".id" +
+			" AND mb.deleted_at IS NULL AND mb.start_date < ? AND mb.end_date > ?)",
+		[]interface{}{end, start}
+}
+
+func (r *maintenanceRepository) Create(b *hotel.MaintenanceBlock) error {
+	return r.db.Create(b).Error
+}
+
+func (r *maintenanceRepository) FindByID(id uint) (*hotel.MaintenanceBlock, error) {
+	var b hotel.MaintenanceBlock
+	if err := r.db.Preload("Room").Preload("Room.RoomType").First(&b, id).Error; err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (r *maintenanceRepository) List(f MaintenanceFilter) ([]hotel.MaintenanceBlock, error) {
+	var blocks []hotel.MaintenanceBlock
+	q := r.db.Preload("Room").Preload("Room.RoomType")
+	if f.RoomID != 0 {
+		q = q.Where("room_id = ?", f.RoomID)
+	}
+	if f.Start != nil {
+		q = q.Where("end_date > ?", *f.Start)
+	}
+	if f.End != nil {
+		q = q.Where("start_date < ?", *f.End)
+	}
+	err := q.Order("start_date ASC, id ASC").Find(&blocks).Error
+	return blocks, err
+}
+
+func (r *maintenanceRepository) Delete(id uint) error {
+	return r.db.Delete(&hotel.MaintenanceBlock{}, id).Error
+}
+
+func (r *maintenanceRepository) CountOverlapping(roomID uint, start, end time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&hotel.MaintenanceBlock{}).
+		Where("room_id = ? AND start_date < ? AND end_date > ?", roomID, end, start).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *maintenanceRepository) ActiveBookings(roomID uint, start, end time.Time) ([]hotel.Booking, error) {
+	var bookings []hotel.Booking
+	cond, args := activeBookingCond("bookings")
+	err := r.db.Model(&hotel.Booking{}).
+		Where("room_id = ?", roomID).
+		Where(cond, args...).
+		Where("check_in < ? AND check_out > ?", end, start).
+		Order("check_in ASC, id ASC").
+		Find(&bookings).Error
+	return bookings, err
+}
+
+func (r *maintenanceRepository) FindFreeRoom(roomTypeID uint, excludeIDs []uint, checkIn, checkOut time.Time) (*hotel.Room, error) {
+	cond, args := activeBookingCond("b")
+	bookedArgs := append(args, checkOut, checkIn)
+	blocked, blockedArgs := roomBlockedCond("rooms", checkIn, checkOut)
+
+	q := r.db.Where("rooms.room_type_id = ?", roomTypeID).
+		Where("NOT EXISTS (SELECT 1 FROM bookings b WHERE b.room_id = rooms.id AND "+cond+
+			" AND b.check_in < ? AND b.check_out > ?)", bookedArgs...).
+		Where("NOT "+blocked, blockedArgs...)
+	if len(excludeIDs) > 0 {
+		q = q.Where("rooms.id NOT IN ?", excludeIDs)
+	}
+
+	var room hotel.Room
+	if err := q.Order("rooms.number").First(&room).Error; err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
@@ -0,0 +1,71 @@
+// internal/repository/repohotel/occupancy_repository.go
+package repohotel
+
+import (
+	"time"
+
+	"backend/internal/models/hotel"
+	"gorm.io/gorm"
+)
+
+// OccupancyRepository: query ringan untuk tape chart, dua query untuk seluruh grid
+type OccupancyRepository interface {
+	ListRooms(roomTypeID uint) ([]hotel.OccupancyRow, error)
+	ListStays(start, end time.Time, roomTypeID uint) ([]hotel.OccupancyBooking, error)
+	ListBlocks(start, end time.Time, roomTypeID uint) ([]hotel.OccupancyBlock, error)
+}
+
+type occupancyRepository struct {
+	db *gorm.DB
+}
+
+func NewOccupancyRepository(db *gorm.DB) OccupancyRepository {
+	return &occupancyRepository{db: db}
+}
+
+func (r *occupancyRepository) ListRooms(roomTypeID uint) ([]hotel.OccupancyRow, error) {
+	var rows []hotel.OccupancyRow
+	q := r.db.Table("rooms").
+		Select("rooms.id AS room_id, rooms.number, rooms.room_type_id, rt.type AS room_type, rooms.status").
+		Joins("JOIN room_types rt ON rt.id = rooms.room_type_id").
+		Where("rooms.deleted_at IS NULL")
+	if roomTypeID != 0 {
+		q = q.Where("rooms.room_type_id = ?", roomTypeID)
+	}
+	err := q.Order("rt.type, rooms.number").Scan(&rows).Error
+	return rows, err
+}
+
+// ListStays: booking yang menempati kamar di rentang [start, end),
+// termasuk yang sudah check-out agar riwayat tetap terlihat
+func (r *occupancyRepository) ListStays(start, end time.Time, roomTypeID uint) ([]hotel.OccupancyBooking, error) {
+	var stays []hotel.OccupancyBooking
+	cond, args := activeBookingCond("bookings")
+	args = append(args, hotel.BookingStatusCheckedOut)
+
+	q := r.db.Table("bookings").
+		Select("bookings.id, bookings.room_id, bookings.name, bookings.status, bookings.source, bookings.guests, bookings.check_in, bookings.check_out").
+		Where("("+cond+") OR (bookings.deleted_at IS NULL AND bookings.status = ?)", args...).
+		Where("bookings.check_in < ? AND bookings.check_out > ?", end, start)
+	if roomTypeID != 0 {
+		q = q.Joins("JOIN rooms ON rooms.id = bookings.room_id").
+			Where("rooms.room_type_id = ?", roomTypeID)
+	}
+	err := q.Order("bookings.check_in, bookings.id").Scan(&stays).Error
+	return stays, err
+}
+
+// ListBlocks: blok maintenance yang beririsan dengan [start, end)
+func (r *occupancyRepository) ListBlocks(start, end time.Time, roomTypeID uint) ([]hotel.OccupancyBlock, error) {
+	var blocks []hotel.OccupancyBlock
+	q := r.db.Table("maintenance_blocks mb").
+		Select("mb.id, mb.room_id, mb.reason, mb.start_date, mb.end_date").
+		Where("mb.deleted_at IS NULL").
+		Where("mb.start_date < ? AND mb.end_date > ?", end, start)
+	if roomTypeID != 0 {
+		q = q.Joins("JOIN rooms ON rooms.id = mb.room_id").
+			Where("rooms.room_type_id = ?", roomTypeID)
+	}
+	err := q.Order("mb.start_date, mb.id").Scan(&blocks).Error
+	return blocks, err
+}
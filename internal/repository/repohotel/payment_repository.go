@@ -0,0 +1,84 @@
+// internal/repository/repohotel/payment_repository.go
+package repohotel
+
+import (
+	"backend/internal/models/hotel"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PaymentRepository interface {
+	Create(p *hotel.Payment) error
+	FindByOrderID(orderID string) (*hotel.Payment, error)
+	FindPendingByBooking(bookingID uint) (*hotel.Payment, error)
+	ListByBooking(bookingID uint) ([]hotel.Payment, error)
+	SumPaidByBooking(bookingID uint) (int64, error)
+	LockByOrderID(orderID string) (*hotel.Payment, error)
+	Update(p *hotel.Payment) error
+	WithTx(tx *gorm.DB) PaymentRepository
+}
+
+type paymentRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentRepository(db *gorm.DB) PaymentRepository {
+	return &paymentRepository{db: db}
+}
+
+func (r *paymentRepository) WithTx(tx *gorm.DB) PaymentRepository {
+	return &paymentRepository{db: tx}
+}
+
+func (r *paymentRepository) Create(p *hotel.Payment) error {
+	return r.db.Create(p).Error
+}
+
+func (r *paymentRepository) FindByOrderID(orderID string) (*hotel.Payment, error) {
+	var p hotel.Payment
+	if err := r.db.Where("order_id = ?", orderID).First(&p).Error; err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *paymentRepository) FindPendingByBooking(bookingID uint) (*hotel.Payment, error) {
+	var p hotel.Payment
+	err := r.db.Where("booking_id = ? AND status = ?", bookingID, hotel.PaymentStatusPending).
+		Order("id DESC").
+		First(&p).Error
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *paymentRepository) ListByBooking(bookingID uint) ([]hotel.Payment, error) {
+	var payments []hotel.Payment
+	err := r.db.Where("booking_id = ?", bookingID).Order("id DESC").Find(&payments).Error
+	return payments, err
+}
+
+func (r *paymentRepository) SumPaidByBooking(bookingID uint) (int64, error) {
+	var total int64
+	err := r.db.Model(&hotel.Payment{}).
+		Where("booking_id = ? AND status = ?", bookingID, hotel.PaymentStatusPaid).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+// LockByOrderID: SELECT ... FOR UPDATE, agar webhook ganda diproses berurutan
+func (r *paymentRepository) LockByOrderID(orderID string) (*hotel.Payment, error) {
+	var p hotel.Payment
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ?", orderID).
+		First(&p).Error; err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *paymentRepository) Update(p *hotel.Payment) error {
+	return r.db.Save(p).Error
+}
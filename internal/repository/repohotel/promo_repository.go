@@ -0,0 +1,142 @@
+// internal/repository/repohotel/promo_repository.go
+package repohotel
+
+import (
+	"backend/internal/models/hotel"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PromoRepository interface {
+	Create(p *hotel.PromoCode) error
+	FindByID(id uint) (*hotel.PromoCode, error)
+	FindByCode(code string) (*hotel.PromoCode, error)
+	List(activeOnly bool) ([]hotel.PromoCode, error)
+	Update(p *hotel.PromoCode, roomTypeIDs []uint) error
+	Delete(id uint) error
+
+	// LockByCode: kunci baris promo (FOR UPDATE) supaya kuota tidak terlampaui oleh request paralel
+	LockByCode(code string) (*hotel.PromoCode, error)
+	// CountRedemptions: jumlah pemakaian yang masih berlaku (masih ada booking reservasinya
+	// yang belum batal/expired), total untuk kode dan khusus untuk user (user_id, atau email bila tanpa user)
+	CountRedemptions(promoID uint, userID *uint, email string) (total int64, perUser int64, err error)
+	CreateRedemption(r *hotel.PromoRedemption) error
+
+	WithTx(tx *gorm.DB) PromoRepository
+}
+
+type promoRepository struct {
+	db *gorm.DB
+}
+
+func NewPromoRepository(db *gorm.DB) PromoRepository {
+	return &promoRepository{db: db}
+}
+
+func (r *promoRepository) WithTx(tx *gorm.DB) PromoRepository {
+	return &promoRepository{db: tx}
+}
+
+func (r *promoRepository) Create(p *hotel.PromoCode) error {
+	return r.db.Create(p).Error
+}
+
+func (r *promoRepository) FindByID(id uint) (*hotel.PromoCode, error) {
+	var p hotel.PromoCode
+	if err := r.db.Preload("RoomTypes").First(&p, id).Error; err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// FindByCode: termasuk promo yang sudah dihapus, untuk booking lama yang memakainya
+func (r *promoRepository) FindByCode(code string) (*hotel.PromoCode, error) {
+	var p hotel.PromoCode
+	if err := r.db.Unscoped().Where("code = ?", code).First(&p).Error; err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *promoRepository) List(activeOnly bool) ([]hotel.PromoCode, error) {
+	var promos []hotel.PromoCode
+	query := r.db.Preload("RoomTypes")
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	err := query.Order("valid_until DESC, id DESC").Find(&promos).Error
+	return promos, err
+}
+
+func (r *promoRepository) Update(p *hotel.PromoCode, roomTypeIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("RoomTypes").Save(p).Error; err != nil {
+			return err
+		}
+		if roomTypeIDs == nil {
+			return nil
+		}
+		var roomTypes []hotel.RoomType
+		if len(roomTypeIDs) > 0 {
+			if err := tx.Where("id IN ?", roomTypeIDs).Find(&roomTypes).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(p).Association("RoomTypes").Replace(roomTypes); err != nil {
+			return err
+		}
+		p.RoomTypes = roomTypes
+		return nil
+	})
+}
+
+func (r *promoRepository) Delete(id uint) error {
+	return r.db.Delete(&hotel.PromoCode{}, id).Error
+}
+
+func (r *promoRepository) LockByCode(code string) (*hotel.PromoCode, error) {
+	var p hotel.PromoCode
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("code = ?", code).
+		First(&p).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := r.db.Model(&p).Association("RoomTypes").Find(&p.RoomTypes); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *promoRepository) CountRedemptions(promoID uint, userID *uint, email string) (int64, int64, error) {
+	base := func() *gorm.DB {
+		// Reservasi banyak kamar: satu kamar batal tidak membatalkan pemakaian promo
+		return r.db.Table("promo_redemptions pr").
+			Where("pr.promo_code_id = ?", promoID).
+			Where("EXISTS (SELECT 1 FROM bookings b WHERE (b.id = pr.booking_id OR b.reservation_id = pr.reservation_id) AND b.status NOT IN ?)", []string{
+				hotel.BookingStatusCancelled.String(),
+				hotel.BookingStatusExpired.String(),
+			})
+	}
+
+	var total int64
+	if err := base().Count(&total).Error; err != nil {
+		return 0, 0, err
+	}
+
+	var perUser int64
+	query := base()
+	if userID != nil {
+		query = query.Where("pr.user_id = ?", *userID)
+	} else {
+		query = query.Where("pr.email = ?", email)
+	}
+	if err := query.Count(&perUser).Error; err != nil {
+		return 0, 0, err
+	}
+	return total, perUser, nil
+}
+
+func (r *promoRepository) CreateRedemption(rd *hotel.PromoRedemption) error {
+	return r.db.Create(rd).Error
+}
@@ -0,0 +1,114 @@
+// internal/repository/repohotel/rate_plan_repository.go
+package repohotel
+
+import (
+	"time"
+
+	"backend/internal/models/hotel"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RatePlanRepository interface {
+	Create(p *hotel.RatePlan) error
+	FindByID(id uint) (*hotel.RatePlan, error)
+	List(roomTypeID uint) ([]hotel.RatePlan, error)
+	Update(p *hotel.RatePlan) error
+	Delete(id uint) error
+
+	UpsertOverride(o *hotel.RateOverride) error
+	ListOverrides(roomTypeID uint, from, to time.Time) ([]hotel.RateOverride, error)
+	DeleteOverride(id uint) error
+
+	// FindForRange: rate plan aktif & override yang menyentuh rentang [from, to)
+	FindForRange(roomTypeID uint, from, to time.Time) ([]hotel.RatePlan, []hotel.RateOverride, error)
+}
+
+type ratePlanRepository struct {
+	db *gorm.DB
+}
+
+func NewRatePlanRepository(db *gorm.DB) RatePlanRepository {
+	return &ratePlanRepository{db: db}
+}
+
+func (r *ratePlanRepository) Create(p *hotel.RatePlan) error {
+	return r.db.Create(p).Error
+}
+
+func (r *ratePlanRepository) FindByID(id uint) (*hotel.RatePlan, error) {
+	var p hotel.RatePlan
+	if err := r.db.First(&p, id).Error; err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *ratePlanRepository) List(roomTypeID uint) ([]hotel.RatePlan, error) {
+	var plans []hotel.RatePlan
+	query := r.db.Model(&hotel.RatePlan{})
+	if roomTypeID != 0 {
+		query = query.Where("room_type_id = ?", roomTypeID)
+	}
+	err := query.Order("start_date ASC, priority DESC").Find(&plans).Error
+	return plans, err
+}
+
+func (r *ratePlanRepository) Update(p *hotel.RatePlan) error {
+	return r.db.Save(p).Error
+}
+
+func (r *ratePlanRepository) Delete(id uint) error {
+	return r.db.Delete(&hotel.RatePlan{}, id).Error
+}
+
+func (r *ratePlanRepository) UpsertOverride(o *hotel.RateOverride) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "room_type_id"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"price", "note", "updated_at"}),
+	}).Create(o).Error
+}
+
+func (r *ratePlanRepository) ListOverrides(roomTypeID uint, from, to time.Time) ([]hotel.RateOverride, error) {
+	var overrides []hotel.RateOverride
+	query := r.db.Model(&hotel.RateOverride{})
+	if roomTypeID != 0 {
+		query = query.Where("room_type_id = ?", roomTypeID)
+	}
+	if !from.IsZero() {
+		query = query.Where("date >= ?", from.Format("2006-01-02"))
+	}
+	if !to.IsZero() {
+		query = query.Where("date <= ?", to.Format("2006-01-02"))
+	}
+	err := query.Order("date ASC").Find(&overrides).Error
+	return overrides, err
+}
+
+func (r *ratePlanRepository) DeleteOverride(id uint) error {
+	return r.db.Delete(&hotel.RateOverride{}, id).Error
+}
+
+func (r *ratePlanRepository) FindForRange(roomTypeID uint, from, to time.Time) ([]hotel.RatePlan, []hotel.RateOverride, error) {
+	fromStr := from.Format("2006-01-02")
+	lastNight := to.AddDate(0, 0, -1).Format("2006-01-02")
+
+	var plans []hotel.RatePlan
+	if err := r.db.
+		Where("room_type_id = ? AND active = ?", roomTypeID, true).
+		Where("start_date <= ? AND end_date >= ?", lastNight, fromStr).
+		Order("priority DESC, id DESC").
+		Find(&plans).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var overrides []hotel.RateOverride
+	if err := r.db.
+		Where("room_type_id = ?", roomTypeID).
+		Where("date >= ? AND date <= ?", fromStr, lastNight).
+		Find(&overrides).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return plans, overrides, nil
+}
@@ -0,0 +1,101 @@
+// internal/repository/repohotel/report_repository.go
+package repohotel
+
+import (
+	"time"
+
+	"backend/internal/models/hotel"
+	"gorm.io/gorm"
+)
+
+// soldBookingStatuses: booking yang dihitung sebagai room-night terjual.
+// Blok OTA tidak ikut karena pendapatannya tidak tercatat di sistem.
+var soldBookingStatuses = []hotel.BookingStatus{
+	hotel.BookingStatusConfirmed,
+	hotel.BookingStatusPaid,
+	hotel.BookingStatusCheckedIn,
+	hotel.BookingStatusCheckedOut,
+}
+
+type ReportRepository interface {
+	RoomCounts(roomTypeID uint) ([]hotel.ReportRoomCount, error)
+	// SoldStays: booking terjual yang menginap di [start, end) atau check-in di rentang tersebut
+	SoldStays(start, end time.Time, roomTypeID uint) ([]hotel.ReportStay, error)
+	Nights(bookingIDs []uint, start, end time.Time) ([]hotel.ReportNight, error)
+	AddOns(bookingIDs []uint) ([]hotel.ReportAddOn, error)
+	Cancellations(start, end time.Time, roomTypeID uint) ([]hotel.ReportCancellation, error)
+}
+
+type reportRepository struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) ReportRepository {
+	return &reportRepository{db: db}
+}
+
+func (r *reportRepository) RoomCounts(roomTypeID uint) ([]hotel.ReportRoomCount, error) {
+	var rows []hotel.ReportRoomCount
+	q := r.db.Table("room_types rt").
+		Select("rt.id AS room_type_id, rt.type AS room_type, COUNT(rooms.id) AS rooms").
+		Joins("LEFT JOIN rooms ON rooms.room_type_id = rt.id AND rooms.deleted_at IS NULL").
+		Where("rt.deleted_at IS NULL")
+	if roomTypeID != 0 {
+		q = q.Where("rt.id = ?", roomTypeID)
+	}
+	err := q.Group("rt.id, rt.type").Order("rt.type").Scan(&rows).Error
+	return rows, err
+}
+
+func (r *reportRepository) SoldStays(start, end time.Time, roomTypeID uint) ([]hotel.ReportStay, error) {
+	var stays []hotel.ReportStay
+	q := r.db.Table("bookings").
+		Select("bookings.id, rooms.room_type_id, bookings.check_in, bookings.check_out, bookings.total_nights, bookings.subtotal, bookings.discount, bookings.add_on_total, bookings.created_at").
+		Joins("JOIN rooms ON rooms.id = bookings.room_id").
+		Where("bookings.deleted_at IS NULL AND bookings.status IN ?", soldBookingStatuses).
+		Where("bookings.check_in < ? AND bookings.check_out > ?", end, start)
+	if roomTypeID != 0 {
+		q = q.Where("rooms.room_type_id = ?", roomTypeID)
+	}
+	err := q.Scan(&stays).Error
+	return stays, err
+}
+
+func (r *reportRepository) Nights(bookingIDs []uint, start, end time.Time) ([]hotel.ReportNight, error) {
+	var nights []hotel.ReportNight
+	if len(bookingIDs) == 0 {
+		return nights, nil
+	}
+	err := r.db.Table("booking_nights").
+		Select("booking_id, date, price").
+		Where("booking_id IN ? AND date >= ? AND date < ?", bookingIDs, start, end).
+		Scan(&nights).Error
+	return nights, err
+}
+
+func (r *reportRepository) AddOns(bookingIDs []uint) ([]hotel.ReportAddOn, error) {
+	var rows []hotel.ReportAddOn
+	if len(bookingIDs) == 0 {
+		return rows, nil
+	}
+	err := r.db.Table("booking_add_ons").
+		Select("booking_id, add_on_id, name, total").
+		Where("booking_id IN ?", bookingIDs).
+		Order("add_on_id").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *reportRepository) Cancellations(start, end time.Time, roomTypeID uint) ([]hotel.ReportCancellation, error) {
+	var rows []hotel.ReportCancellation
+	q := r.db.Table("bookings").
+		Select("rooms.room_type_id, bookings.cancelled_at").
+		Joins("JOIN rooms ON rooms.id = bookings.room_id").
+		Where("bookings.deleted_at IS NULL AND bookings.status = ?", hotel.BookingStatusCancelled).
+		Where("bookings.cancelled_at >= ? AND bookings.cancelled_at < ?", start, end)
+	if roomTypeID != 0 {
+		q = q.Where("rooms.room_type_id = ?", roomTypeID)
+	}
+	err := q.Scan(&rows).Error
+	return rows, err
+}
@@ -42,7 +42,7 @@ func (r *repo) Create(review *hotel.GuestReview) error {
 func (r *repo) GetApproved() ([]hotel.GuestReview, error) {
 	var reviews []hotel.GuestReview
 	err := r.db.
-		Preload("Admin"). // TAMBAH INI
+		Preload("Guest", publicGuestFields).
 		Where("is_approved = ?", true).
 		Order("created_at DESC").
 		Find(&reviews).Error
@@ -52,7 +52,7 @@ func (r *repo) GetApproved() ([]hotel.GuestReview, error) {
 func (r *repo) GetPending() ([]hotel.GuestReview, error) {
 	var reviews []hotel.GuestReview
 	err := r.db.
-		Preload("Admin"). // TAMBAH INI
+		Preload("Guest", publicGuestFields).
 		Where("is_approved = ?", false).
 		Order("created_at DESC").
 		Find(&reviews).Error
@@ -65,4 +65,9 @@ func (r *repo) Approve(id uint) error {
 
 func (r *repo) Delete(id uint) error {
 	return r.db.Delete(&hotel.GuestReview{}, id).Error
-}
\ No newline at end of file
+}
+
+// publicGuestFields: ulasan tampil publik, jangan ikut kirim kontak & identitas tamu
+func publicGuestFields(db *gorm.DB) *gorm.DB {
+	return db.Select("id", "full_name")
+}
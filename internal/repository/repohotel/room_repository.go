@@ -2,6 +2,8 @@
 package repohotel
 
 import (
+	"time"
+
 	"backend/internal/models/hotel"
 	"gorm.io/gorm"
 )
@@ -86,9 +88,14 @@ func (r *roomRepository) List(f RoomFilter) ([]hotel.Room, int64, error) {
 	return rooms, total, nil
 }
 
+// ListPublic: kamar available yang tidak sedang diblokir maintenance hari ini
 func (r *roomRepository) ListPublic(limit int) ([]hotel.Room, error) {
 	var rooms []hotel.Room
-	query := r.db.Preload("RoomType").Where("status = ?", hotel.RoomStatusAvailable)
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+	blocked, blockedArgs := roomBlockedCond("rooms", today, today.AddDate(0, 0, 1))
+	query := r.db.Preload("RoomType").
+		Where("status = ?", hotel.RoomStatusAvailable).
+		Where("NOT "+blocked, blockedArgs...)
 	if limit > 0 {
 		query = query.Limit(limit)
 	}
@@ -46,7 +46,7 @@ func (r *RoomTypeRepositoryImpl) FindByType(t string) (*hotel.RoomType, error) {
 
 func (r *RoomTypeRepositoryImpl) List() ([]hotel.RoomType, error) {
 	var types []hotel.RoomType
-	if err := r.DB.Find(&types).Error; err != nil {
+	if err := r.DB.Order("sort_order ASC, id ASC").Find(&types).Error; err != nil {
 		return nil, err
 	}
 	return types, nil
@@ -0,0 +1,68 @@
+// internal/repository/repohotel/stay_restriction_repository.go
+package repohotel
+
+import (
+	"time"
+
+	"backend/internal/models/hotel"
+	"gorm.io/gorm"
+)
+
+type StayRestrictionRepository interface {
+	Create(r *hotel.StayRestriction) error
+	FindByID(id uint) (*hotel.StayRestriction, error)
+	List(roomTypeID uint) ([]hotel.StayRestriction, error)
+	Update(r *hotel.StayRestriction) error
+	Delete(id uint) error
+
+	// FindForStay: aturan aktif yang menyentuh tanggal check-in s/d check-out (inklusif)
+	FindForStay(roomTypeID uint, checkIn, checkOut time.Time) ([]hotel.StayRestriction, error)
+}
+
+type stayRestrictionRepository struct {
+	db *gorm.DB
+}
+
+func NewStayRestrictionRepository(db *gorm.DB) StayRestrictionRepository {
+	return &stayRestrictionRepository{db: db}
+}
+
+func (r *stayRestrictionRepository) Create(sr *hotel.StayRestriction) error {
+	return r.db.Create(sr).Error
+}
+
+func (r *stayRestrictionRepository) FindByID(id uint) (*hotel.StayRestriction, error) {
+	var sr hotel.StayRestriction
+	if err := r.db.First(&sr, id).Error; err != nil {
+		return nil, err
+	}
+	return &sr, nil
+}
+
+func (r *stayRestrictionRepository) List(roomTypeID uint) ([]hotel.StayRestriction, error) {
+	var restrictions []hotel.StayRestriction
+	query := r.db.Model(&hotel.StayRestriction{})
+	if roomTypeID != 0 {
+		query = query.Where("room_type_id = ?", roomTypeID)
+	}
+	err := query.Order("start_date ASC, id ASC").Find(&restrictions).Error
+	return restrictions, err
+}
+
+func (r *stayRestrictionRepository) Update(sr *hotel.StayRestriction) error {
+	return r.db.Save(sr).Error
+}
+
+func (r *stayRestrictionRepository) Delete(id uint) error {
+	return r.db.Delete(&hotel.StayRestriction{}, id).Error
+}
+
+func (r *stayRestrictionRepository) FindForStay(roomTypeID uint, checkIn, checkOut time.Time) ([]hotel.StayRestriction, error) {
+	var restrictions []hotel.StayRestriction
+	err := r.db.
+		Where("room_type_id = ? AND active = ?", roomTypeID, true).
+		Where("start_date <= ? AND end_date >= ?", checkOut.Format("2006-01-02"), checkIn.Format("2006-01-02")).
+		Order("id ASC").
+		Find(&restrictions).Error
+	return restrictions, err
+}
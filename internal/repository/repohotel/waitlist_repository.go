@@ -0,0 +1,123 @@
+// internal/repository/repohotel/waitlist_repository.go
+package repohotel
+
+import (
+	"time"
+
+	"backend/internal/models/hotel"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WaitlistFilter struct {
+	UserID     *uint
+	RoomTypeID uint
+	Status     string
+}
+
+type WaitlistRepository interface {
+	Create(e *hotel.WaitlistEntry) error
+	FindForUser(id, userID uint) (*hotel.WaitlistEntry, error)
+	List(f WaitlistFilter) ([]hotel.WaitlistEntry, error)
+	Update(e *hotel.WaitlistEntry) error
+	// ExpireStale: antrean yang tanggal check-in-nya lewat & link klaim yang kedaluwarsa
+	ExpireStale(now, today time.Time) (int64, error)
+	// LockWaiting: antrean waiting urut FIFO, harus dipanggil lewat WithTx
+	LockWaiting(limit int) ([]hotel.WaitlistEntry, error)
+	// CountOutstanding: link klaim aktif untuk tipe kamar & tanggal yang beririsan
+	CountOutstanding(roomTypeID uint, checkIn, checkOut time.Time) (int64, error)
+	// Reserve: ubah notified → claimed secara atomik agar satu token hanya dipakai sekali
+	Reserve(token string, userID uint, now time.Time) (*hotel.WaitlistEntry, error)
+	WithTx(tx *gorm.DB) WaitlistRepository
+}
+
+type waitlistRepository struct {
+	db *gorm.DB
+}
+
+func NewWaitlistRepository(db *gorm.DB) WaitlistRepository {
+	return &waitlistRepository{db: db}
+}
+
+func (r *waitlistRepository) WithTx(tx *gorm.DB) WaitlistRepository {
+	return &waitlistRepository{db: tx}
+}
+
+func (r *waitlistRepository) Create(e *hotel.WaitlistEntry) error {
+	return r.db.Create(e).Error
+}
+
+func (r *waitlistRepository) FindForUser(id, userID uint) (*hotel.WaitlistEntry, error) {
+	var e hotel.WaitlistEntry
+	if err := r.db.Preload("RoomType").Where("user_id = ?", userID).First(&e, id).Error; err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *waitlistRepository) List(f WaitlistFilter) ([]hotel.WaitlistEntry, error) {
+	var entries []hotel.WaitlistEntry
+	q := r.db.Preload("RoomType")
+	if f.UserID != nil {
+		q = q.Where("user_id = ?", *f.UserID)
+	}
+	if f.RoomTypeID != 0 {
+		q = q.Where("room_type_id = ?", f.RoomTypeID)
+	}
+	if f.Status != "" {
+		q = q.Where("status = ?", f.Status)
+	}
+	err := q.Order("id ASC").Find(&entries).Error
+	return entries, err
+}
+
+func (r *waitlistRepository) Update(e *hotel.WaitlistEntry) error {
+	return r.db.Omit("RoomType").Save(e).Error
+}
+
+func (r *waitlistRepository) ExpireStale(now, today time.Time) (int64, error) {
+	res := r.db.Model(&hotel.WaitlistEntry{}).
+		Where("(status IN ? AND check_in < ?) OR (status = ? AND claim_expires_at <= ?)",
+			[]hotel.WaitlistStatus{hotel.WaitlistWaiting, hotel.WaitlistNotified}, today,
+			hotel.WaitlistNotified, now).
+		Update("status", hotel.WaitlistExpired)
+	return res.RowsAffected, res.Error
+}
+
+func (r *waitlistRepository) LockWaiting(limit int) ([]hotel.WaitlistEntry, error) {
+	var entries []hotel.WaitlistEntry
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("RoomType").
+		Where("status = ?", hotel.WaitlistWaiting).
+		Order("id ASC").
+		Limit(limit).
+		Find(&entries).Error
+	return entries, err
+}
+
+func (r *waitlistRepository) CountOutstanding(roomTypeID uint, checkIn, checkOut time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&hotel.WaitlistEntry{}).
+		Where("room_type_id = ? AND status = ?", roomTypeID, hotel.WaitlistNotified).
+		Where("check_in < ? AND check_out > ?", checkOut, checkIn).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *waitlistRepository) Reserve(token string, userID uint, now time.Time) (*hotel.WaitlistEntry, error) {
+	res := r.db.Model(&hotel.WaitlistEntry{}).
+		Where("claim_token = ? AND user_id = ? AND status = ? AND claim_expires_at > ?",
+			token, userID, hotel.WaitlistNotified, now).
+		Update("status", hotel.WaitlistClaimed)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	var e hotel.WaitlistEntry
+	if err := r.db.Preload("RoomType").Where("claim_token = ?", token).First(&e).Error; err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
@@ -0,0 +1,126 @@
+// internal/repository/reposcheduler/scheduler_repository.go
+package reposcheduler
+
+import (
+	"time"
+
+	"backend/internal/models/scheduler"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RunFilter struct {
+	Job    string
+	Status string
+	Limit  int
+	Offset int
+}
+
+type SchedulerRepository interface {
+	// AcquireLease: ambil lease job sampai `until` bila lease kosong/kedaluwarsa.
+	// slot != nil (run terjadwal) hanya berhasil bila slot tersebut belum pernah diambil.
+	AcquireLease(name, owner string, until time.Time, slot *time.Time) (bool, error)
+	// RenewLease: perpanjang lease yang masih dipegang owner; false berarti lease sudah lepas
+	RenewLease(name, owner string, until time.Time) (bool, error)
+	ReleaseLease(name, owner string) error
+
+	CreateRun(run *scheduler.JobRun) error
+	FinishRun(run *scheduler.JobRun) error
+	// AbandonRuns: run berstatus running milik job yang lease-nya sudah kita pegang
+	// berarti instance sebelumnya mati di tengah jalan
+	AbandonRuns(name string, now time.Time) error
+	ListRuns(f RunFilter) ([]scheduler.JobRun, int64, error)
+	LastRun(name string) (*scheduler.JobRun, error)
+}
+
+type schedulerRepository struct {
+	db *gorm.DB
+}
+
+func NewSchedulerRepository(db *gorm.DB) SchedulerRepository {
+	return &schedulerRepository{db: db}
+}
+
+func (r *schedulerRepository) AcquireLease(name, owner string, until time.Time, slot *time.Time) (bool, error) {
+	// Baris lease dibuat sekali, selanjutnya hanya di-UPDATE secara atomik
+	lease := scheduler.JobLease{Name: name, LockedUntil: time.Unix(0, 0).UTC()}
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&lease).Error; err != nil {
+		return false, err
+	}
+
+	now := time.Now().UTC()
+	q := r.db.Model(&scheduler.JobLease{}).
+		Where("name = ? AND locked_until < ?", name, now)
+	updates := map[string]interface{}{"owner": owner, "locked_until": until, "updated_at": now}
+	if slot != nil {
+		q = q.Where("last_slot IS NULL OR last_slot < ?", *slot)
+		updates["last_slot"] = *slot
+	}
+	res := q.Updates(updates)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (r *schedulerRepository) RenewLease(name, owner string, until time.Time) (bool, error) {
+	now := time.Now().UTC()
+	res := r.db.Model(&scheduler.JobLease{}).
+		Where("name = ? AND owner = ? AND locked_until >= ?", name, owner, now).
+		Updates(map[string]interface{}{"locked_until": until, "updated_at": now})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (r *schedulerRepository) ReleaseLease(name, owner string) error {
+	now := time.Now().UTC()
+	return r.db.Model(&scheduler.JobLease{}).
+		Where("name = ? AND owner = ?", name, owner).
+		Updates(map[string]interface{}{"locked_until": now, "updated_at": now}).Error
+}
+
+func (r *schedulerRepository) CreateRun(run *scheduler.JobRun) error {
+	return r.db.Create(run).Error
+}
+
+func (r *schedulerRepository) FinishRun(run *scheduler.JobRun) error {
+	return r.db.Model(run).Select("status", "message", "error", "finished_at", "duration_ms").Updates(run).Error
+}
+
+func (r *schedulerRepository) AbandonRuns(name string, now time.Time) error {
+	return r.db.Model(&scheduler.JobRun{}).
+		Where("job = ? AND status = ?", name, scheduler.RunRunning).
+		Updates(map[string]interface{}{
+			"status":      scheduler.RunFailed,
+			"error":       "instance berhenti sebelum job selesai",
+			"finished_at": now,
+		}).Error
+}
+
+func (r *schedulerRepository) ListRuns(f RunFilter) ([]scheduler.JobRun, int64, error) {
+	var runs []scheduler.JobRun
+	var total int64
+
+	q := r.db.Model(&scheduler.JobRun{})
+	if f.Job != "" {
+		q = q.Where("job = ?", f.Job)
+	}
+	if f.Status != "" {
+		q = q.Where("status = ?", f.Status)
+	}
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := q.Order("started_at DESC, id DESC").Limit(f.Limit).Offset(f.Offset).Find(&runs).Error
+	return runs, total, err
+}
+
+func (r *schedulerRepository) LastRun(name string) (*scheduler.JobRun, error) {
+	var run scheduler.JobRun
+	if err := r.db.Where("job = ?", name).Order("started_at DESC, id DESC").First(&run).Error; err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
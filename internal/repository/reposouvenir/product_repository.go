@@ -4,6 +4,7 @@ package reposouvenir
 import (
     "backend/internal/models/souvenir"
     "gorm.io/gorm"
+    "gorm.io/gorm/clause"
 )
 
 type ProductRepository interface {
@@ -12,6 +13,10 @@ type ProductRepository interface {
     GetAll(page, limit int) ([]souvenir.Product, int64, error)
     Update(product *souvenir.Product) error
     Delete(id uint) error
+
+    // LockByID: kunci baris produk (FOR UPDATE) saat stok dikurangi, harus lewat WithTx
+    LockByID(id uint) (*souvenir.Product, error)
+    WithTx(tx *gorm.DB) ProductRepository
     GetByCategoryID(categoryID uint, page, limit int) ([]souvenir.Product, int64, error)
 }
 
@@ -87,4 +92,17 @@ func (r *productRepository) GetByCategoryID(categoryID uint, page, limit int) ([
         return nil, 0, err
     }
     return products, total, nil
-}
\ No newline at end of file
+}
+
+func (r *productRepository) WithTx(tx *gorm.DB) ProductRepository {
+    return &productRepository{tx}
+}
+
+func (r *productRepository) LockByID(id uint) (*souvenir.Product, error) {
+    var product souvenir.Product
+    err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&product, id).Error
+    if err != nil {
+        return nil, err
+    }
+    return &product, nil
+}
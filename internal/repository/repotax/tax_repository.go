@@ -0,0 +1,110 @@
+// internal/repository/repotax/tax_repository.go
+package repotax
+
+import (
+	"time"
+
+	"backend/internal/models/hotel"
+	"backend/internal/models/tax"
+	"gorm.io/gorm"
+)
+
+type TaxRepository interface {
+	Create(rate *tax.TaxRate) error
+	FindByID(id uint) (*tax.TaxRate, error)
+	// List: unit kosong = semua business unit, urut unit lalu prioritas
+	List(unit string, activeOnly bool) ([]tax.TaxRate, error)
+	Update(rate *tax.TaxRate) error
+	Delete(id uint) error
+
+	// ReplaceLines: ganti seluruh baris pajak milik satu transaksi
+	ReplaceLines(sourceType string, sourceID uint, lines []tax.TaxLine) error
+	// Summary: total pajak per unit & komponen yang diposting di [start, end).
+	// Pajak booking yang batal/expired/no-show tidak ikut dihitung.
+	Summary(start, end time.Time, unit string) ([]tax.ReportRow, error)
+
+	WithTx(tx *gorm.DB) TaxRepository
+}
+
+type taxRepository struct {
+	db *gorm.DB
+}
+
+func NewTaxRepository(db *gorm.DB) TaxRepository {
+	return &taxRepository{db: db}
+}
+
+func (r *taxRepository) WithTx(tx *gorm.DB) TaxRepository {
+	return &taxRepository{db: tx}
+}
+
+func (r *taxRepository) Create(rate *tax.TaxRate) error {
+	return r.db.Create(rate).Error
+}
+
+func (r *taxRepository) FindByID(id uint) (*tax.TaxRate, error) {
+	var rate tax.TaxRate
+	if err := r.db.First(&rate, id).Error; err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func (r *taxRepository) List(unit string, activeOnly bool) ([]tax.TaxRate, error) {
+	var rates []tax.TaxRate
+	query := r.db
+	if unit != "" {
+		query = query.Where("business_unit = ?", unit)
+	}
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+	err := query.Order("business_unit ASC, priority ASC, id ASC").Find(&rates).Error
+	return rates, err
+}
+
+func (r *taxRepository) Update(rate *tax.TaxRate) error {
+	return r.db.Save(rate).Error
+}
+
+func (r *taxRepository) Delete(id uint) error {
+	return r.db.Delete(&tax.TaxRate{}, id).Error
+}
+
+func (r *taxRepository) ReplaceLines(sourceType string, sourceID uint, lines []tax.TaxLine) error {
+	if err := r.db.Where("source_type = ? AND source_id = ?", sourceType, sourceID).
+		Delete(&tax.TaxLine{}).Error; err != nil {
+		return err
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	for i := range lines {
+		lines[i].ID = 0
+		lines[i].SourceType = sourceType
+		lines[i].SourceID = sourceID
+	}
+	return r.db.Create(&lines).Error
+}
+
+func (r *taxRepository) Summary(start, end time.Time, unit string) ([]tax.ReportRow, error) {
+	var rows []tax.ReportRow
+	q := r.db.Table("tax_lines tl").
+		Select("tl.business_unit, tl.code, tl.name, tl.rate, tl.inclusive, "+
+			"COUNT(DISTINCT CONCAT(tl.source_type, ':', tl.source_id)) AS transactions, "+
+			"SUM(tl.taxable_amount) AS taxable_amount, SUM(tl.amount) AS amount").
+		Joins("LEFT JOIN bookings b ON tl.source_type = ? AND b.id = tl.source_id", tax.SourceBooking).
+		Where("tl.created_at >= ? AND tl.created_at < ?", start, end).
+		Where("tl.source_type <> ? OR (b.deleted_at IS NULL AND b.status NOT IN ?)", tax.SourceBooking, []hotel.BookingStatus{
+			hotel.BookingStatusCancelled,
+			hotel.BookingStatusExpired,
+			hotel.BookingStatusNoShow,
+		})
+	if unit != "" {
+		q = q.Where("tl.business_unit = ?", unit)
+	}
+	err := q.Group("tl.business_unit, tl.code, tl.name, tl.rate, tl.inclusive").
+		Order("tl.business_unit, tl.code").
+		Scan(&rows).Error
+	return rows, err
+}
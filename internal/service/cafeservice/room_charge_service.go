@@ -0,0 +1,52 @@
+// backend/internal/service/cafeservice/room_charge_service.go
+package cafeservice
+
+import (
+	"backend/internal/models/cafe"
+	"backend/internal/models/hotel"
+	"backend/internal/repository/repocafe"
+	"backend/internal/service/hotelservice"
+	"gorm.io/gorm"
+)
+
+// RoomChargeService: pesanan cafe yang dibayar belakangan lewat folio kamar tamu
+type RoomChargeService interface {
+	ChargeToRoom(input cafe.RoomChargeRequest, userID uint) (*hotel.Folio, error)
+}
+
+type roomChargeService struct {
+	repo   repocafe.ProductRepository
+	folios hotelservice.FolioService
+	db     *gorm.DB
+}
+
+func NewRoomChargeService(repo repocafe.ProductRepository, folios hotelservice.FolioService, db *gorm.DB) RoomChargeService {
+	return &roomChargeService{repo, folios, db}
+}
+
+func (s *roomChargeService) ChargeToRoom(input cafe.RoomChargeRequest, userID uint) (*hotel.Folio, error) {
+	items := make([]hotelservice.OutletChargeItem, 0, len(input.Items))
+	for _, item := range input.Items {
+		items = append(items, hotelservice.OutletChargeItem{ProductID: item.ProductID, Quantity: item.Quantity})
+	}
+	target := hotel.RoomChargeTarget{BookingID: input.BookingID, RoomNumber: input.RoomNumber}
+	return hotelservice.ChargeOutletToRoom(s.db, s.folios, hotel.FolioSourceCafe, "Cafe", target, items, userID, s.lockProduct)
+}
+
+func (s *roomChargeService) lockProduct(tx *gorm.DB, id uint) (*hotelservice.OutletProduct, error) {
+	repo := s.repo.WithTx(tx)
+	product, err := repo.LockByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return &hotelservice.OutletProduct{
+		ID:    product.ID,
+		Name:  product.Nama,
+		Price: product.Harga,
+		Stock: product.Stok,
+		Save: func(stock int) error {
+			product.Stok = stock
+			return repo.Update(product)
+		},
+	}, nil
+}
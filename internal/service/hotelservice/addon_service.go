@@ -0,0 +1,389 @@
+// internal/service/hotelservice/addon_service.go
+package hotelservice
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"backend/internal/models/hotel"
+	"backend/internal/repository/repohotel"
+	"backend/internal/service/taxservice"
+	"gorm.io/gorm"
+)
+
+type AddOnService interface {
+	Create(req hotel.CreateAddOnRequest) (*hotel.AddOn, error)
+	List(activeOnly bool) ([]hotel.AddOn, error)
+	Update(id uint, req hotel.UpdateAddOnRequest) (*hotel.AddOn, error)
+	Delete(id uint) error
+
+	// Quote: hitung add-on pilihan tamu untuk satu booking, dipanggil di dalam transaksi booking
+	Quote(tx *gorm.DB, selections []hotel.AddOnSelection, nights, guests int) ([]hotel.BookingAddOn, int64, error)
+	// Reprice: hitung ulang add-on booking setelah jumlah malam/tamu berubah, mengembalikan total baru
+	Reprice(tx *gorm.DB, bookingID uint, nights, guests int) (int64, error)
+	// Attach/Remove: tambah atau hapus add-on pada booking yang sudah ada.
+	// source = hotel.BookingChangeByGuest membatasi ke booking milik userID sendiri.
+	Attach(bookingID, userID uint, source string, req hotel.AttachAddOnsRequest) (*hotel.Booking, error)
+	Remove(bookingID, itemID, userID uint, source string) (*hotel.Booking, error)
+}
+
+type addOnService struct {
+	repo        repohotel.AddOnRepository
+	bookingRepo repohotel.BookingRepository
+	taxes       taxservice.TaxService
+	db          *gorm.DB
+}
+
+func NewAddOnService(repo repohotel.AddOnRepository, bookingRepo repohotel.BookingRepository, taxes taxservice.TaxService, db *gorm.DB) AddOnService {
+	return &addOnService{
+		repo:        repo,
+		bookingRepo: bookingRepo,
+		taxes:       taxes,
+		db:          db,
+	}
+}
+
+// addOnLineTotal: harga satuan × jumlah, dikali malam dan/atau tamu sesuai cara hitungnya
+func addOnLineTotal(pricing hotel.AddOnPricing, unitPrice int64, quantity, nights, guests int) int64 {
+	total := unitPrice * int64(quantity)
+	switch pricing {
+	case hotel.AddOnPerNight:
+		total *= int64(nights)
+	case hotel.AddOnPerGuest:
+		total *= int64(guests)
+	case hotel.AddOnPerGuestNight:
+		total *= int64(nights * guests)
+	}
+	return total
+}
+
+func (s *addOnService) Create(req hotel.CreateAddOnRequest) (*hotel.AddOn, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("nama add-on wajib diisi")
+	}
+	a := &hotel.AddOn{
+		Name:        name,
+		Description: req.Description,
+		Pricing:     req.Pricing,
+		Price:       req.Price,
+		MaxQuantity: req.MaxQuantity,
+		Active:      true,
+	}
+	if err := s.repo.Create(a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (s *addOnService) List(activeOnly bool) ([]hotel.AddOn, error) {
+	return s.repo.List(activeOnly)
+}
+
+// Update: perubahan katalog tidak mengubah add-on yang sudah ditagihkan ke booking
+func (s *addOnService) Update(id uint, req hotel.UpdateAddOnRequest) (*hotel.AddOn, error) {
+	a, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.New("nama add-on wajib diisi")
+		}
+		a.Name = name
+	}
+	if req.Description != nil {
+		a.Description = *req.Description
+	}
+	if req.Pricing != nil {
+		a.Pricing = *req.Pricing
+	}
+	if req.Price != nil {
+		a.Price = *req.Price
+	}
+	if req.MaxQuantity != nil {
+		a.MaxQuantity = *req.MaxQuantity
+	}
+	if req.Active != nil {
+		a.Active = *req.Active
+	}
+	if err := s.repo.Update(a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (s *addOnService) Delete(id uint) error {
+	return s.repo.Delete(id)
+}
+
+// mergeSelections: gabungkan add-on yang dipilih lebih dari sekali, quantity kosong = 1
+func mergeSelections(selections []hotel.AddOnSelection) ([]uint, map[uint]int) {
+	var ids []uint
+	qty := make(map[uint]int, len(selections))
+	for _, sel := range selections {
+		q := sel.Quantity
+		if q <= 0 {
+			q = 1
+		}
+		if _, ok := qty[sel.AddOnID]; !ok {
+			ids = append(ids, sel.AddOnID)
+		}
+		qty[sel.AddOnID] += q
+	}
+	return ids, qty
+}
+
+// loadCatalog: add-on aktif dari katalog, error bila ada id yang tidak dikenal
+func (s *addOnService) loadCatalog(repo repohotel.AddOnRepository, ids []uint) (map[uint]hotel.AddOn, error) {
+	addOns, err := repo.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	catalog := make(map[uint]hotel.AddOn, len(addOns))
+	for _, a := range addOns {
+		catalog[a.ID] = a
+	}
+	for _, id := range ids {
+		a, ok := catalog[id]
+		if !ok {
+			return nil, fmt.Errorf("add-on %d tidak ditemukan", id)
+		}
+		if !a.Active {
+			return nil, fmt.Errorf("add-on %s sedang tidak tersedia", a.Name)
+		}
+	}
+	return catalog, nil
+}
+
+func checkMaxQuantity(a hotel.AddOn, quantity int) error {
+	if a.MaxQuantity > 0 && quantity > a.MaxQuantity {
+		return fmt.Errorf("add-on %s maksimal %d per booking", a.Name, a.MaxQuantity)
+	}
+	return nil
+}
+
+func (s *addOnService) Quote(tx *gorm.DB, selections []hotel.AddOnSelection, nights, guests int) ([]hotel.BookingAddOn, int64, error) {
+	if len(selections) == 0 {
+		return nil, 0, nil
+	}
+	ids, qty := mergeSelections(selections)
+	catalog, err := s.loadCatalog(s.repo.WithTx(tx), ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]hotel.BookingAddOn, 0, len(ids))
+	var total int64
+	for _, id := range ids {
+		a := catalog[id]
+		if err := checkMaxQuantity(a, qty[id]); err != nil {
+			return nil, 0, err
+		}
+		item := hotel.BookingAddOn{
+			AddOnID:   a.ID,
+			Name:      a.Name,
+			Pricing:   a.Pricing,
+			UnitPrice: a.Price,
+			Quantity:  qty[id],
+			Total:     addOnLineTotal(a.Pricing, a.Price, qty[id], nights, guests),
+		}
+		total += item.Total
+		items = append(items, item)
+	}
+	return items, total, nil
+}
+
+func (s *addOnService) Reprice(tx *gorm.DB, bookingID uint, nights, guests int) (int64, error) {
+	repo := s.repo.WithTx(tx)
+	items, err := repo.ListByBooking(bookingID)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for i := range items {
+		item := &items[i]
+		// Harga satuan tetap harga saat add-on ditambahkan
+		newTotal := addOnLineTotal(item.Pricing, item.UnitPrice, item.Quantity, nights, guests)
+		if newTotal != item.Total {
+			item.Total = newTotal
+			if err := repo.SaveItem(item); err != nil {
+				return 0, err
+			}
+		}
+		total += item.Total
+	}
+	return total, nil
+}
+
+// addOnAllowed: booking yang masih boleh ditambah/dikurangi add-on-nya.
+// Selama menginap hanya front desk yang bisa menambahkan.
+func addOnAllowed(status hotel.BookingStatus, source string) bool {
+	if modifiableStatuses[status] {
+		return true
+	}
+	return status == hotel.BookingStatusCheckedIn && source == hotel.BookingChangeByAdmin
+}
+
+// lockForAddOns: kunci booking dan pastikan add-on-nya boleh diubah oleh source
+func (s *addOnService) lockForAddOns(tx *gorm.DB, bookingID, userID uint, source string) (*hotel.Booking, error) {
+	b, err := s.bookingRepo.WithTx(tx).LockByID(bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if source == hotel.BookingChangeByGuest && (b.UserID == nil || *b.UserID != userID) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if !addOnAllowed(b.Status, source) {
+		return nil, fmt.Errorf("add-on booking berstatus %s tidak bisa diubah", b.Status)
+	}
+	return b, nil
+}
+
+func (s *addOnService) Attach(bookingID, userID uint, source string, req hotel.AttachAddOnsRequest) (*hotel.Booking, error) {
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, errors.New("gagal memulai transaksi")
+	}
+	repo := s.repo.WithTx(tx)
+
+	b, err := s.lockForAddOns(tx, bookingID, userID, source)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	ids, qty := mergeSelections(req.AddOns)
+	catalog, err := s.loadCatalog(repo, ids)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	existing, err := repo.ListByBooking(b.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	var added []string
+	for _, id := range ids {
+		a := catalog[id]
+		var item *hotel.BookingAddOn
+		for i := range existing {
+			if existing[i].AddOnID == id {
+				item = &existing[i]
+				break
+			}
+		}
+		if item == nil {
+			existing = append(existing, hotel.BookingAddOn{
+				BookingID: b.ID,
+				AddOnID:   a.ID,
+				Name:      a.Name,
+				Pricing:   a.Pricing,
+				UnitPrice: a.Price,
+			})
+			item = &existing[len(existing)-1]
+		}
+		item.Quantity += qty[id]
+		if err := checkMaxQuantity(a, item.Quantity); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		item.Total = addOnLineTotal(item.Pricing, item.UnitPrice, item.Quantity, b.TotalNights, b.Guests)
+		if err := repo.SaveItem(item); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		added = append(added, fmt.Sprintf("%s x%d", a.Name, qty[id]))
+	}
+
+	var total int64
+	for _, item := range existing {
+		total += item.Total
+	}
+	if err := s.updateBookingTotal(tx, b, total, userID, source, "tambah add-on: "+strings.Join(added, ", ")); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	return s.bookingRepo.FindDetail(b.ID)
+}
+
+func (s *addOnService) Remove(bookingID, itemID, userID uint, source string) (*hotel.Booking, error) {
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, errors.New("gagal memulai transaksi")
+	}
+	repo := s.repo.WithTx(tx)
+
+	b, err := s.lockForAddOns(tx, bookingID, userID, source)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	items, err := repo.ListByBooking(b.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	var removed *hotel.BookingAddOn
+	var total int64
+	for i := range items {
+		if items[i].ID == itemID {
+			removed = &items[i]
+			continue
+		}
+		total += items[i].Total
+	}
+	if removed == nil {
+		tx.Rollback()
+		return nil, errors.New("add-on tidak ditemukan pada booking ini")
+	}
+	if err := repo.DeleteItem(b.ID, itemID); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := s.updateBookingTotal(tx, b, total, userID, source, "hapus add-on: "+removed.Name); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	return s.bookingRepo.FindDetail(b.ID)
+}
+
+// updateBookingTotal: simpan total add-on baru ke booking beserta pajak & riwayat perubahannya
+func (s *addOnService) updateBookingTotal(tx *gorm.DB, b *hotel.Booking, addOnTotal int64, userID uint, source, reason string) error {
+	oldAddOnTotal, oldTax, oldTotal := b.AddOnTotal, b.Tax, b.TotalPrice
+	b.AddOnTotal = addOnTotal
+	if err := applyBookingTax(tx, s.taxes, b); err != nil {
+		return err
+	}
+	if err := tx.Model(b).Updates(map[string]interface{}{
+		"add_on_total": b.AddOnTotal,
+		"tax":          b.Tax,
+		"total_price":  b.TotalPrice,
+	}).Error; err != nil {
+		return err
+	}
+
+	var changes []hotel.BookingFieldChange
+	changes = appendChange(changes, "add_on_total", oldAddOnTotal, b.AddOnTotal)
+	changes = appendChange(changes, "tax", oldTax, b.Tax)
+	changes = appendChange(changes, "total_price", oldTotal, b.TotalPrice)
+	return s.bookingRepo.WithTx(tx).CreateChange(&hotel.BookingChange{
+		BookingID: b.ID,
+		ChangedBy: &userID,
+		Source:    source,
+		Changes:   changes,
+		Reason:    reason,
+	})
+}
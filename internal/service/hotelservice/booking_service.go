@@ -2,8 +2,12 @@
 package hotelservice
 
 import (
+	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
+	"log"
+	"math/big"
 	"net/url"
 	"os"
 	"strconv"
@@ -11,27 +15,59 @@ import (
 	"time"
 
 	"backend/internal/models/hotel"
+	"backend/internal/models/tax"
 	"backend/internal/repository/repohotel"
+	"backend/internal/service/taxservice"
 	"gorm.io/gorm"
 )
 
 type BookingService interface {
-	Create(req hotel.CreateBookingRequest) (*hotel.BookingResponse, error)
+	Create(userID uint, req hotel.CreateBookingRequest) (*hotel.BookingResponse, error)
 	Confirm(id uint) error
-	Cancel(id uint) error
+	Cancel(id uint, reason string) (*hotel.CancellationQuote, error)
+	CancellationQuote(id uint) (*hotel.CancellationQuote, error)
 	List(status string, limit, offset int) ([]hotel.Booking, int64, error)
 	CheckAvailability(checkIn, checkOut time.Time, roomType string) ([]hotel.AvailabilityResponse, error)
 	GuestBook(userID uint, req hotel.GuestBookingRequest) (*hotel.GuestBookingResponse, error)
+	ExpireHolds(ctx context.Context) (int, error)
+	// Reservasi: header beberapa booking dengan kode konfirmasi
+	GetReservation(code string) (*hotel.Reservation, error)
+	LookupReservation(code, email string) (*hotel.Reservation, error)
+	ConfirmReservation(code string) (*hotel.Reservation, error)
+	CancelReservation(code, reason string) (*hotel.ReservationCancellation, error)
+	CancelReservationMine(code string, userID uint, reason string) (*hotel.ReservationCancellation, error)
+
+	// ClaimWaitlist: buat booking dari link klaim waitlist
+	ClaimWaitlist(token string, userID uint) (*hotel.GuestBookingResponse, error)
+	Modify(id, userID uint, source string, req hotel.ModifyBookingRequest) (*hotel.Booking, error)
+	ListChanges(id uint) ([]hotel.BookingChange, error)
+
+	// Portal guest: semua dibatasi ke booking milik userID
+	ListMine(userID uint, status string, limit, offset int) ([]hotel.Booking, int64, error)
+	GetMine(id, userID uint) (*hotel.Booking, error)
+	CancellationQuoteMine(id, userID uint) (*hotel.CancellationQuote, error)
+	CancelMine(id, userID uint, reason string) (*hotel.CancellationQuote, error)
+	SummaryMine(id, userID uint) (string, error)
 }
 
 type bookingService struct {
-	bookingRepo repohotel.BookingRepository
-	roomRepo    repohotel.RoomRepository
-	waNumber    string
-	db          *gorm.DB
+	bookingRepo  repohotel.BookingRepository
+	roomRepo     repohotel.RoomRepository
+	paymentRepo  repohotel.PaymentRepository
+	rateService  RatePlanService
+	promos       PromoService
+	policies     CancellationPolicyService
+	restrictions StayRestrictionService
+	addons       AddOnService
+	taxes        taxservice.TaxService
+	waitlist     WaitlistService
+	waNumber     string
+	holdTTL      time.Duration
+	paymentURL   string
+	db           *gorm.DB
 }
 
-func NewBookingService(bookingRepo repohotel.BookingRepository, roomRepo repohotel.RoomRepository, db *gorm.DB) BookingService {
+func NewBookingService(bookingRepo repohotel.BookingRepository, roomRepo repohotel.RoomRepository, paymentRepo repohotel.PaymentRepository, rateService RatePlanService, promos PromoService, policies CancellationPolicyService, restrictions StayRestrictionService, addons AddOnService, taxes taxservice.TaxService, waitlist WaitlistService, db *gorm.DB) BookingService {
 	waNumber := os.Getenv("HOTEL_WHATSAPP_NUMBER")
 	if waNumber == "" {
 		waNumber = "6281396554949"
@@ -39,16 +75,61 @@ func NewBookingService(bookingRepo repohotel.BookingRepository, roomRepo repohot
 	if !strings.HasPrefix(waNumber, "62") {
 		waNumber = "62" + strings.TrimLeft(waNumber, "0")
 	}
+	// Lama hold untuk booking pending, contoh: 30m, 2h
+	holdTTL, err := time.ParseDuration(os.Getenv("BOOKING_HOLD_TTL"))
+	if err != nil || holdTTL <= 0 {
+		holdTTL = 30 * time.Minute
+	}
+	// Halaman frontend untuk membayar reservasi lewat payment gateway
+	paymentURL := os.Getenv("BOOKING_PAYMENT_URL")
+	if paymentURL == "" {
+		paymentURL = "http://localhost:3000/payment"
+	}
 	return &bookingService{
-		bookingRepo: bookingRepo,
-		roomRepo:    roomRepo,
-		waNumber:    waNumber,
-		db:          db,
+		bookingRepo:  bookingRepo,
+		roomRepo:     roomRepo,
+		paymentRepo:  paymentRepo,
+		rateService:  rateService,
+		promos:       promos,
+		policies:     policies,
+		restrictions: restrictions,
+		addons:       addons,
+		taxes:        taxes,
+		waitlist:     waitlist,
+		waNumber:     waNumber,
+		holdTTL:      holdTTL,
+		paymentURL:   paymentURL,
+		db:           db,
+	}
+}
+
+// holdUntil: batas waktu hold untuk booking pending yang baru dibuat
+func (s *bookingService) holdUntil() *time.Time {
+	t := time.Now().Add(s.holdTTL)
+	return &t
+}
+
+// paymentInstructions: link pembayaran online untuk pesan WhatsApp. Pembayaran lewat
+// gateway langsung melunasi booking, jadi harus selesai sebelum hold habis.
+func (s *bookingService) paymentInstructions(code string, holdUntil *time.Time) string {
+	link := s.paymentURL + "?code=" + url.QueryEscape(code)
+	if holdUntil == nil {
+		return "Bayar online lewat:\n" + link
+	}
+	return fmt.Sprintf("Selesaikan pembayaran online sebelum %s lewat:\n%s\nBooking otomatis dibatalkan bila belum dibayar.",
+		holdUntil.Format("02 Jan 2006 15:04"), link)
+}
+
+// applyPromo: validasi & hitung diskon bila guest mengisi kode promo
+func (s *bookingService) applyPromo(tx *gorm.DB, code string, in PromoInput) (*hotel.PromoCode, int64, error) {
+	if strings.TrimSpace(code) == "" {
+		return nil, 0, nil
 	}
+	return s.promos.Apply(tx, code, in)
 }
 
 // Create: Booking oleh guest (pilih kamar spesifik)
-func (s *bookingService) Create(req hotel.CreateBookingRequest) (*hotel.BookingResponse, error) {
+func (s *bookingService) Create(userID uint, req hotel.CreateBookingRequest) (*hotel.BookingResponse, error) {
 	checkIn, err := time.Parse("2006-01-02", req.CheckIn)
 	if err != nil {
 		return nil, errors.New("format check_in tidak valid, gunakan YYYY-MM-DD")
@@ -61,66 +142,152 @@ func (s *bookingService) Create(req hotel.CreateBookingRequest) (*hotel.BookingR
 		return nil, errors.New("check_out harus setelah check_in")
 	}
 
-	room, err := s.roomRepo.FindByID(req.RoomID)
+	nights := int(checkOut.Sub(checkIn).Hours() / 24)
+	if nights <= 0 {
+		return nil, errors.New("jumlah malam tidak valid")
+	}
+	adults, children, err := resolveOccupancy(req.Guests, req.Adults, req.Children)
 	if err != nil {
+		return nil, err
+	}
+
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, errors.New("gagal memulai transaksi")
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Kunci baris kamar supaya request paralel untuk kamar yang sama antre
+	txRepo := s.bookingRepo.WithTx(tx)
+	room, err := txRepo.LockRoom(req.RoomID)
+	if err != nil {
+		tx.Rollback()
 		return nil, errors.New("kamar tidak ditemukan")
 	}
-	if room.Status != hotel.RoomStatusAvailable {
-		return nil, errors.New("kamar tidak tersedia")
+	if err := checkOccupancy(room, adults+children); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	violation, err := s.restrictions.Check(room.RoomTypeID, checkIn, checkOut)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if violation != nil {
+		tx.Rollback()
+		return nil, errors.New(violation.Message)
 	}
 
-	count, err := s.bookingRepo.CountOverlapping(req.RoomID, checkIn, checkOut, nil)
+	count, err := txRepo.CountOverlapping(req.RoomID, checkIn, checkOut, nil)
 	if err != nil {
+		tx.Rollback()
 		return nil, err
 	}
 	if count > 0 {
+		tx.Rollback()
 		return nil, errors.New("kamar sudah dipesan pada tanggal tersebut")
 	}
+	if blocked, err := txRepo.IsBlocked(req.RoomID, checkIn, checkOut); err != nil || blocked {
+		tx.Rollback()
+		if err != nil {
+			return nil, err
+		}
+		return nil, errMaintenanceBlocked
+	}
 
-	nights := int(checkOut.Sub(checkIn).Hours() / 24)
-	if nights <= 0 {
-		return nil, errors.New("jumlah malam tidak valid")
+	rates, subtotal, err := s.rateService.Quote(room.RoomTypeID, checkIn, checkOut)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
 	}
 
-	totalPrice := int64(nights) * room.RoomType.Price
+	promoIn := PromoInput{UserID: &userID, Email: req.Email, RoomTypeID: room.RoomTypeID, Nights: nights, Subtotal: subtotal}
+	promo, discount, err := s.applyPromo(tx, req.PromoCode, promoIn)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	addOns, addOnTotal, err := s.addons.Quote(tx, req.AddOns, nights, adults+children)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 
-	tx := s.db.Begin()
-	if tx.Error != nil {
-		return nil, errors.New("gagal memulai transaksi")
+	reservation, err := s.newReservation(tx, userID, req.Name, req.Phone, req.Email, checkIn, checkOut)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
 
 	booking := &hotel.Booking{
-		RoomID:      req.RoomID,
-		Name:        req.Name,
-		Phone:       req.Phone,
-		Email:       req.Email,
-		CheckIn:     checkIn,
-		CheckOut:    checkOut,
-		Guests:      req.Guests,
-		TotalNights: nights,
-		TotalPrice:  totalPrice,
-		Status:      hotel.BookingStatusPending,
-		Notes:       req.Notes,
+		RoomID:        req.RoomID,
+		UserID:        &userID,
+		ReservationID: &reservation.ID,
+		Name:          req.Name,
+		Phone:         req.Phone,
+		Email:         req.Email,
+		CheckIn:       checkIn,
+		CheckOut:      checkOut,
+		Guests:        adults + children,
+		Adults:        adults,
+		Children:      children,
+		TotalNights:   nights,
+		Subtotal:      subtotal,
+		Discount:      discount,
+		AddOnTotal:    addOnTotal,
+		Status:        hotel.BookingStatusPending,
+		Notes:         req.Notes,
+		HoldExpiresAt: s.holdUntil(),
+		Nights:        toBookingNights(rates),
+		AddOns:        addOns,
+
+		CancellationPolicyID: s.policies.Resolve(room.RoomTypeID, rates),
+	}
+	booking.ExtraGuestCharge = extraGuestCharge(room.RoomType, adults, children)
+	booking.ExtraGuestTotal = booking.ExtraGuestCharge * int64(nights)
+
+	if promo != nil {
+		booking.PromoCode = promo.Code
+	}
+	if err := applyBookingTax(tx, s.taxes, booking); err != nil {
+		tx.Rollback()
+		return nil, err
 	}
 
 	if err := tx.Create(booking).Error; err != nil {
 		tx.Rollback()
 		return nil, err
 	}
+	if promo != nil {
+		if err := s.promos.Redeem(tx, promo, booking.ID, booking.ReservationID, promoIn, discount); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
 
 	if err := tx.Commit().Error; err != nil {
 		return nil, err
 	}
 
-	waURL := s.generateWhatsAppURL(booking, room, totalPrice, nights)
+	waURL := s.generateWhatsAppURL(booking, room, rates, booking.TotalPrice, nights, s.paymentInstructions(reservation.Code, booking.HoldExpiresAt))
 	return &hotel.BookingResponse{
-		ID:          booking.ID,
-		WhatsAppURL: waURL,
+		ID:              booking.ID,
+		ReservationCode: reservation.Code,
+		Nights:          rates,
+		Subtotal:        subtotal,
+		PromoCode:       booking.PromoCode,
+		Discount:        discount,
+		AddOns:          booking.AddOns,
+		AddOnTotal:      addOnTotal,
+		ExtraGuestTotal: booking.ExtraGuestTotal,
+		Tax:             booking.Tax,
+		Taxes:           booking.Taxes,
+		TotalPrice:      booking.TotalPrice,
+		WhatsAppURL:     waURL,
 	}, nil
 }
 
@@ -137,6 +304,12 @@ func (s *bookingService) GuestBook(userID uint, req hotel.GuestBookingRequest) (
 	if !checkOut.After(checkIn) {
 		return nil, errors.New("check_out harus setelah check_in")
 	}
+	adults, children, err := resolveOccupancy(req.Guests, req.Adults, req.Children)
+	if err != nil {
+		return nil, err
+	}
+	guests := adults + children
+	req.Guests = guests
 
 	avail, err := s.CheckAvailability(checkIn, checkOut, req.RoomType)
 	if err != nil {
@@ -145,14 +318,20 @@ func (s *bookingService) GuestBook(userID uint, req hotel.GuestBookingRequest) (
 	if len(avail) == 0 {
 		return nil, errors.New("tipe kamar tidak ditemukan")
 	}
+	if avail[0].Restriction != nil {
+		return nil, errors.New(avail[0].Restriction.Message)
+	}
+	if avail[0].MaxOccupancy > 0 && guests > avail[0].MaxOccupancy {
+		return nil, fmt.Errorf("kamar %s maksimal %d tamu per kamar", avail[0].Name, avail[0].MaxOccupancy)
+	}
 	if avail[0].AvailableRooms < req.TotalRooms {
 		return nil, fmt.Errorf("hanya %d kamar tersedia", avail[0].AvailableRooms)
 	}
 
 	nights := int(checkOut.Sub(checkIn).Hours() / 24)
-	pricePerNight := avail[0].PricePerNight
-	pricePerRoom := int64(nights) * pricePerNight
-	totalPrice := pricePerRoom * int64(req.TotalRooms)
+	rates := avail[0].Nights
+	pricePerRoom := avail[0].TotalPrice
+	subtotal := pricePerRoom * int64(req.TotalRooms)
 
 	tx := s.db.Begin()
 	if tx.Error != nil {
@@ -160,30 +339,88 @@ func (s *bookingService) GuestBook(userID uint, req hotel.GuestBookingRequest) (
 	}
 	defer func() { if r := recover(); r != nil { tx.Rollback() } }()
 
-	var rooms []hotel.Room
-	if err := tx.Preload("RoomType").
-		Where("room_type_id IN (SELECT id FROM room_types WHERE type = ?) AND status = ? AND deleted_at IS NULL", req.RoomType, string(hotel.RoomStatusAvailable)).
-		Limit(req.TotalRooms).
-		Find(&rooms).Error; err != nil {
+	// Alokasi berdasarkan booking yang beririsan, dengan kamar terkunci (FOR UPDATE).
+	// Hanya kamar yang kapasitasnya cukup untuk jumlah tamu per kamar.
+	rooms, err := s.bookingRepo.WithTx(tx).LockFreeRooms(req.RoomType, checkIn, checkOut, guests, req.TotalRooms)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if len(rooms) < req.TotalRooms {
+		tx.Rollback()
+		return nil, fmt.Errorf("hanya %d kamar tersedia untuk %d tamu per kamar", len(rooms), guests)
+	}
+	extraCharge := extraGuestCharge(rooms[0].RoomType, adults, children)
+	extraPerRoom := extraCharge * int64(nights)
+
+	// Promo dihitung atas total semua kamar lalu dibagi rata per booking
+	promoIn := PromoInput{UserID: &userID, Email: req.Email, RoomTypeID: avail[0].RoomTypeID, Nights: nights, Subtotal: subtotal}
+	promo, discount, err := s.applyPromo(tx, req.PromoCode, promoIn)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	// Add-on dipilih per kamar, tidak ikut diskon promo
+	addOns, addOnPerRoom, err := s.addons.Quote(tx, req.AddOns, nights, guests)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	addOnTotal := addOnPerRoom * int64(len(rooms))
+	promoCode := ""
+	if promo != nil {
+		promoCode = promo.Code
+	}
+
+	// Semua kamar dalam satu checkout dikelompokkan di bawah satu kode reservasi
+	reservation, err := s.newReservation(tx, userID, req.Name, req.Phone, req.Email, checkIn, checkOut)
+	if err != nil {
 		tx.Rollback()
 		return nil, err
 	}
 
+	holdUntil := s.holdUntil()
+	policyID := s.policies.Resolve(avail[0].RoomTypeID, rates)
 	var bookingIDs []uint
-	for _, room := range rooms {
+	var taxTotal, totalPrice int64
+	var taxLines []tax.TaxLine
+	for i, room := range rooms {
+		roomDiscount := discount / int64(len(rooms))
+		if i == 0 {
+			roomDiscount += discount % int64(len(rooms))
+		}
+
 		booking := &hotel.Booking{
-			RoomID:      room.ID,
-			UserID:      &userID,
-			Name:        req.Name,
-			Phone:       req.Phone,
-			Email:       req.Email,
-			CheckIn:     checkIn,
-			CheckOut:    checkOut,
-			Guests:      req.Guests,
-			TotalNights: nights,
-			TotalPrice:  pricePerRoom, // per kamar
-			Status:      hotel.BookingStatusPending,
-			Notes:       req.Notes,
+			RoomID:        room.ID,
+			UserID:        &userID,
+			ReservationID: &reservation.ID,
+			Name:          req.Name,
+			Phone:         req.Phone,
+			Email:         req.Email,
+			CheckIn:       checkIn,
+			CheckOut:      checkOut,
+			Guests:        guests,
+			Adults:        adults,
+			Children:      children,
+			TotalNights:   nights,
+			Subtotal:      pricePerRoom, // per kamar
+			PromoCode:     promoCode,
+			Discount:      roomDiscount,
+			AddOnTotal:    addOnPerRoom,
+			Status:        hotel.BookingStatusPending,
+			Notes:         req.Notes,
+			HoldExpiresAt: holdUntil,
+			Nights:        toBookingNights(rates),
+			AddOns:        append([]hotel.BookingAddOn(nil), addOns...),
+
+			ExtraGuestCharge:     extraCharge,
+			ExtraGuestTotal:      extraPerRoom,
+			CancellationPolicyID: policyID,
+		}
+		// Pajak per booking karena tiap kamar bisa dibatalkan/diubah sendiri
+		if err := applyBookingTax(tx, s.taxes, booking); err != nil {
+			tx.Rollback()
+			return nil, err
 		}
 
 		if err := tx.Create(booking).Error; err != nil {
@@ -191,77 +428,400 @@ func (s *bookingService) GuestBook(userID uint, req hotel.GuestBookingRequest) (
 			return nil, err
 		}
 		bookingIDs = append(bookingIDs, booking.ID)
+		taxTotal += booking.Tax
+		totalPrice += booking.TotalPrice
+		taxLines = mergeTaxLines(taxLines, booking.Taxes)
+	}
+
+	// Satu checkout = satu pemakaian promo, dicatat pada booking pertama
+	if promo != nil {
+		if err := s.promos.Redeem(tx, promo, bookingIDs[0], &reservation.ID, promoIn, discount); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
 	}
 
 	if err := tx.Commit().Error; err != nil {
 		return nil, err
 	}
 
-	waURL := s.generateWhatsAppURLGuest(req, avail[0], nights, totalPrice, len(bookingIDs), checkIn, checkOut)
+	extraLines := formatExtraGuestLine("Tambahan tamu per kamar", extraCharge, nights)
+	payLines := s.paymentInstructions(reservation.Code, holdUntil)
+	waURL := s.generateWhatsAppURLGuest(req, avail[0].Name, rates, nights, formatPromoLines(subtotal, promoCode, discount), formatAddOnLines("Add-on per kamar", addOns)+extraLines, formatTaxLines(taxLines), payLines, totalPrice, len(bookingIDs), checkIn, checkOut)
 	return &hotel.GuestBookingResponse{
-		BookingIDs:  bookingIDs,
-		WhatsAppURL: waURL,
+		ReservationCode: reservation.Code,
+		BookingIDs:      bookingIDs,
+		NightsPerRoom:   rates,
+		Subtotal:        subtotal,
+		PromoCode:       promoCode,
+		Discount:        discount,
+		AddOnsPerRoom:   addOns,
+		AddOnTotal:      addOnTotal,
+		ExtraGuestTotal: extraPerRoom * int64(len(bookingIDs)),
+		Tax:             taxTotal,
+		TotalPrice:      totalPrice,
+		WhatsAppURL:     waURL,
 	}, nil
 }
 
-// Confirm: Admin konfirmasi → ubah status kamar jadi Booked
+// Confirm: Admin konfirmasi booking pending → confirmed (status kamar tidak diubah)
 func (s *bookingService) Confirm(id uint) error {
 	tx := s.db.Begin()
 	if tx.Error != nil {
 		return errors.New("gagal memulai transaksi")
 	}
 
-	b, err := s.bookingRepo.FindByID(id)
+	// Dibaca ulang dengan lock supaya konfirmasi/pembatalan/expire bersamaan tidak saling timpa
+	b, err := s.bookingRepo.WithTx(tx).LockByID(id)
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
-	if b.Status != hotel.BookingStatusPending {
+	if err := s.confirmInTx(tx, b); err != nil {
 		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// confirmInTx: konfirmasi satu booking pending di dalam transaksi tx; b harus sudah
+// dikunci (LockByID/LockReservationBookings) di tx yang sama
+func (s *bookingService) confirmInTx(tx *gorm.DB, b *hotel.Booking) error {
+	if b.Status != hotel.BookingStatusPending {
 		return errors.New("hanya booking pending yang bisa dikonfirmasi")
 	}
 
-	b.Status = hotel.BookingStatusConfirmed
-	if err := tx.Save(b).Error; err != nil {
-		tx.Rollback()
+	// Hold bisa saja sudah habis dan kamarnya diambil booking lain
+	txRepo := s.bookingRepo.WithTx(tx)
+	if _, err := txRepo.LockRoom(b.RoomID); err != nil {
+		return err
+	}
+	if err := ensureRoomFree(txRepo, b); err != nil {
 		return err
 	}
 
-	room, err := s.roomRepo.FindByID(b.RoomID)
-	if err == nil && room.Status == hotel.RoomStatusAvailable {
-		room.Status = hotel.RoomStatusBooked
-		tx.Save(room)
+	b.Status = hotel.BookingStatusConfirmed
+	b.HoldExpiresAt = nil
+	return tx.Save(b).Error
+}
+
+// cancellableStatuses: booking yang sudah check-in/check-out/batal/expired tidak bisa dibatalkan
+var cancellableStatuses = map[hotel.BookingStatus]bool{
+	hotel.BookingStatusPending:   true,
+	hotel.BookingStatusConfirmed: true,
+	hotel.BookingStatusPaid:      true,
+}
+
+// paidAmount: total pembayaran yang benar-benar tercatat, ditambah pelunasan lama
+// yang ditandai migrasi. Konfirmasi admin saja tidak berarti booking sudah dibayar.
+func paidAmount(repo repohotel.PaymentRepository, b *hotel.Booking) (int64, error) {
+	paid, err := repo.SumPaidByBooking(b.ID)
+	if err != nil {
+		return 0, err
 	}
+	return paid + b.LegacyPaidAmount, nil
+}
 
-	return tx.Commit().Error
+// createRefund: pengembalian dana ke tamu dicatat sebagai payment refund supaya muncul
+// di daftar pembayaran & invoice dan bisa diproses front desk
+func createRefund(payments repohotel.PaymentRepository, bookingID uint, amount int64, note string, now time.Time) error {
+	return payments.Create(&hotel.Payment{
+		BookingID:      bookingID,
+		Provider:       "front_desk",
+		OrderID:        fmt.Sprintf("RF-%d-%d", bookingID, now.UnixNano()),
+		Amount:         amount,
+		Status:         hotel.PaymentStatusRefund,
+		RefundRequired: true,
+		Note:           note,
+	})
 }
 
-// Cancel: Batalkan booking
-func (s *bookingService) Cancel(id uint) error {
+// CancellationQuote: simulasi denda & refund tanpa membatalkan booking
+func (s *bookingService) CancellationQuote(id uint) (*hotel.CancellationQuote, error) {
+	b, err := s.bookingRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !cancellableStatuses[b.Status] {
+		return nil, fmt.Errorf("booking berstatus %s tidak bisa dibatalkan", b.Status)
+	}
+	paid, err := paidAmount(s.paymentRepo, b)
+	if err != nil {
+		return nil, err
+	}
+	return s.policies.Evaluate(b, paid, time.Now())
+}
+
+// Cancel: Batalkan booking sesuai kebijakan pembatalan, simpan denda & refund
+func (s *bookingService) Cancel(id uint, reason string) (*hotel.CancellationQuote, error) {
 	tx := s.db.Begin()
 	if tx.Error != nil {
-		return errors.New("gagal memulai transaksi")
+		return nil, errors.New("gagal memulai transaksi")
 	}
 
-	b, err := s.bookingRepo.FindByID(id)
+	b, err := s.bookingRepo.WithTx(tx).LockByID(id)
 	if err != nil {
 		tx.Rollback()
-		return err
+		return nil, err
+	}
+	quote, err := s.cancelInTx(tx, b, reason, time.Now())
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	return quote, nil
+}
+
+// cancelInTx: batalkan satu booking (sudah dikunci) di dalam transaksi tx
+func (s *bookingService) cancelInTx(tx *gorm.DB, b *hotel.Booking, reason string, now time.Time) (*hotel.CancellationQuote, error) {
+	if !cancellableStatuses[b.Status] {
+		return nil, fmt.Errorf("booking berstatus %s tidak bisa dibatalkan", b.Status)
+	}
+
+	paymentRepo := s.paymentRepo.WithTx(tx)
+	paid, err := paidAmount(paymentRepo, b)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := s.policies.Evaluate(b, paid, now)
+	if err != nil {
+		return nil, err
 	}
 
 	b.Status = hotel.BookingStatusCancelled
+	b.HoldExpiresAt = nil
+	b.CancelledAt = &now
+	b.CancellationFee = quote.Fee
+	b.RefundAmount = quote.RefundAmount
+	b.CancelReason = reason
+	// Ketersediaan dihitung per tanggal dari booking aktif; status kamar hanya
+	// mencerminkan kondisi fisik (dihuni/dibersihkan) dan tidak diubah di sini
 	if err := tx.Save(b).Error; err != nil {
+		return nil, err
+	}
+	if quote.RefundAmount > 0 {
+		if err := createRefund(paymentRepo, b.ID, quote.RefundAmount, "refund pembatalan booking", now); err != nil {
+			return nil, err
+		}
+	}
+	return quote, nil
+}
+
+func (s *bookingService) ClaimWaitlist(token string, userID uint) (*hotel.GuestBookingResponse, error) {
+	entry, err := s.waitlist.Reserve(token, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.GuestBook(userID, hotel.GuestBookingRequest{
+		RoomType:   entry.RoomType.Type,
+		TotalRooms: 1,
+		Name:       entry.Name,
+		Phone:      entry.Phone,
+		Email:      entry.Email,
+		CheckIn:    entry.CheckIn.Format(dateLayout),
+		CheckOut:   entry.CheckOut.Format(dateLayout),
+		Guests:     entry.Guests,
+		Adults:     entry.Adults,
+		Children:   entry.Children,
+		Notes:      fmt.Sprintf("Dari waitlist #%d", entry.ID),
+	})
+	if err != nil {
+		// Penawaran waitlist tidak menahan kamar, jadi kamar bisa keburu dipesan
+		// tamu lain: link tetap berlaku sampai kedaluwarsa
+		if ferr := s.waitlist.Finish(entry, nil); ferr != nil {
+			log.Printf("Waitlist: gagal mengembalikan entry %d: %v", entry.ID, ferr)
+		}
+		return nil, err
+	}
+	if err := s.waitlist.Finish(entry, &resp.BookingIDs[0]); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// modifiableStatuses: booking yang masih boleh diubah tanggal/kamar/jumlah tamunya
+var modifiableStatuses = map[hotel.BookingStatus]bool{
+	hotel.BookingStatusPending:   true,
+	hotel.BookingStatusConfirmed: true,
+	hotel.BookingStatusPaid:      true,
+}
+
+// Modify: ubah tanggal, kamar atau jumlah tamu lalu hitung ulang malam & harga.
+// source = hotel.BookingChangeByGuest membatasi ke booking milik userID sendiri.
+func (s *bookingService) Modify(id, userID uint, source string, req hotel.ModifyBookingRequest) (*hotel.Booking, error) {
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, errors.New("gagal memulai transaksi")
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepo := s.bookingRepo.WithTx(tx)
+	b, err := txRepo.LockByID(id)
+	if err != nil {
 		tx.Rollback()
-		return err
+		return nil, err
+	}
+	if source == hotel.BookingChangeByGuest && (b.UserID == nil || *b.UserID != userID) {
+		tx.Rollback()
+		return nil, gorm.ErrRecordNotFound
+	}
+	if !modifiableStatuses[b.Status] {
+		tx.Rollback()
+		return nil, fmt.Errorf("booking berstatus %s tidak bisa diubah", b.Status)
+	}
+
+	checkIn, checkOut := b.CheckIn, b.CheckOut
+	if req.CheckIn != nil {
+		if checkIn, err = time.Parse(dateLayout, *req.CheckIn); err != nil {
+			tx.Rollback()
+			return nil, errors.New("format check_in tidak valid, gunakan YYYY-MM-DD")
+		}
+	}
+	if req.CheckOut != nil {
+		if checkOut, err = time.Parse(dateLayout, *req.CheckOut); err != nil {
+			tx.Rollback()
+			return nil, errors.New("format check_out tidak valid, gunakan YYYY-MM-DD")
+		}
+	}
+	if !checkOut.After(checkIn) {
+		tx.Rollback()
+		return nil, errors.New("check_out harus setelah check_in")
+	}
+	if source == hotel.BookingChangeByGuest && !checkIn.Equal(b.CheckIn) &&
+		checkIn.Format(dateLayout) < time.Now().Format(dateLayout) {
+		tx.Rollback()
+		return nil, errors.New("check_in tidak boleh di masa lalu")
 	}
 
-	room, err := s.roomRepo.FindByID(b.RoomID)
-	if err == nil && room.Status == hotel.RoomStatusBooked {
-		room.Status = hotel.RoomStatusAvailable
-		tx.Save(room)
+	roomID := b.RoomID
+	if req.RoomID != nil {
+		roomID = *req.RoomID
+	}
+	adults, children, err := modifiedOccupancy(b, req)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
 	}
+	guests := adults + children
 
-	return tx.Commit().Error
+	datesChanged := !checkIn.Equal(b.CheckIn) || !checkOut.Equal(b.CheckOut)
+	roomChanged := roomID != b.RoomID
+	occupancyChanged := guests != b.Guests || adults != b.Adults || children != b.Children
+	if !datesChanged && !roomChanged && !occupancyChanged {
+		tx.Rollback()
+		return nil, errors.New("tidak ada perubahan")
+	}
+
+	var changes []hotel.BookingFieldChange
+	nights := b.TotalNights
+	subtotal := b.Subtotal
+	room, err := txRepo.LockRoom(roomID)
+	if err != nil {
+		tx.Rollback()
+		return nil, errors.New("kamar tidak ditemukan")
+	}
+	if err := checkOccupancy(room, guests); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if datesChanged || roomChanged {
+		// Booking ini sendiri dikecualikan supaya perpanjangan/pergeseran tanggal tidak bentrok dengan dirinya
+		count, err := txRepo.CountOverlapping(roomID, checkIn, checkOut, &b.ID)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if count > 0 {
+			tx.Rollback()
+			return nil, errors.New("kamar sudah dipesan pada tanggal tersebut")
+		}
+		if blocked, err := txRepo.IsBlocked(roomID, checkIn, checkOut); err != nil || blocked {
+			tx.Rollback()
+			if err != nil {
+				return nil, err
+			}
+			return nil, errMaintenanceBlocked
+		}
+
+		rates, quoted, err := s.rateService.Quote(room.RoomTypeID, checkIn, checkOut)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		subtotal = quoted
+		nights = int(checkOut.Sub(checkIn).Hours() / 24)
+
+		changes = appendChange(changes, "check_in", b.CheckIn.Format(dateLayout), checkIn.Format(dateLayout))
+		changes = appendChange(changes, "check_out", b.CheckOut.Format(dateLayout), checkOut.Format(dateLayout))
+		changes = appendChange(changes, "room_id", b.RoomID, roomID)
+
+		if err := txRepo.ReplaceNights(b.ID, toBookingNights(rates)); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		b.CheckIn, b.CheckOut, b.RoomID = checkIn, checkOut, roomID
+		b.CancellationPolicyID = s.policies.Resolve(room.RoomTypeID, rates)
+	}
+	changes = appendChange(changes, "guests", b.Guests, guests)
+	changes = appendChange(changes, "adults", b.Adults, adults)
+	changes = appendChange(changes, "children", b.Children, children)
+	b.Guests, b.Adults, b.Children = guests, adults, children
+
+	b.ExtraGuestCharge = extraGuestCharge(room.RoomType, adults, children)
+
+	changes, err = repriceStay(tx, s.promos, s.addons, s.taxes, b, subtotal, nights, changes)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Omit("Room", "Nights", "AddOns", "Taxes").Save(b).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	change := &hotel.BookingChange{
+		BookingID: b.ID,
+		ChangedBy: &userID,
+		Source:    source,
+		Changes:   changes,
+		Reason:    req.Reason,
+	}
+	if err := txRepo.CreateChange(change); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	return s.bookingRepo.FindByID(b.ID)
+}
+
+// appendChange: catat field hanya bila nilainya berubah
+func appendChange(changes []hotel.BookingFieldChange, field string, from, to interface{}) []hotel.BookingFieldChange {
+	if from == to {
+		return changes
+	}
+	return append(changes, hotel.BookingFieldChange{Field: field, Old: from, New: to})
+}
+
+func (s *bookingService) ListChanges(id uint) ([]hotel.BookingChange, error) {
+	if _, err := s.bookingRepo.FindByID(id); err != nil {
+		return nil, err
+	}
+	return s.bookingRepo.ListChanges(id)
 }
 
 // List
@@ -274,13 +834,162 @@ func (s *bookingService) List(status string, limit, offset int) ([]hotel.Booking
 	return s.bookingRepo.List(f)
 }
 
-// CheckAvailability
+func (s *bookingService) ListMine(userID uint, status string, limit, offset int) ([]hotel.Booking, int64, error) {
+	return s.bookingRepo.List(repohotel.BookingFilter{
+		UserID: &userID,
+		Status: status,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+func (s *bookingService) GetMine(id, userID uint) (*hotel.Booking, error) {
+	return s.bookingRepo.FindForUser(id, userID)
+}
+
+func (s *bookingService) CancellationQuoteMine(id, userID uint) (*hotel.CancellationQuote, error) {
+	if _, err := s.bookingRepo.FindForUser(id, userID); err != nil {
+		return nil, err
+	}
+	return s.CancellationQuote(id)
+}
+
+func (s *bookingService) CancelMine(id, userID uint, reason string) (*hotel.CancellationQuote, error) {
+	if _, err := s.bookingRepo.FindForUser(id, userID); err != nil {
+		return nil, err
+	}
+	return s.Cancel(id, reason)
+}
+
+// SummaryMine: ringkasan booking dalam bentuk teks untuk diunduh guest
+func (s *bookingService) SummaryMine(id, userID uint) (string, error) {
+	b, err := s.bookingRepo.FindForUser(id, userID)
+	if err != nil {
+		return "", err
+	}
+	return bookingSummaryText(b), nil
+}
+
+// bookingSummaryText: format sama dengan pesan WhatsApp, ditambah status & pembatalan
+func bookingSummaryText(b *hotel.Booking) string {
+	rates := make([]hotel.NightlyRate, 0, len(b.Nights))
+	for _, n := range b.Nights {
+		rates = append(rates, hotel.NightlyRate{Date: n.Date.Format(dateLayout), Price: n.Price})
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "RINGKASAN BOOKING - MUTIARA HOTEL\n\n")
+	fmt.Fprintf(&sb, "No. Booking: %d\n", b.ID)
+	fmt.Fprintf(&sb, "Status: %s\n", b.Status)
+	fmt.Fprintf(&sb, "Nama: %s\nNo. HP: %s\nEmail: %s\n", b.Name, b.Phone, b.Email)
+	fmt.Fprintf(&sb, "Kamar: %s (No. %s)\n", b.Room.RoomType.DisplayName(), b.Room.Number)
+	fmt.Fprintf(&sb, "Check-in: %s\nCheck-out: %s\n", b.CheckIn.Format("02 Jan 2006"), b.CheckOut.Format("02 Jan 2006"))
+	fmt.Fprintf(&sb, "Malam: %d\nTamu: %d\n", b.TotalNights, b.Guests)
+	fmt.Fprintf(&sb, "Rincian harga:\n%s\n", formatNightlyRates(rates))
+	sb.WriteString(formatPromoLines(b.Subtotal, b.PromoCode, b.Discount))
+	sb.WriteString(formatAddOnLines("Add-on", b.AddOns))
+	sb.WriteString(formatExtraGuestLine("Tambahan tamu", b.ExtraGuestCharge, b.TotalNights))
+	sb.WriteString(formatTaxLines(b.Taxes))
+	fmt.Fprintf(&sb, "Total: %s\n", formatRupiah(b.TotalPrice))
+	if b.PaidAt != nil {
+		fmt.Fprintf(&sb, "Dibayar: %s\n", b.PaidAt.Format("02 Jan 2006 15:04"))
+	}
+	if b.CancelledAt != nil {
+		fmt.Fprintf(&sb, "Dibatalkan: %s\n", b.CancelledAt.Format("02 Jan 2006 15:04"))
+		fmt.Fprintf(&sb, "Denda pembatalan: %s\nRefund: %s\n", formatRupiah(b.CancellationFee), formatRupiah(b.RefundAmount))
+	}
+	if b.Notes != "" {
+		fmt.Fprintf(&sb, "\nCatatan:\n%s\n", b.Notes)
+	}
+	return sb.String()
+}
+
+// ExpireHolds: ubah booking pending yang hold-nya habis menjadi expired,
+// sehingga kamarnya kembali tersedia. Dipanggil berkala oleh background job.
+func (s *bookingService) ExpireHolds(ctx context.Context) (int, error) {
+	tx := s.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return 0, errors.New("gagal memulai transaksi")
+	}
+
+	expired, err := s.bookingRepo.WithTx(tx).LockExpiredHolds(time.Now(), 500)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if len(expired) == 0 {
+		tx.Rollback()
+		return 0, nil
+	}
+
+	ids := make([]uint, len(expired))
+	for i, b := range expired {
+		ids[i] = b.ID
+	}
+	if err := tx.Model(&hotel.Booking{}).
+		Where("id IN ?", ids).
+		Update("status", hotel.BookingStatusExpired).Error; err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+// CheckAvailability: jumlah kamar kosong per tipe + rincian harga per malam
 func (s *bookingService) CheckAvailability(checkIn, checkOut time.Time, roomType string) ([]hotel.AvailabilityResponse, error) {
-	return s.bookingRepo.CheckAvailability(checkIn, checkOut, roomType)
+	results, err := s.bookingRepo.CheckAvailability(checkIn, checkOut, roomType)
+	if err != nil {
+		return nil, err
+	}
+	for i := range results {
+		rates, total, err := s.rateService.Quote(results[i].RoomTypeID, checkIn, checkOut)
+		if err != nil {
+			return nil, err
+		}
+		results[i].Nights = rates
+		results[i].TotalPrice = total
+
+		// Tipe kamar yang terkena aturan lama menginap tetap tampil beserta alasannya
+		violation, err := s.restrictions.Check(results[i].RoomTypeID, checkIn, checkOut)
+		if err != nil {
+			return nil, err
+		}
+		if violation != nil {
+			results[i].Restriction = violation
+			results[i].AvailableRooms = 0
+		}
+	}
+	return results, nil
+}
+
+// toBookingNights: simpan rincian harga per malam ke booking
+func toBookingNights(rates []hotel.NightlyRate) []hotel.BookingNight {
+	nights := make([]hotel.BookingNight, 0, len(rates))
+	for _, r := range rates {
+		d, _ := time.Parse("2006-01-02", r.Date)
+		nights = append(nights, hotel.BookingNight{Date: d, Price: r.Price, Source: r.Source})
+	}
+	return nights
+}
+
+// ensureRoomFree: pastikan tidak ada booking aktif lain di kamar & tanggal yang sama
+func ensureRoomFree(repo repohotel.BookingRepository, b *hotel.Booking) error {
+	count, err := repo.CountOverlapping(b.RoomID, b.CheckIn, b.CheckOut, &b.ID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("kamar sudah dipesan booking lain pada tanggal tersebut")
+	}
+	return nil
 }
 
 // Helper: WhatsApp URL untuk single booking
-func (s *bookingService) generateWhatsAppURL(b *hotel.Booking, r *hotel.Room, totalPrice int64, nights int) string {
+func (s *bookingService) generateWhatsAppURL(b *hotel.Booking, r *hotel.Room, rates []hotel.NightlyRate, totalPrice int64, nights int, payLines string) string {
 	msg := fmt.Sprintf(`*PESANAN KAMAR - MUTIARA HOTEL*
 
 Nama: %s
@@ -292,28 +1001,33 @@ Check-in: %s
 Check-out: %s
 Malam: %d
 Tamu: %d
-Total: %s
+Rincian harga:
+%s
+%s%s%sTotal: %s
 
 Catatan:
 %s
 
-Silakan transfer ke:
-BCA 1234567890 a.n. Hotel Mutiara
-Konfirmasi setelah transfer.`,
+%s`,
 		b.Name, b.Phone, b.Email,
-		strings.Title(r.RoomType.Type), r.Number,
-		strings.Title(r.RoomType.Type),
+		r.RoomType.DisplayName(), r.Number,
+		r.RoomType.DisplayName(),
 		b.CheckIn.Format("02 Jan 2006"),
 		b.CheckOut.Format("02 Jan 2006"),
 		nights, b.Guests,
+		formatNightlyRates(rates),
+		formatPromoLines(b.Subtotal, b.PromoCode, b.Discount),
+		formatAddOnLines("Add-on", b.AddOns)+formatExtraGuestLine("Tambahan tamu", b.ExtraGuestCharge, nights),
+		formatTaxLines(b.Taxes),
 		formatRupiah(totalPrice),
 		b.Notes,
+		payLines,
 	)
 	return fmt.Sprintf("https://wa.me/%s?text=%s", s.waNumber, url.QueryEscape(msg))
 }
 
 // Helper: WhatsApp untuk guest booking
-func (s *bookingService) generateWhatsAppURLGuest(req hotel.GuestBookingRequest, avail hotel.AvailabilityResponse, nights int, totalPrice int64, totalRooms int, checkIn, checkOut time.Time) string {
+func (s *bookingService) generateWhatsAppURLGuest(req hotel.GuestBookingRequest, roomTypeName string, rates []hotel.NightlyRate, nights int, promoLines, addOnLines, taxLines, payLines string, totalPrice int64, totalRooms int, checkIn, checkOut time.Time) string {
 	msg := fmt.Sprintf(`*PESANAN KAMAR - MUTIARA HOTEL*
 
 Nama: %s
@@ -325,29 +1039,246 @@ Check-in: %s
 Check-out: %s
 Malam: %d
 Tamu: %d
-Harga/malam: %s
-Total: %s
+Harga/kamar per malam:
+%s
+%s%s%sTotal: %s
 
 Catatan:
 %s
 
-Silakan transfer ke:
-BCA 1234567890 a.n. Hotel Mutiara
-Konfirmasi setelah transfer.`,
+%s`,
 		req.Name, req.Phone, req.Email,
-		strings.Title(req.RoomType),
+		roomTypeName,
 		totalRooms,
 		checkIn.Format("02 Jan 2006"),
 		checkOut.Format("02 Jan 2006"),
 		nights,
 		req.Guests,
-		formatRupiah(avail.PricePerNight),
+		formatNightlyRates(rates),
+		promoLines,
+		addOnLines,
+		taxLines,
 		formatRupiah(totalPrice),
 		req.Notes,
+		payLines,
 	)
 	return fmt.Sprintf("https://wa.me/%s?text=%s", s.waNumber, url.QueryEscape(msg))
 }
 
+// formatNightlyRates: "- 12 Des 2025: Rp 500.000" per baris
+func formatNightlyRates(rates []hotel.NightlyRate) string {
+	var b strings.Builder
+	for i, r := range rates {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		label := r.Date
+		if d, err := time.Parse("2006-01-02", r.Date); err == nil {
+			label = d.Format("02 Jan 2006")
+		}
+		fmt.Fprintf(&b, "- %s: %s", label, formatRupiah(r.Price))
+	}
+	return b.String()
+}
+
+// formatPromoLines: baris subtotal & diskon di pesan WhatsApp, kosong bila tanpa promo
+func formatPromoLines(subtotal int64, code string, discount int64) string {
+	if discount <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("Subtotal: %s\nDiskon promo %s: -%s\n", formatRupiah(subtotal), code, formatRupiah(discount))
+}
+
+// formatAddOnLines: "- Sarapan x2 (per tamu/malam): Rp 400.000" per add-on, kosong bila tanpa add-on
+func formatAddOnLines(title string, items []hotel.BookingAddOn) string {
+	if len(items) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s:\n", title)
+	for _, item := range items {
+		fmt.Fprintf(&b, "- %s x%d (%s): %s\n", item.Name, item.Quantity, item.Pricing.Label(), formatRupiah(item.Total))
+	}
+	return b.String()
+}
+
+// formatTaxLines: "PB1 10%: Rp 50.000" per komponen pajak, kosong bila bebas pajak
+func formatTaxLines(lines []tax.TaxLine) string {
+	var b strings.Builder
+	for _, l := range lines {
+		fmt.Fprintf(&b, "%s: %s\n", taxLabel(l), formatRupiah(l.Amount))
+	}
+	return b.String()
+}
+
+// taxLabel: "PB1 10%", ditambah "(termasuk)" untuk pajak inclusive
+func taxLabel(l tax.TaxLine) string {
+	label := fmt.Sprintf("%s %s%%", l.Name, strconv.FormatFloat(l.Rate, 'f', -1, 64))
+	if l.Inclusive {
+		label += " (termasuk)"
+	}
+	return label
+}
+
+// mergeTaxLines: jumlahkan baris pajak beberapa booking per komponen
+func mergeTaxLines(dst, src []tax.TaxLine) []tax.TaxLine {
+	for _, l := range src {
+		merged := false
+		for i := range dst {
+			if dst[i].TaxRateID == l.TaxRateID {
+				dst[i].TaxableAmount += l.TaxableAmount
+				dst[i].Amount += l.Amount
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			dst = append(dst, l)
+		}
+	}
+	return dst
+}
+
+// bookingTaxable: dasar pajak booking = kamar setelah diskon + add-on + tambahan tamu + denda late check-out
+func bookingTaxable(b *hotel.Booking) int64 {
+	return b.Subtotal - b.Discount + b.AddOnTotal + b.ExtraGuestTotal + b.LateCheckoutFee
+}
+
+// resolveOccupancy: pecah jumlah tamu menjadi dewasa & anak. Request lama yang hanya
+// mengirim guests dianggap semua dewasa; bila keduanya dikirim jumlahnya harus cocok.
+func resolveOccupancy(guests, adults, children int) (int, int, error) {
+	if adults == 0 && children == 0 {
+		adults = guests
+	}
+	if adults <= 0 {
+		return 0, 0, errors.New("minimal 1 tamu dewasa")
+	}
+	if children < 0 {
+		return 0, 0, errors.New("jumlah anak tidak valid")
+	}
+	if guests > 0 && guests != adults+children {
+		return 0, 0, errors.New("guests harus sama dengan adults + children")
+	}
+	return adults, children, nil
+}
+
+// modifiedOccupancy: komposisi tamu setelah perubahan booking. Booking lama tanpa
+// rincian dianggap semua dewasa; bila hanya guests yang diubah, jumlah anak dipertahankan.
+func modifiedOccupancy(b *hotel.Booking, req hotel.ModifyBookingRequest) (int, int, error) {
+	adults, children := b.Adults, b.Children
+	if adults == 0 && children == 0 {
+		adults = b.Guests
+	}
+	if req.Adults == nil && req.Children == nil {
+		if req.Guests == nil {
+			return adults, children, nil
+		}
+		if children >= *req.Guests {
+			children = *req.Guests - 1
+		}
+		return resolveOccupancy(*req.Guests, *req.Guests-children, children)
+	}
+	if req.Adults != nil {
+		adults = *req.Adults
+	}
+	if req.Children != nil {
+		children = *req.Children
+	}
+	guests := 0
+	if req.Guests != nil {
+		guests = *req.Guests
+	}
+	return resolveOccupancy(guests, adults, children)
+}
+
+// checkOccupancy: jumlah tamu tidak boleh melebihi kapasitas kamar maupun batas
+// maksimal tipe kamarnya.
+func checkOccupancy(room *hotel.Room, guests int) error {
+	limit := room.Capacity
+	if max := room.RoomType.MaxOccupancy; max > 0 && max < limit {
+		limit = max
+	}
+	if guests > limit {
+		return fmt.Errorf("kamar %s maksimal %d tamu", room.Number, limit)
+	}
+	return nil
+}
+
+// extraGuestCharge: biaya tamu tambahan per malam. Dewasa mengisi kuota BaseOccupancy
+// lebih dulu, sisa kuota untuk anak; tamu di luar kuota dikenai tarif tambahan tipe kamar.
+func extraGuestCharge(rt hotel.RoomType, adults, children int) int64 {
+	base := rt.BaseOccupancy
+	extraAdults := adults - base
+	if extraAdults < 0 {
+		extraAdults = 0
+	}
+	remaining := base - adults
+	if remaining < 0 {
+		remaining = 0
+	}
+	extraChildren := children - remaining
+	if extraChildren < 0 {
+		extraChildren = 0
+	}
+	return int64(extraAdults)*rt.ExtraAdultPrice + int64(extraChildren)*rt.ExtraChildPrice
+}
+
+// formatExtraGuestLine: baris tambahan tamu untuk pesan WhatsApp, kosong bila tidak ada
+func formatExtraGuestLine(label string, perNight int64, nights int) string {
+	if perNight == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s: %s x %d malam = %s\n", label, formatRupiah(perNight), nights, formatRupiah(perNight*int64(nights)))
+}
+
+// applyBookingTax: hitung pajak hotel lalu set Tax & TotalPrice booking.
+// Booking baru menyimpan baris pajaknya saat Create, booking yang sudah ada
+// langsung diganti di dalam tx.
+func applyBookingTax(tx *gorm.DB, taxes taxservice.TaxService, b *hotel.Booking) error {
+	br, err := taxes.Calculate(tax.UnitHotel, bookingTaxable(b))
+	if err != nil {
+		return err
+	}
+	b.Tax = br.Tax
+	b.TotalPrice = br.Gross
+	b.Taxes = br.Lines
+	if b.ID == 0 {
+		return nil
+	}
+	return taxes.Record(tx, tax.SourceBooking, b.ID, br.Lines)
+}
+
+// repriceStay: hitung ulang diskon promo, biaya tamu tambahan, add-on dan pajak setelah
+// subtotal kamar atau jumlah malam berubah. Dipakai perubahan booking dan pulang lebih
+// awal supaya total selalu dihitung dengan cara yang sama seperti saat booking dibuat.
+func repriceStay(tx *gorm.DB, promos PromoService, addons AddOnService, taxes taxservice.TaxService, b *hotel.Booking, subtotal int64, nights int, changes []hotel.BookingFieldChange) ([]hotel.BookingFieldChange, error) {
+	discount := promos.Reprice(b.PromoCode, b.Subtotal, b.Discount, subtotal)
+	changes = appendChange(changes, "total_nights", b.TotalNights, nights)
+	changes = appendChange(changes, "subtotal", b.Subtotal, subtotal)
+	changes = appendChange(changes, "discount", b.Discount, discount)
+	b.TotalNights, b.Subtotal, b.Discount = nights, subtotal, discount
+
+	extraTotal := b.ExtraGuestCharge * int64(nights)
+	changes = appendChange(changes, "extra_guest_total", b.ExtraGuestTotal, extraTotal)
+	b.ExtraGuestTotal = extraTotal
+
+	// Add-on per malam/per tamu ikut dihitung ulang
+	addOnTotal, err := addons.Reprice(tx, b.ID, nights, b.Guests)
+	if err != nil {
+		return nil, err
+	}
+	changes = appendChange(changes, "add_on_total", b.AddOnTotal, addOnTotal)
+	b.AddOnTotal = addOnTotal
+
+	oldTax, oldTotal := b.Tax, b.TotalPrice
+	if err := applyBookingTax(tx, taxes, b); err != nil {
+		return nil, err
+	}
+	changes = appendChange(changes, "tax", oldTax, b.Tax)
+	changes = appendChange(changes, "total_price", oldTotal, b.TotalPrice)
+	return changes, nil
+}
+
 // formatRupiah: Rp 1.500.000
 func formatRupiah(n int64) string {
 	if n == 0 {
@@ -362,4 +1293,167 @@ func formatRupiah(n int64) string {
 		result.WriteByte(s[i])
 	}
 	return "Rp " + result.String()
-}
\ No newline at end of file
+}
+// reservationCodeAlphabet: tanpa karakter yang mudah tertukar (0/O, 1/I/L)
+const reservationCodeAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+
+func newReservationCode() (string, error) {
+	code := make([]byte, 5)
+	max := big.NewInt(int64(len(reservationCodeAlphabet)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = reservationCodeAlphabet[n.Int64()]
+	}
+	return "MTR-" + string(code), nil
+}
+
+func normalizeReservationCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// newReservation: buat header reservasi dengan kode unik di dalam transaksi tx
+func (s *bookingService) newReservation(tx *gorm.DB, userID uint, name, phone, email string, checkIn, checkOut time.Time) (*hotel.Reservation, error) {
+	txRepo := s.bookingRepo.WithTx(tx)
+	for attempt := 0; attempt < 5; attempt++ {
+		code, err := newReservationCode()
+		if err != nil {
+			return nil, err
+		}
+		exists, err := txRepo.ReservationCodeExists(code)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			continue
+		}
+		res := &hotel.Reservation{
+			Code:     code,
+			UserID:   &userID,
+			Name:     name,
+			Phone:    phone,
+			Email:    email,
+			CheckIn:  checkIn,
+			CheckOut: checkOut,
+		}
+		if err := txRepo.CreateReservation(res); err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+	return nil, errors.New("gagal membuat kode reservasi")
+}
+
+func (s *bookingService) GetReservation(code string) (*hotel.Reservation, error) {
+	return s.bookingRepo.FindReservation(normalizeReservationCode(code))
+}
+
+// LookupReservation: pencarian publik, kode & email harus cocok
+func (s *bookingService) LookupReservation(code, email string) (*hotel.Reservation, error) {
+	res, err := s.bookingRepo.FindReservation(normalizeReservationCode(code))
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(strings.TrimSpace(email), res.Email) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return res, nil
+}
+
+// ConfirmReservation: konfirmasi semua booking pending dalam reservasi sekaligus
+func (s *bookingService) ConfirmReservation(code string) (*hotel.Reservation, error) {
+	res, err := s.bookingRepo.FindReservation(normalizeReservationCode(code))
+	if err != nil {
+		return nil, err
+	}
+
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, errors.New("gagal memulai transaksi")
+	}
+	bookings, err := s.bookingRepo.WithTx(tx).LockReservationBookings(res.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	confirmed := 0
+	for i := range bookings {
+		if bookings[i].Status != hotel.BookingStatusPending {
+			continue
+		}
+		if err := s.confirmInTx(tx, &bookings[i]); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("booking #%d: %v", bookings[i].ID, err)
+		}
+		confirmed++
+	}
+	if confirmed == 0 {
+		tx.Rollback()
+		return nil, errors.New("tidak ada booking pending dalam reservasi ini")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	return s.bookingRepo.FindReservation(res.Code)
+}
+
+// CancelReservation: batalkan semua booking yang masih bisa dibatalkan dalam reservasi
+func (s *bookingService) CancelReservation(code, reason string) (*hotel.ReservationCancellation, error) {
+	res, err := s.bookingRepo.FindReservation(normalizeReservationCode(code))
+	if err != nil {
+		return nil, err
+	}
+	return s.cancelReservation(res, reason)
+}
+
+func (s *bookingService) CancelReservationMine(code string, userID uint, reason string) (*hotel.ReservationCancellation, error) {
+	res, err := s.bookingRepo.FindReservation(normalizeReservationCode(code))
+	if err != nil {
+		return nil, err
+	}
+	if res.UserID == nil || *res.UserID != userID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return s.cancelReservation(res, reason)
+}
+
+func (s *bookingService) cancelReservation(res *hotel.Reservation, reason string) (*hotel.ReservationCancellation, error) {
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, errors.New("gagal memulai transaksi")
+	}
+	bookings, err := s.bookingRepo.WithTx(tx).LockReservationBookings(res.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	result := &hotel.ReservationCancellation{Code: res.Code}
+	now := time.Now()
+	for i := range bookings {
+		if !cancellableStatuses[bookings[i].Status] {
+			continue
+		}
+		quote, err := s.cancelInTx(tx, &bookings[i], reason, now)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("booking #%d: %v", bookings[i].ID, err)
+		}
+		result.Quotes = append(result.Quotes, *quote)
+		result.Fee += quote.Fee
+		result.RefundAmount += quote.RefundAmount
+	}
+	if len(result.Quotes) == 0 {
+		tx.Rollback()
+		return nil, errors.New("tidak ada booking yang bisa dibatalkan dalam reservasi ini")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,107 @@
+// internal/service/hotelservice/booking_service_test.go
+package hotelservice
+
+import (
+	"testing"
+	"time"
+
+	"backend/internal/models/hotel"
+)
+
+func TestPaidAmountCountsRecordedPaymentsOnly(t *testing.T) {
+	payments := newFakePaymentRepo()
+	confirmed := &hotel.Booking{ID: 1, Status: hotel.BookingStatusConfirmed, TotalPrice: 800000}
+
+	if paid, _ := paidAmount(payments, confirmed); paid != 0 {
+		t.Fatalf("confirmed booking without payments: paid = %d, want 0", paid)
+	}
+
+	payments.Create(&hotel.Payment{BookingID: 1, OrderID: "BK-1-1", Amount: 300000, Status: hotel.PaymentStatusPaid})
+	payments.Create(&hotel.Payment{BookingID: 1, OrderID: "BK-1-2", Amount: 500000, Status: hotel.PaymentStatusPending})
+	if paid, _ := paidAmount(payments, confirmed); paid != 300000 {
+		t.Fatalf("paid = %d, want 300000", paid)
+	}
+
+	legacy := &hotel.Booking{ID: 2, Status: hotel.BookingStatusConfirmed, TotalPrice: 800000, LegacyPaidAmount: 800000}
+	if paid, _ := paidAmount(payments, legacy); paid != 800000 {
+		t.Fatalf("legacy booking: paid = %d, want 800000", paid)
+	}
+}
+
+func TestConfirmLocksBookingInsideTransaction(t *testing.T) {
+	db, stub := newStubDB(t)
+	checkIn := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	repo := &fakeBookingRepo{bookings: map[uint]*hotel.Booking{
+		1: {ID: 1, RoomID: 3, Status: hotel.BookingStatusPending, CheckIn: checkIn, CheckOut: checkIn.AddDate(0, 0, 2)},
+		2: {ID: 2, RoomID: 3, Status: hotel.BookingStatusCancelled},
+	}}
+	s := &bookingService{bookingRepo: repo, db: db}
+
+	if err := s.Confirm(1); err != nil {
+		t.Fatalf("Confirm: %v", err)
+	}
+	if len(repo.locked) != 1 || repo.locked[0] != 1 {
+		t.Fatalf("locked = %v, want booking 1 locked in the transaction", repo.locked)
+	}
+	if len(stub.Execs("UPDATE `bookings`")) != 1 {
+		t.Fatal("confirmed booking was not saved")
+	}
+
+	if err := s.Confirm(2); err == nil {
+		t.Fatal("cancelled booking must not be confirmed")
+	}
+	repo.overlapping = 1
+	if err := s.Confirm(1); err == nil {
+		t.Fatal("booking whose room was taken must not be confirmed")
+	}
+}
+
+type fakePolicyService struct {
+	CancellationPolicyService
+	fee int64
+}
+
+func (f fakePolicyService) Evaluate(b *hotel.Booking, paid int64, now time.Time) (*hotel.CancellationQuote, error) {
+	refund := paid - f.fee
+	if refund < 0 {
+		refund = 0
+	}
+	return &hotel.CancellationQuote{BookingID: b.ID, Fee: f.fee, PaidAmount: paid, RefundAmount: refund}, nil
+}
+
+func TestCancelRecordsRefundPayment(t *testing.T) {
+	db, _ := newStubDB(t)
+	payments := newFakePaymentRepo()
+	repo := &fakeBookingRepo{bookings: map[uint]*hotel.Booking{
+		1: {ID: 1, Status: hotel.BookingStatusPaid, TotalPrice: 800000},
+		2: {ID: 2, Status: hotel.BookingStatusPending, TotalPrice: 800000},
+	}}
+	s := &bookingService{bookingRepo: repo, paymentRepo: payments, policies: fakePolicyService{fee: 200000}, db: db}
+	payments.Create(&hotel.Payment{BookingID: 1, OrderID: "BK-1-1", Amount: 800000, Status: hotel.PaymentStatusPaid})
+
+	quote, err := s.Cancel(1, "batal")
+	if err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if quote.RefundAmount != 600000 {
+		t.Fatalf("refund = %d, want 600000", quote.RefundAmount)
+	}
+	list, _ := payments.ListByBooking(1)
+	var refunds []hotel.Payment
+	for _, p := range list {
+		if p.Status == hotel.PaymentStatusRefund {
+			refunds = append(refunds, p)
+		}
+	}
+	if len(refunds) != 1 || refunds[0].Amount != 600000 || !refunds[0].RefundRequired {
+		t.Fatalf("refund payments = %+v, want one of 600000 with refund_required", refunds)
+	}
+
+	// Belum dibayar: tidak ada yang dikembalikan
+	if _, err := s.Cancel(2, "batal"); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if list, _ := payments.ListByBooking(2); len(list) != 0 {
+		t.Fatalf("unpaid booking payments = %+v, want none", list)
+	}
+}
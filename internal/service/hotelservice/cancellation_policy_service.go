@@ -0,0 +1,148 @@
+// internal/service/hotelservice/cancellation_policy_service.go
+package hotelservice
+
+import (
+	"errors"
+	"time"
+
+	"backend/internal/models/hotel"
+	"backend/internal/repository/repohotel"
+)
+
+type CancellationPolicyService interface {
+	Create(req hotel.CreateCancellationPolicyRequest) (*hotel.CancellationPolicy, error)
+	List() ([]hotel.CancellationPolicy, error)
+	Update(id uint, req hotel.UpdateCancellationPolicyRequest) (*hotel.CancellationPolicy, error)
+	Delete(id uint) error
+
+	// Resolve: kebijakan untuk booking baru, rate plan malam pertama > tipe kamar
+	Resolve(roomTypeID uint, rates []hotel.NightlyRate) *uint
+	// Evaluate: hitung denda & refund bila booking dibatalkan pada waktu now
+	Evaluate(b *hotel.Booking, paid int64, now time.Time) (*hotel.CancellationQuote, error)
+}
+
+type cancellationPolicyService struct {
+	repo         repohotel.CancellationPolicyRepository
+	ratePlanRepo repohotel.RatePlanRepository
+	roomTypeRepo repohotel.RoomTypeRepository
+}
+
+func NewCancellationPolicyService(repo repohotel.CancellationPolicyRepository, ratePlanRepo repohotel.RatePlanRepository, roomTypeRepo repohotel.RoomTypeRepository) CancellationPolicyService {
+	return &cancellationPolicyService{
+		repo:         repo,
+		ratePlanRepo: ratePlanRepo,
+		roomTypeRepo: roomTypeRepo,
+	}
+}
+
+// nilIfZero: id 0 dari request berarti relasi dilepas
+func nilIfZero(id uint) *uint {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
+
+func (s *cancellationPolicyService) Create(req hotel.CreateCancellationPolicyRequest) (*hotel.CancellationPolicy, error) {
+	p := &hotel.CancellationPolicy{
+		Name:           req.Name,
+		Description:    req.Description,
+		FreeCancelDays: req.FreeCancelDays,
+		PenaltyPercent: req.PenaltyPercent,
+		NonRefundable:  req.NonRefundable,
+	}
+	if err := s.repo.Create(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *cancellationPolicyService) List() ([]hotel.CancellationPolicy, error) {
+	return s.repo.List()
+}
+
+func (s *cancellationPolicyService) Update(id uint, req hotel.UpdateCancellationPolicyRequest) (*hotel.CancellationPolicy, error) {
+	p, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		p.Name = *req.Name
+	}
+	if req.Description != nil {
+		p.Description = *req.Description
+	}
+	if req.FreeCancelDays != nil {
+		p.FreeCancelDays = *req.FreeCancelDays
+	}
+	if req.PenaltyPercent != nil {
+		p.PenaltyPercent = *req.PenaltyPercent
+	}
+	if req.NonRefundable != nil {
+		p.NonRefundable = *req.NonRefundable
+	}
+
+	if err := s.repo.Update(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *cancellationPolicyService) Delete(id uint) error {
+	inUse, err := s.repo.IsInUse(id)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return errors.New("kebijakan masih dipakai tipe kamar atau rate plan")
+	}
+	return s.repo.Delete(id)
+}
+
+func (s *cancellationPolicyService) Resolve(roomTypeID uint, rates []hotel.NightlyRate) *uint {
+	if len(rates) > 0 && rates[0].RatePlanID != 0 {
+		if plan, err := s.ratePlanRepo.FindByID(rates[0].RatePlanID); err == nil && plan.CancellationPolicyID != nil {
+			return plan.CancellationPolicyID
+		}
+	}
+	if rt, err := s.roomTypeRepo.FindByID(roomTypeID); err == nil {
+		return rt.CancellationPolicyID
+	}
+	return nil
+}
+
+// Evaluate: tanpa kebijakan pembatalan selalu gratis (perilaku lama).
+// Booking pending belum dijamin pembayaran, jadi tidak dikenakan denda.
+func (s *cancellationPolicyService) Evaluate(b *hotel.Booking, paid int64, now time.Time) (*hotel.CancellationQuote, error) {
+	today, _ := time.Parse(dateLayout, now.Format(dateLayout))
+	arrival, _ := time.Parse(dateLayout, b.CheckIn.Format(dateLayout))
+
+	q := &hotel.CancellationQuote{
+		BookingID:         b.ID,
+		PolicyID:          b.CancellationPolicyID,
+		DaysBeforeArrival: int(arrival.Sub(today).Hours() / 24),
+		PaidAmount:        paid,
+	}
+
+	if b.CancellationPolicyID != nil && b.Status != hotel.BookingStatusPending {
+		p, err := s.repo.FindByID(*b.CancellationPolicyID)
+		if err != nil {
+			return nil, err
+		}
+		q.PolicyName = p.Name
+		switch {
+		case p.NonRefundable:
+			q.Fee = b.TotalPrice
+		case q.DaysBeforeArrival >= p.FreeCancelDays:
+			q.Fee = 0
+		default:
+			q.Fee = b.TotalPrice * int64(p.PenaltyPercent) / 100
+		}
+	}
+
+	if refund := paid - q.Fee; refund > 0 {
+		q.RefundAmount = refund
+	}
+	return q, nil
+}
@@ -0,0 +1,288 @@
+// internal/service/hotelservice/folio_service.go
+package hotelservice
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"backend/internal/models/hotel"
+	"backend/internal/models/tax"
+	"backend/internal/repository/repohotel"
+	"backend/internal/service/taxservice"
+	"gorm.io/gorm"
+)
+
+type FolioService interface {
+	Get(bookingID uint) (*hotel.Folio, error)
+	GetMine(bookingID, userID uint) (*hotel.Folio, error)
+	// Posting manual oleh front desk
+	Charge(bookingID, userID uint, req hotel.FolioChargeRequest) (*hotel.Folio, error)
+	Pay(bookingID, userID uint, req hotel.FolioPaymentRequest) (*hotel.Folio, error)
+	Adjust(bookingID, userID uint, req hotel.FolioAdjustmentRequest) (*hotel.Folio, error)
+
+	// PostRoomCharges: posting tagihan outlet (cafe/souvenir/buku) ke kamar tamu yang sedang menginap,
+	// dipanggil di dalam transaksi outlet supaya stok & tagihan tercatat bersamaan
+	PostRoomCharges(tx *gorm.DB, target hotel.RoomChargeTarget, source string, postedBy uint, lines []hotel.FolioLine) (*hotel.Folio, error)
+	// Close: tutup folio saat check-out, error bila saldonya belum lunas
+	Close(tx *gorm.DB, bookingID uint) error
+}
+
+type folioService struct {
+	repo        repohotel.FolioRepository
+	bookingRepo repohotel.BookingRepository
+	taxes       taxservice.TaxService
+	db          *gorm.DB
+}
+
+func NewFolioService(repo repohotel.FolioRepository, bookingRepo repohotel.BookingRepository, taxes taxservice.TaxService, db *gorm.DB) FolioService {
+	return &folioService{
+		repo:        repo,
+		bookingRepo: bookingRepo,
+		taxes:       taxes,
+		db:          db,
+	}
+}
+
+// folioTaxUnit: tagihan dikenai pajak business unit asal postingnya
+func folioTaxUnit(source string) string {
+	switch source {
+	case hotel.FolioSourceCafe:
+		return tax.UnitCafe
+	case hotel.FolioSourceSouvenir:
+		return tax.UnitSouvenir
+	case hotel.FolioSourceBook:
+		return tax.UnitBook
+	}
+	return tax.UnitHotel
+}
+
+// applyTax: hitung pajak tagihan, Amount menjadi harga + pajak exclusive.
+// Baris pajak ikut tersimpan bersama entry.
+func (s *folioService) applyTax(e *hotel.FolioEntry) error {
+	br, err := s.taxes.Calculate(folioTaxUnit(e.Source), e.UnitPrice*int64(e.Quantity))
+	if err != nil {
+		return err
+	}
+	e.Amount = br.Gross
+	e.Tax = br.Tax
+	e.Taxes = br.Lines
+	return nil
+}
+
+func (s *folioService) Get(bookingID uint) (*hotel.Folio, error) {
+	if _, err := s.bookingRepo.FindByID(bookingID); err != nil {
+		return nil, err
+	}
+	return s.find(bookingID)
+}
+
+func (s *folioService) GetMine(bookingID, userID uint) (*hotel.Folio, error) {
+	if _, err := s.bookingRepo.FindForUser(bookingID, userID); err != nil {
+		return nil, err
+	}
+	return s.find(bookingID)
+}
+
+// find: booking tanpa posting apa pun ditampilkan sebagai folio kosong
+func (s *folioService) find(bookingID uint) (*hotel.Folio, error) {
+	f, err := s.repo.FindByBooking(bookingID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &hotel.Folio{BookingID: bookingID, Status: hotel.FolioStatusOpen, Entries: []hotel.FolioEntry{}}, nil
+	}
+	return f, err
+}
+
+// openFolio: folio booking yang masih terbuka, dibuat bila belum ada.
+// Booking harus sudah dikunci oleh pemanggil supaya folio tidak dibuat dua kali.
+func (s *folioService) openFolio(tx *gorm.DB, b *hotel.Booking, create bool) (*hotel.Folio, error) {
+	repo := s.repo.WithTx(tx)
+	f, err := repo.LockByBooking(b.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if !create {
+			return nil, errors.New("booking belum memiliki tagihan folio")
+		}
+		f = &hotel.Folio{BookingID: b.ID, Status: hotel.FolioStatusOpen}
+		if err := repo.Create(f); err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if f.Status != hotel.FolioStatusOpen {
+		return nil, errors.New("folio sudah ditutup")
+	}
+	return f, nil
+}
+
+// post: kunci booking lalu simpan entries ke folionya.
+// Tagihan baru hanya untuk tamu in-house; pembayaran & koreksi tetap bisa
+// untuk folio yang masih terbuka, misalnya setelah check-out otomatis.
+func (s *folioService) post(bookingID uint, entries []hotel.FolioEntry) (*hotel.Folio, error) {
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, errors.New("gagal memulai transaksi")
+	}
+
+	b, err := s.bookingRepo.WithTx(tx).LockByID(bookingID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	isCharge := entries[0].Type == hotel.FolioEntryCharge
+	if isCharge && b.Status != hotel.BookingStatusCheckedIn {
+		tx.Rollback()
+		return nil, errors.New("tagihan hanya bisa diposting ke tamu yang sedang menginap")
+	}
+	f, err := s.openFolio(tx, b, b.Status == hotel.BookingStatusCheckedIn)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	for i := range entries {
+		entries[i].FolioID = f.ID
+	}
+	if err := s.repo.WithTx(tx).CreateEntries(entries); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	return s.find(bookingID)
+}
+
+func (s *folioService) Charge(bookingID, userID uint, req hotel.FolioChargeRequest) (*hotel.Folio, error) {
+	qty := req.Quantity
+	if qty <= 0 {
+		qty = 1
+	}
+	entry := hotel.FolioEntry{
+		Type:        hotel.FolioEntryCharge,
+		Source:      hotel.FolioSourceFrontDesk,
+		Description: strings.TrimSpace(req.Description),
+		Quantity:    qty,
+		UnitPrice:   req.UnitPrice,
+		Reference:   req.Reference,
+		PostedBy:    &userID,
+	}
+	if err := s.applyTax(&entry); err != nil {
+		return nil, err
+	}
+	return s.post(bookingID, []hotel.FolioEntry{entry})
+}
+
+func (s *folioService) Pay(bookingID, userID uint, req hotel.FolioPaymentRequest) (*hotel.Folio, error) {
+	return s.post(bookingID, []hotel.FolioEntry{{
+		Type:        hotel.FolioEntryPayment,
+		Source:      hotel.FolioSourceFrontDesk,
+		Description: "Pembayaran " + req.Method,
+		Quantity:    1,
+		Amount:      -req.Amount,
+		Method:      req.Method,
+		Reference:   req.Reference,
+		PostedBy:    &userID,
+	}})
+}
+
+func (s *folioService) Adjust(bookingID, userID uint, req hotel.FolioAdjustmentRequest) (*hotel.Folio, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, errors.New("alasan koreksi wajib diisi")
+	}
+	return s.post(bookingID, []hotel.FolioEntry{{
+		Type:        hotel.FolioEntryAdjustment,
+		Source:      hotel.FolioSourceFrontDesk,
+		Description: reason,
+		Quantity:    1,
+		Amount:      req.Amount,
+		PostedBy:    &userID,
+	}})
+}
+
+func (s *folioService) PostRoomCharges(tx *gorm.DB, target hotel.RoomChargeTarget, source string, postedBy uint, lines []hotel.FolioLine) (*hotel.Folio, error) {
+	if len(lines) == 0 {
+		return nil, errors.New("tidak ada tagihan yang diposting")
+	}
+
+	bookingID := target.BookingID
+	if bookingID == 0 {
+		number := strings.TrimSpace(target.RoomNumber)
+		if number == "" {
+			return nil, errors.New("booking_id atau room_number wajib diisi")
+		}
+		b, err := s.repo.WithTx(tx).FindInHouseBooking(number)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("tidak ada tamu yang sedang menginap di kamar %s", number)
+		}
+		if err != nil {
+			return nil, err
+		}
+		bookingID = b.ID
+	}
+
+	b, err := s.bookingRepo.WithTx(tx).LockByID(bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if b.Status != hotel.BookingStatusCheckedIn {
+		return nil, errors.New("tagihan hanya bisa diposting ke tamu yang sedang menginap")
+	}
+	f, err := s.openFolio(tx, b, true)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]hotel.FolioEntry, 0, len(lines))
+	for _, l := range lines {
+		entry := hotel.FolioEntry{
+			FolioID:     f.ID,
+			Type:        hotel.FolioEntryCharge,
+			Source:      source,
+			Description: l.Description,
+			Quantity:    l.Quantity,
+			UnitPrice:   l.UnitPrice,
+			Reference:   l.Reference,
+			PostedBy:    &postedBy,
+		}
+		if err := s.applyTax(&entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	if err := s.repo.WithTx(tx).CreateEntries(entries); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *folioService) Close(tx *gorm.DB, bookingID uint) error {
+	repo := s.repo.WithTx(tx)
+	f, err := repo.LockByBooking(bookingID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if f.Status == hotel.FolioStatusClosed {
+		return nil
+	}
+
+	balance, err := repo.Balance(f.ID)
+	if err != nil {
+		return err
+	}
+	if balance > 0 {
+		return fmt.Errorf("folio masih memiliki tagihan %s yang belum dibayar, lunasi dulu sebelum check-out", formatRupiah(balance))
+	}
+
+	now := time.Now()
+	f.Status = hotel.FolioStatusClosed
+	f.ClosedAt = &now
+	return repo.Update(f)
+}
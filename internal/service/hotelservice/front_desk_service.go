@@ -0,0 +1,384 @@
+// internal/service/hotelservice/front_desk_service.go
+package hotelservice
+
+import (
+	"context"
+	"errors"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	"backend/internal/models/hotel"
+	"backend/internal/repository/repohotel"
+	"backend/internal/service/taxservice"
+	"gorm.io/gorm"
+)
+
+type FrontDeskService interface {
+	CheckIn(id, userID uint) (*hotel.Booking, error)
+	CheckOut(id, userID uint, req hotel.CheckOutRequest) (*hotel.Booking, error)
+	// RunFallbacks: dipanggil background job untuk booking yang tidak ditindak front desk
+	RunFallbacks(ctx context.Context) (*hotel.FrontDeskRunResult, error)
+}
+
+type frontDeskService struct {
+	bookingRepo  repohotel.BookingRepository
+	paymentRepo  repohotel.PaymentRepository
+	housekeeping HousekeepingService
+	folios       FolioService
+	promos       PromoService
+	addons       AddOnService
+	taxes        taxservice.TaxService
+	checkoutHour int
+	checkoutMin  int
+	lateFeeHour  int64
+	db           *gorm.DB
+}
+
+func NewFrontDeskService(bookingRepo repohotel.BookingRepository, paymentRepo repohotel.PaymentRepository, housekeeping HousekeepingService, folios FolioService, promos PromoService, addons AddOnService, taxes taxservice.TaxService, db *gorm.DB) FrontDeskService {
+	// Jam check-out standar, contoh: 12:00
+	checkout, err := time.Parse("15:04", os.Getenv("CHECKOUT_TIME"))
+	if err != nil {
+		checkout = time.Date(0, 1, 1, 12, 0, 0, 0, time.UTC)
+	}
+	// Denda late check-out per jam (dibulatkan ke atas), maksimal harga satu malam
+	lateFee, err := strconv.ParseInt(os.Getenv("LATE_CHECKOUT_FEE_PER_HOUR"), 10, 64)
+	if err != nil || lateFee < 0 {
+		lateFee = 50000
+	}
+	return &frontDeskService{
+		bookingRepo:  bookingRepo,
+		paymentRepo:  paymentRepo,
+		housekeeping: housekeeping,
+		folios:       folios,
+		promos:       promos,
+		addons:       addons,
+		taxes:        taxes,
+		checkoutHour: checkout.Hour(),
+		checkoutMin:  checkout.Minute(),
+		lateFeeHour:  lateFee,
+		db:           db,
+	}
+}
+
+// calendarDay: tanggal kalender dari nilai tanggal yang tersimpan (check_in, check_out,
+// tanggal malam), dinormalkan ke tengah malam UTC. Kolom date dibaca driver sebagai tengah
+// malam zona lokal (loc=Local), sedangkan tanggal dari request berupa tengah malam UTC;
+// keduanya dibulatkan ke tengah malam UTC terdekat supaya tanggalnya tidak bergeser.
+func calendarDay(t time.Time) time.Time {
+	u := t.UTC().Add(12 * time.Hour)
+	return time.Date(u.Year(), u.Month(), u.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// localDay: tanggal hari ini (atau created_at/cancelled_at) menurut jam hotel, dalam
+// bentuk yang sama dengan calendarDay supaya bisa dibandingkan
+func localDay(t time.Time) time.Time {
+	y, m, d := t.In(time.Local).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *frontDeskService) CheckIn(id, userID uint) (*hotel.Booking, error) {
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, errors.New("gagal memulai transaksi")
+	}
+	txRepo := s.bookingRepo.WithTx(tx)
+
+	b, err := txRepo.LockByID(id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	switch b.Status {
+	case hotel.BookingStatusConfirmed, hotel.BookingStatusPaid:
+	case hotel.BookingStatusPending:
+		tx.Rollback()
+		return nil, errors.New("booking masih pending, konfirmasi dulu sebelum check-in")
+	case hotel.BookingStatusCheckedIn:
+		tx.Rollback()
+		return nil, errors.New("booking sudah check-in")
+	default:
+		tx.Rollback()
+		return nil, errors.New("booking dengan status " + b.Status.String() + " tidak bisa check-in")
+	}
+
+	now := time.Now()
+	today := localDay(now)
+	if today.Before(calendarDay(b.CheckIn)) {
+		tx.Rollback()
+		return nil, errors.New("belum tanggal check-in, ubah tanggal booking untuk kedatangan lebih awal")
+	}
+	if !today.Before(calendarDay(b.CheckOut)) {
+		tx.Rollback()
+		return nil, errors.New("tanggal check-out sudah lewat")
+	}
+
+	room, err := txRepo.LockRoom(b.RoomID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if room.Status == hotel.RoomStatusCleaning {
+		tx.Rollback()
+		return nil, errors.New("kamar masih dalam antrean housekeeping")
+	}
+
+	oldStatus := b.Status
+	b.Status = hotel.BookingStatusCheckedIn
+	b.ActualCheckIn = &now
+	if err := tx.Save(b).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Model(&hotel.Room{}).
+		Where("id = ?", b.RoomID).
+		Update("status", hotel.RoomStatusBooked).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := txRepo.CreateChange(&hotel.BookingChange{
+		BookingID: b.ID,
+		ChangedBy: &userID,
+		Source:    hotel.BookingChangeByAdmin,
+		Changes:   appendChange(nil, "status", oldStatus.String(), hotel.BookingStatusCheckedIn.String()),
+		Reason:    "check-in",
+	}); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	return s.bookingRepo.FindDetail(id)
+}
+
+func (s *frontDeskService) CheckOut(id, userID uint, req hotel.CheckOutRequest) (*hotel.Booking, error) {
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, errors.New("gagal memulai transaksi")
+	}
+	txRepo := s.bookingRepo.WithTx(tx)
+
+	if _, err := txRepo.LockByID(id); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	b, err := txRepo.FindDetail(id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if b.Status != hotel.BookingStatusCheckedIn {
+		tx.Rollback()
+		return nil, errors.New("hanya booking yang sudah check-in yang bisa check-out")
+	}
+	// Tagihan cafe/souvenir/lainnya harus lunas sebelum tamu pulang
+	if err := s.folios.Close(tx, b.ID); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	now := time.Now()
+	var changes []hotel.BookingFieldChange
+	today := localDay(now)
+	checkOutDay := calendarDay(b.CheckOut)
+
+	switch {
+	case today.Before(checkOutDay):
+		// Pulang lebih awal: malam sisanya dilepas ke inventori
+		newCheckOut := today
+		if minCheckOut := calendarDay(b.CheckIn).AddDate(0, 0, 1); newCheckOut.Before(minCheckOut) {
+			newCheckOut = minCheckOut
+		}
+		if newCheckOut.Before(checkOutDay) {
+			original := b.CheckOut
+			bookedNights := b.TotalNights
+			nights := int(newCheckOut.Sub(calendarDay(b.CheckIn)).Hours() / 24)
+			b.OriginalCheckOut = &original
+			b.EarlyDeparture = true
+			b.CheckOut = newCheckOut
+			changes = appendChange(changes, "check_out", original.Format(dateLayout), newCheckOut.Format(dateLayout))
+
+			if !req.RefundUnusedNights {
+				changes = appendChange(changes, "total_nights", b.TotalNights, nights)
+				b.TotalNights = nights
+			} else {
+				var kept []hotel.BookingNight
+				var subtotal int64
+				for _, n := range b.Nights {
+					if calendarDay(n.Date).Before(newCheckOut) {
+						kept = append(kept, hotel.BookingNight{Date: n.Date, Price: n.Price, Source: n.Source})
+						subtotal += n.Price
+					}
+				}
+				if len(b.Nights) == 0 && bookedNights > 0 {
+					// Booking lama tanpa rincian harga per malam
+					subtotal = b.Subtotal * int64(nights) / int64(bookedNights)
+				}
+				if err := txRepo.ReplaceNights(b.ID, kept); err != nil {
+					tx.Rollback()
+					return nil, err
+				}
+				// Promo, tamu tambahan, add-on & pajak dihitung ulang seperti saat booking
+				oldTotal := b.TotalPrice
+				changes, err = repriceStay(tx, s.promos, s.addons, s.taxes, b, subtotal, nights, changes)
+				if err != nil {
+					tx.Rollback()
+					return nil, err
+				}
+				if err := s.recordRefund(tx, b, oldTotal, now, &changes); err != nil {
+					tx.Rollback()
+					return nil, err
+				}
+			}
+		}
+	default:
+		fee := s.lateCheckoutFee(b, now)
+		if req.LateFee != nil {
+			fee = *req.LateFee
+		}
+		if fee > 0 {
+			oldTotal := b.TotalPrice
+			b.LateCheckoutFee = fee
+			if err := applyBookingTax(tx, s.taxes, b); err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+			changes = appendChange(changes, "late_checkout_fee", int64(0), fee)
+			changes = appendChange(changes, "total_price", oldTotal, b.TotalPrice)
+		}
+	}
+
+	b.Status = hotel.BookingStatusCheckedOut
+	b.ActualCheckOut = &now
+	changes = appendChange(changes, "status", hotel.BookingStatusCheckedIn.String(), hotel.BookingStatusCheckedOut.String())
+	if err := tx.Omit("Room", "Nights", "AddOns", "Taxes").Save(b).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Kamar masuk antrean housekeeping, baru available setelah diinspeksi
+	if err := s.housekeeping.OnCheckout(tx, b.RoomID, &b.ID); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := txRepo.CreateChange(&hotel.BookingChange{
+		BookingID: b.ID,
+		ChangedBy: &userID,
+		Source:    hotel.BookingChangeByAdmin,
+		Changes:   changes,
+		Reason:    "check-out",
+	}); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	return s.bookingRepo.FindDetail(id)
+}
+
+// recordRefund: kelebihan bayar setelah total turun dicatat lewat createRefund. Tidak
+// melebihi selisih total, dan dikurangi refund yang sudah dicatat sebelumnya.
+func (s *frontDeskService) recordRefund(tx *gorm.DB, b *hotel.Booking, oldTotal int64, now time.Time, changes *[]hotel.BookingFieldChange) error {
+	paymentRepo := s.paymentRepo.WithTx(tx)
+	paid, err := paidAmount(paymentRepo, b)
+	if err != nil {
+		return err
+	}
+	refund := paid - b.RefundAmount - b.TotalPrice
+	if maxRefund := oldTotal - b.TotalPrice; refund > maxRefund {
+		refund = maxRefund
+	}
+	if refund <= 0 {
+		return nil
+	}
+	if err := createRefund(paymentRepo, b.ID, refund, "refund malam tidak terpakai (pulang lebih awal)", now); err != nil {
+		return err
+	}
+	*changes = appendChange(*changes, "refund_amount", b.RefundAmount, b.RefundAmount+refund)
+	b.RefundAmount += refund
+	return nil
+}
+
+// lateCheckoutFee: denda per jam (dibulatkan ke atas) setelah jam check-out standar,
+// maksimal harga malam terakhir
+func (s *frontDeskService) lateCheckoutFee(b *hotel.Booking, now time.Time) int64 {
+	y, m, d := calendarDay(b.CheckOut).Date()
+	deadline := time.Date(y, m, d, s.checkoutHour, s.checkoutMin, 0, 0, now.Location())
+	if !now.After(deadline) || s.lateFeeHour == 0 {
+		return 0
+	}
+	hours := int64(math.Ceil(now.Sub(deadline).Hours()))
+	fee := hours * s.lateFeeHour
+
+	var nightCap int64
+	if len(b.Nights) > 0 {
+		nightCap = b.Nights[len(b.Nights)-1].Price
+	} else if b.TotalNights > 0 {
+		nightCap = b.Subtotal / int64(b.TotalNights)
+	}
+	if nightCap > 0 && fee > nightCap {
+		fee = nightCap
+	}
+	return fee
+}
+
+// RunFallbacks: check-out otomatis untuk tamu yang lewat tanggal check-out tanpa
+// check-out manual (tanpa denda), dan no-show untuk booking yang tidak pernah check-in.
+// Folio tamu yang check-out otomatis dibiarkan terbuka supaya sisa tagihannya tetap bisa ditagih.
+func (s *frontDeskService) RunFallbacks(ctx context.Context) (*hotel.FrontDeskRunResult, error) {
+	tx := s.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, errors.New("gagal memulai transaksi")
+	}
+	txRepo := s.bookingRepo.WithTx(tx)
+	now := time.Now()
+	today := localDay(now)
+	result := &hotel.FrontDeskRunResult{}
+
+	overdue, err := txRepo.LockOverdueStays(today, 500)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	for i := range overdue {
+		b := &overdue[i]
+		if err := tx.Model(b).Updates(map[string]interface{}{
+			"status":           hotel.BookingStatusCheckedOut,
+			"actual_check_out": now,
+		}).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if err := s.housekeeping.OnCheckout(tx, b.RoomID, &b.ID); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		result.AutoCheckedOut++
+	}
+
+	noShows, err := txRepo.LockNoShows(today, 500)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	for i := range noShows {
+		b := &noShows[i]
+		// Status kamar tidak diubah: tamu no-show tidak pernah menempati kamar,
+		// ketersediaan sudah dihitung dari tanggal & status booking
+		if err := tx.Model(b).Update("status", hotel.BookingStatusNoShow).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		result.NoShows++
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,72 @@
+// internal/service/hotelservice/front_desk_service_test.go
+package hotelservice
+
+import (
+	"testing"
+	"time"
+
+	"backend/internal/models/hotel"
+)
+
+func TestCalendarDayKeepsStoredDate(t *testing.T) {
+	want := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	for _, loc := range []*time.Location{
+		time.UTC,
+		time.FixedZone("WIB", 7*3600),
+		time.FixedZone("EST", -5*3600),
+	} {
+		// kolom date dibaca sebagai tengah malam zona lokal
+		stored := time.Date(2025, 3, 10, 0, 0, 0, 0, loc)
+		if got := calendarDay(stored); !got.Equal(want) {
+			t.Errorf("%s: calendarDay = %v, want %v", loc, got, want)
+		}
+	}
+}
+
+func TestLocalDayUsesHotelClock(t *testing.T) {
+	wib := time.FixedZone("WIB", 7*3600)
+	prev := time.Local
+	time.Local = wib
+	defer func() { time.Local = prev }()
+
+	// 20:00 UTC tanggal 9 sudah tanggal 10 di hotel
+	now := time.Date(2025, 3, 9, 20, 0, 0, 0, time.UTC)
+	if got, want := localDay(now), time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("localDay = %v, want %v", got, want)
+	}
+}
+
+func TestRecordRefundCreatesRefundPayment(t *testing.T) {
+	db, _ := newStubDB(t)
+	payments := newFakePaymentRepo()
+	s := &frontDeskService{paymentRepo: payments}
+	payments.Create(&hotel.Payment{BookingID: 1, OrderID: "BK-1-1", Amount: 900000, Status: hotel.PaymentStatusPaid})
+
+	b := &hotel.Booking{ID: 1, TotalPrice: 600000}
+	var changes []hotel.BookingFieldChange
+	if err := s.recordRefund(db, b, 900000, time.Now(), &changes); err != nil {
+		t.Fatalf("recordRefund: %v", err)
+	}
+	if b.RefundAmount != 300000 {
+		t.Fatalf("refund amount = %d, want 300000", b.RefundAmount)
+	}
+	list, _ := payments.ListByBooking(1)
+	var refund *hotel.Payment
+	for i := range list {
+		if list[i].Status == hotel.PaymentStatusRefund {
+			refund = &list[i]
+		}
+	}
+	if refund == nil || refund.Amount != 300000 || !refund.RefundRequired {
+		t.Fatalf("refund payment = %+v, want 300000 with refund_required", refund)
+	}
+
+	// Booking yang belum dibayar penuh tidak mendapat refund
+	unpaid := &hotel.Booking{ID: 2, TotalPrice: 600000}
+	if err := s.recordRefund(db, unpaid, 900000, time.Now(), &changes); err != nil {
+		t.Fatalf("recordRefund: %v", err)
+	}
+	if unpaid.RefundAmount != 0 {
+		t.Fatalf("unpaid booking refund = %d, want 0", unpaid.RefundAmount)
+	}
+}
@@ -12,11 +12,22 @@ type GalleryService interface {
 	Update(id uint, req hotel.UpdateGalleryRequest) (*hotel.Gallery, error)
 	Save(item *hotel.Gallery) error // simpan semua field (untuk update image)
 	Delete(id uint) error
+	RoomTypeExists(slug string) bool // validasi filter room_type terhadap katalog
 }
 
-type galleryService struct{ repo repohotel.GalleryRepository }
+type galleryService struct {
+	repo         repohotel.GalleryRepository
+	roomTypeRepo repohotel.RoomTypeRepository
+}
+
+func NewGalleryService(repo repohotel.GalleryRepository, roomTypeRepo repohotel.RoomTypeRepository) GalleryService {
+	return &galleryService{repo: repo, roomTypeRepo: roomTypeRepo}
+}
 
-func NewGalleryService(repo repohotel.GalleryRepository) GalleryService { return &galleryService{repo} }
+func (s *galleryService) RoomTypeExists(slug string) bool {
+	rt, err := s.roomTypeRepo.FindByType(slug)
+	return err == nil && rt != nil
+}
 
 func (s *galleryService) Create(item *hotel.Gallery) error                 { return s.repo.Create(item) }
 func (s *galleryService) GetByID(id uint) (*hotel.Gallery, error)          { return s.repo.FindByID(id) }
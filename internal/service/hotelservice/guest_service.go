@@ -0,0 +1,115 @@
+// internal/service/hotelservice/guest_service.go
+package hotelservice
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"backend/internal/models/auth"
+	"backend/internal/models/hotel"
+	"backend/internal/repository/guest"
+	"backend/internal/repository/repohotel"
+	"gorm.io/gorm"
+)
+
+type GuestService interface {
+	Profile(id uint) (*hotel.GuestProfile, error)
+	UpdateProfile(id uint, req auth.UpdateGuestProfileRequest) (*auth.Guest, error)
+	List(search string, limit, offset int) ([]auth.Guest, int64, error)
+}
+
+type guestService struct {
+	guestRepo   guest.GuestRepository
+	bookingRepo repohotel.BookingRepository
+}
+
+func NewGuestService(guestRepo guest.GuestRepository, bookingRepo repohotel.BookingRepository) GuestService {
+	return &guestService{guestRepo: guestRepo, bookingRepo: bookingRepo}
+}
+
+func (s *guestService) find(id uint) (*auth.Guest, error) {
+	g, err := s.guestRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if g == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return g, nil
+}
+
+func (s *guestService) Profile(id uint) (*hotel.GuestProfile, error) {
+	g, err := s.find(id)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.bookingRepo.StayStats(id)
+	if err != nil {
+		return nil, err
+	}
+	stays, _, err := s.bookingRepo.List(repohotel.BookingFilter{UserID: &id, Limit: 10})
+	if err != nil {
+		return nil, err
+	}
+	return &hotel.GuestProfile{Guest: *g, Stats: *stats, RecentStays: stays}, nil
+}
+
+func (s *guestService) UpdateProfile(id uint, req auth.UpdateGuestProfileRequest) (*auth.Guest, error) {
+	g, err := s.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.FullName != nil {
+		g.FullName = strings.TrimSpace(*req.FullName)
+	}
+	if req.PhoneNumber != nil {
+		g.PhoneNumber = strings.TrimSpace(*req.PhoneNumber)
+	}
+	if req.IDType != nil {
+		g.IDType = *req.IDType
+	}
+	if req.IDNumber != nil {
+		g.IDNumber = strings.TrimSpace(*req.IDNumber)
+	}
+	if req.Nationality != nil {
+		g.Nationality = strings.TrimSpace(*req.Nationality)
+	}
+	if req.DateOfBirth != nil {
+		if *req.DateOfBirth == "" {
+			g.DateOfBirth = nil
+		} else {
+			dob, err := time.Parse(dateLayout, *req.DateOfBirth)
+			if err != nil {
+				return nil, errors.New("format date_of_birth tidak valid, gunakan YYYY-MM-DD")
+			}
+			g.DateOfBirth = &dob
+		}
+	}
+	if req.Address != nil {
+		g.Address = *req.Address
+	}
+	if req.Preferences != nil {
+		g.Preferences = *req.Preferences
+	}
+	// Waktu persetujuan dicatat setiap kali tamu memberi consent
+	if req.MarketingConsent != nil && *req.MarketingConsent != g.MarketingConsent {
+		g.MarketingConsent = *req.MarketingConsent
+		if g.MarketingConsent {
+			now := time.Now()
+			g.MarketingConsentAt = &now
+		} else {
+			g.MarketingConsentAt = nil
+		}
+	}
+
+	if err := s.guestRepo.Update(g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (s *guestService) List(search string, limit, offset int) ([]auth.Guest, int64, error) {
+	return s.guestRepo.List(strings.TrimSpace(search), limit, offset)
+}
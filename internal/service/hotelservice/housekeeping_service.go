@@ -0,0 +1,223 @@
+// internal/service/hotelservice/housekeeping_service.go
+package hotelservice
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"backend/internal/models/hotel"
+	"backend/internal/repository/repohotel"
+	"gorm.io/gorm"
+)
+
+// Alur kamar setelah check-out:
+// check-out → kamar cleaning + task pending → (assign) → in_progress → cleaned
+// → inspeksi lolos → kamar available. Inspeksi gagal mengembalikan task ke pending.
+type HousekeepingService interface {
+	// OnCheckout: dipanggil di dalam transaksi check-out
+	OnCheckout(tx *gorm.DB, roomID uint, bookingID *uint) error
+
+	Create(req hotel.CreateHousekeepingTaskRequest) (*hotel.HousekeepingTask, error)
+	List(f repohotel.HousekeepingFilter) ([]hotel.HousekeepingTask, error)
+	Board() (*hotel.HousekeepingBoard, error)
+	Assign(id, staffID uint) (*hotel.HousekeepingTask, error)
+	Start(id, staffID uint) (*hotel.HousekeepingTask, error)
+	MarkCleaned(id, staffID uint) (*hotel.HousekeepingTask, error)
+	Inspect(id, inspectorID uint, req hotel.InspectHousekeepingTaskRequest) (*hotel.HousekeepingTask, error)
+}
+
+type housekeepingService struct {
+	repo     repohotel.HousekeepingRepository
+	roomRepo repohotel.RoomRepository
+	db       *gorm.DB
+}
+
+func NewHousekeepingService(repo repohotel.HousekeepingRepository, roomRepo repohotel.RoomRepository, db *gorm.DB) HousekeepingService {
+	return &housekeepingService{
+		repo:     repo,
+		roomRepo: roomRepo,
+		db:       db,
+	}
+}
+
+func (s *housekeepingService) OnCheckout(tx *gorm.DB, roomID uint, bookingID *uint) error {
+	if err := tx.Model(&hotel.Room{}).
+		Where("id = ?", roomID).
+		Update("status", hotel.RoomStatusCleaning).Error; err != nil {
+		return err
+	}
+
+	// Satu task checkout terbuka per kamar sudah cukup
+	open, err := s.repo.WithTx(tx).CountOpenForRoom(roomID, 0)
+	if err != nil {
+		return err
+	}
+	if open > 0 {
+		return nil
+	}
+	return s.repo.WithTx(tx).Create(&hotel.HousekeepingTask{
+		RoomID:    roomID,
+		BookingID: bookingID,
+		Type:      hotel.HousekeepingTypeCheckout,
+		Status:    hotel.HousekeepingPending,
+	})
+}
+
+// Create: task manual, tidak mengubah status kamar
+func (s *housekeepingService) Create(req hotel.CreateHousekeepingTaskRequest) (*hotel.HousekeepingTask, error) {
+	if _, err := s.roomRepo.FindByID(req.RoomID); err != nil {
+		return nil, errors.New("kamar tidak ditemukan")
+	}
+	t := &hotel.HousekeepingTask{
+		RoomID: req.RoomID,
+		Type:   hotel.HousekeepingTypeRequest,
+		Status: hotel.HousekeepingPending,
+		Notes:  req.Notes,
+	}
+	if err := s.repo.Create(t); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(t.ID)
+}
+
+func (s *housekeepingService) List(f repohotel.HousekeepingFilter) ([]hotel.HousekeepingTask, error) {
+	return s.repo.List(f)
+}
+
+func (s *housekeepingService) Board() (*hotel.HousekeepingBoard, error) {
+	tasks, err := s.repo.ListOpen()
+	if err != nil {
+		return nil, err
+	}
+	board := &hotel.HousekeepingBoard{
+		Pending:    []hotel.HousekeepingTask{},
+		InProgress: []hotel.HousekeepingTask{},
+		Cleaned:    []hotel.HousekeepingTask{},
+	}
+	for _, t := range tasks {
+		switch t.Status {
+		case hotel.HousekeepingPending:
+			board.Pending = append(board.Pending, t)
+		case hotel.HousekeepingInProgress:
+			board.InProgress = append(board.InProgress, t)
+		case hotel.HousekeepingCleaned:
+			board.Cleaned = append(board.Cleaned, t)
+		}
+	}
+	board.UntrackedRooms, err = s.repo.UntrackedCleaningRooms()
+	if err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+// transition: kunci task, cek status asal, jalankan perubahan lalu simpan
+func (s *housekeepingService) transition(id uint, from []hotel.HousekeepingStatus, apply func(tx *gorm.DB, t *hotel.HousekeepingTask) error) (*hotel.HousekeepingTask, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		t, err := s.repo.WithTx(tx).LockByID(id)
+		if err != nil {
+			return err
+		}
+		allowed := false
+		for _, st := range from {
+			if t.Status == st {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			names := make([]string, len(from))
+			for i, st := range from {
+				names[i] = string(st)
+			}
+			return fmt.Errorf("task berstatus %s, harus %s", t.Status, strings.Join(names, "/"))
+		}
+		if err := apply(tx, t); err != nil {
+			return err
+		}
+		return s.repo.WithTx(tx).Update(t)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(id)
+}
+
+func (s *housekeepingService) Assign(id, staffID uint) (*hotel.HousekeepingTask, error) {
+	ok, err := s.repo.IsHotelStaff(staffID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("staf tidak ditemukan atau bukan admin hotel")
+	}
+	return s.transition(id, []hotel.HousekeepingStatus{hotel.HousekeepingPending, hotel.HousekeepingInProgress},
+		func(tx *gorm.DB, t *hotel.HousekeepingTask) error {
+			t.AssignedTo = &staffID
+			return nil
+		})
+}
+
+func (s *housekeepingService) Start(id, staffID uint) (*hotel.HousekeepingTask, error) {
+	return s.transition(id, []hotel.HousekeepingStatus{hotel.HousekeepingPending},
+		func(tx *gorm.DB, t *hotel.HousekeepingTask) error {
+			now := time.Now()
+			t.Status = hotel.HousekeepingInProgress
+			t.StartedAt = &now
+			if t.AssignedTo == nil {
+				t.AssignedTo = &staffID
+			}
+			return nil
+		})
+}
+
+func (s *housekeepingService) MarkCleaned(id, staffID uint) (*hotel.HousekeepingTask, error) {
+	return s.transition(id, []hotel.HousekeepingStatus{hotel.HousekeepingPending, hotel.HousekeepingInProgress},
+		func(tx *gorm.DB, t *hotel.HousekeepingTask) error {
+			now := time.Now()
+			if t.StartedAt == nil {
+				t.StartedAt = &now
+			}
+			if t.AssignedTo == nil {
+				t.AssignedTo = &staffID
+			}
+			t.Status = hotel.HousekeepingCleaned
+			t.CleanedAt = &now
+			return nil
+		})
+}
+
+func (s *housekeepingService) Inspect(id, inspectorID uint, req hotel.InspectHousekeepingTaskRequest) (*hotel.HousekeepingTask, error) {
+	return s.transition(id, []hotel.HousekeepingStatus{hotel.HousekeepingCleaned},
+		func(tx *gorm.DB, t *hotel.HousekeepingTask) error {
+			if req.Notes != "" {
+				t.Notes = strings.TrimSpace(t.Notes + "\n" + req.Notes)
+			}
+			if !req.Passed {
+				// Dibersihkan ulang oleh staf yang sama
+				t.Status = hotel.HousekeepingPending
+				t.StartedAt = nil
+				t.CleanedAt = nil
+				return nil
+			}
+
+			now := time.Now()
+			t.Status = hotel.HousekeepingInspected
+			t.InspectedAt = &now
+			t.InspectedBy = &inspectorID
+
+			// Kamar baru dijual lagi bila tidak ada task lain yang masih terbuka
+			open, err := s.repo.WithTx(tx).CountOpenForRoom(t.RoomID, t.ID)
+			if err != nil {
+				return err
+			}
+			if open > 0 {
+				return nil
+			}
+			return tx.Model(&hotel.Room{}).
+				Where("id = ? AND status = ?", t.RoomID, hotel.RoomStatusCleaning).
+				Update("status", hotel.RoomStatusAvailable).Error
+		})
+}
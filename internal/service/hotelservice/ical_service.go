@@ -0,0 +1,459 @@
+// internal/service/hotelservice/ical_service.go
+package hotelservice
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"backend/internal/ical"
+	"backend/internal/models/hotel"
+	"backend/internal/repository/repohotel"
+	"gorm.io/gorm"
+)
+
+// Rentang ekspor feed per tipe kamar
+const icalExportDays = 365
+
+// Batas ukuran feed iCal yang diimpor
+const icalMaxFeedBytes = 5 << 20
+
+type ICalService interface {
+	CreateFeed(req hotel.CreateICalFeedRequest) (*hotel.ICalFeed, error)
+	ListFeeds() ([]hotel.ICalFeed, error)
+	UpdateFeed(id uint, req hotel.UpdateICalFeedRequest) (*hotel.ICalFeed, error)
+	DeleteFeed(id uint) error
+
+	// SyncFeed: impor satu feed sekarang juga
+	SyncFeed(id uint) (*hotel.ICalSyncResult, error)
+	// SyncAll: impor semua feed aktif, dipanggil berkala oleh background job
+	SyncAll(ctx context.Context) (int, error)
+
+	RoomCalendar(roomID uint) ([]byte, error)
+	RoomTypeCalendar(roomTypeID uint) ([]byte, error)
+}
+
+type icalService struct {
+	repo         repohotel.ICalRepository
+	bookingRepo  repohotel.BookingRepository
+	roomRepo     repohotel.RoomRepository
+	roomTypeRepo repohotel.RoomTypeRepository
+	client       *http.Client
+	db           *gorm.DB
+}
+
+func NewICalService(repo repohotel.ICalRepository, bookingRepo repohotel.BookingRepository, roomRepo repohotel.RoomRepository, roomTypeRepo repohotel.RoomTypeRepository, db *gorm.DB) ICalService {
+	return &icalService{
+		repo:         repo,
+		bookingRepo:  bookingRepo,
+		roomRepo:     roomRepo,
+		roomTypeRepo: roomTypeRepo,
+		client:       newFeedClient(),
+		db:           db,
+	}
+}
+
+func (s *icalService) CreateFeed(req hotel.CreateICalFeedRequest) (*hotel.ICalFeed, error) {
+	if err := validateFeedURL(req.URL); err != nil {
+		return nil, err
+	}
+	if _, err := s.roomRepo.FindByID(req.RoomID); err != nil {
+		return nil, errors.New("kamar tidak ditemukan")
+	}
+	f := &hotel.ICalFeed{
+		RoomID: req.RoomID,
+		Name:   req.Name,
+		URL:    req.URL,
+		Active: true,
+	}
+	if err := s.repo.CreateFeed(f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *icalService) ListFeeds() ([]hotel.ICalFeed, error) {
+	return s.repo.ListFeeds(false)
+}
+
+func (s *icalService) UpdateFeed(id uint, req hotel.UpdateICalFeedRequest) (*hotel.ICalFeed, error) {
+	f, err := s.repo.FindFeed(id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		f.Name = *req.Name
+	}
+	if req.URL != nil {
+		if err := validateFeedURL(*req.URL); err != nil {
+			return nil, err
+		}
+		f.URL = *req.URL
+	}
+	if req.Active != nil {
+		f.Active = *req.Active
+	}
+	if err := s.repo.UpdateFeed(f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// DeleteFeed: blok yang sudah diimpor dari feed ini ikut dilepas
+func (s *icalService) DeleteFeed(id uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Model(&hotel.Booking{}).
+			Where("feed_id = ? AND status = ?", id, hotel.BookingStatusBlocked).
+			Updates(map[string]interface{}{
+				"status":        hotel.BookingStatusCancelled,
+				"cancelled_at":  now,
+				"cancel_reason": "feed iCal dihapus",
+			}).Error; err != nil {
+			return err
+		}
+		return s.repo.WithTx(tx).DeleteFeed(id)
+	})
+}
+
+func (s *icalService) SyncAll(ctx context.Context) (int, error) {
+	feeds, err := s.repo.WithTx(s.db.WithContext(ctx)).ListFeeds(true)
+	if err != nil {
+		return 0, err
+	}
+	var failed []string
+	for _, f := range feeds {
+		if err := ctx.Err(); err != nil {
+			return len(feeds) - len(failed), err
+		}
+		if _, err := s.syncFeed(ctx, f.ID); err != nil {
+			failed = append(failed, fmt.Sprintf("feed %d: %v", f.ID, err))
+		}
+	}
+	if len(failed) > 0 {
+		return len(feeds) - len(failed), errors.New(strings.Join(failed, "; "))
+	}
+	return len(feeds), nil
+}
+
+func (s *icalService) SyncFeed(id uint) (*hotel.ICalSyncResult, error) {
+	return s.syncFeed(context.Background(), id)
+}
+
+func (s *icalService) syncFeed(ctx context.Context, id uint) (*hotel.ICalSyncResult, error) {
+	db := s.db.WithContext(ctx)
+	feed, err := s.repo.WithTx(db).FindFeed(id)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.fetch(ctx, feed.URL)
+	if err != nil {
+		feed.LastError = err.Error()
+		if uerr := s.repo.WithTx(db).UpdateFeed(feed); uerr != nil {
+			return nil, errors.Join(err, fmt.Errorf("gagal menyimpan status feed: %w", uerr))
+		}
+		return nil, err
+	}
+
+	result := &hotel.ICalSyncResult{FeedID: feed.ID}
+	today, _ := time.Parse(dateLayout, time.Now().Format(dateLayout))
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		txRepo := s.bookingRepo.WithTx(tx)
+		// Kunci kamar supaya impor tidak balapan dengan booking dari website
+		if _, err := txRepo.LockRoom(feed.RoomID); err != nil {
+			return errors.New("kamar feed tidak ditemukan")
+		}
+
+		imported, err := s.repo.WithTx(tx).ImportedBookings(feed.ID)
+		if err != nil {
+			return err
+		}
+		existing := make(map[string]*hotel.Booking, len(imported))
+		for i := range imported {
+			existing[imported[i].ExternalUID] = &imported[i]
+		}
+
+		for _, e := range events {
+			if e.Cancelled || !e.End.After(today) {
+				continue
+			}
+			uid := e.UID
+			if uid == "" {
+				uid = e.Start.Format(dateLayout) + "/" + e.End.Format(dateLayout)
+			}
+			nights := int(e.End.Sub(e.Start).Hours() / 24)
+
+			if b, ok := existing[uid]; ok {
+				delete(existing, uid)
+				if b.CheckIn.Equal(e.Start) && b.CheckOut.Equal(e.End) {
+					continue
+				}
+				taken, err := roomTaken(txRepo, feed.RoomID, e.Start, e.End, &b.ID)
+				if err != nil {
+					return err
+				}
+				if taken {
+					result.Conflicts = append(result.Conflicts, conflictNote(e))
+					continue
+				}
+				if err := tx.Model(b).Updates(map[string]interface{}{
+					"check_in":     e.Start,
+					"check_out":    e.End,
+					"total_nights": nights,
+				}).Error; err != nil {
+					return err
+				}
+				result.Updated++
+				continue
+			}
+
+			taken, err := roomTaken(txRepo, feed.RoomID, e.Start, e.End, nil)
+			if err != nil {
+				return err
+			}
+			if taken {
+				result.Conflicts = append(result.Conflicts, conflictNote(e))
+				continue
+			}
+			feedID := feed.ID
+			block := &hotel.Booking{
+				RoomID:      feed.RoomID,
+				Name:        "OTA: " + feed.Name,
+				Phone:       "-",
+				CheckIn:     e.Start,
+				CheckOut:    e.End,
+				Guests:      1,
+				TotalNights: nights,
+				Status:      hotel.BookingStatusBlocked,
+				Notes:       e.Summary,
+				Source:      hotel.BookingSourceICal,
+				FeedID:      &feedID,
+				ExternalUID: uid,
+			}
+			if err := tx.Create(block).Error; err != nil {
+				return err
+			}
+			result.Created++
+		}
+
+		// Event yang hilang dari feed berarti dibatalkan di OTA
+		now := time.Now()
+		for _, b := range existing {
+			if !b.CheckOut.After(today) {
+				continue
+			}
+			if err := tx.Model(b).Updates(map[string]interface{}{
+				"status":        hotel.BookingStatusCancelled,
+				"cancelled_at":  now,
+				"cancel_reason": "tidak ada lagi di feed iCal",
+			}).Error; err != nil {
+				return err
+			}
+			result.Released++
+		}
+
+		feed.LastSyncedAt = &now
+		feed.LastError = strings.Join(result.Conflicts, "; ")
+		return s.repo.WithTx(tx).UpdateFeed(feed)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// roomTaken: kamar sudah terpakai booking lain atau blok maintenance di rentang tersebut
+func roomTaken(repo repohotel.BookingRepository, roomID uint, checkIn, checkOut time.Time, excludeID *uint) (bool, error) {
+	count, err := repo.CountOverlapping(roomID, checkIn, checkOut, excludeID)
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+	return repo.IsBlocked(roomID, checkIn, checkOut)
+}
+
+// conflictNote: event OTA yang bentrok dengan booking lain (terjual dua kali) atau maintenance
+func conflictNote(e ical.Event) string {
+	return fmt.Sprintf("bentrok %s s/d %s (%s)", e.Start.Format(dateLayout), e.End.Format(dateLayout), e.UID)
+}
+
+// newFeedClient: client untuk mengambil feed OTA. Alamat tujuan dicek saat dial (setelah
+// DNS & redirect) supaya URL feed tidak bisa diarahkan ke loopback atau jaringan internal.
+func newFeedClient() *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second, Control: guardFeedDial}
+	return &http.Client{
+		Timeout: 20 * time.Second,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
+
+// sharedAddressSpace: 100.64.0.0/10 (carrier-grade NAT), tidak tercakup IsPrivate
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+func guardFeedDial(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() || sharedAddressSpace.Contains(ip) {
+		return fmt.Errorf("alamat %s tidak diizinkan untuk feed (jaringan internal)", host)
+	}
+	return nil
+}
+
+// validateFeedURL: feed hanya boleh diambil lewat http/https
+func validateFeedURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return errors.New("url feed harus berupa alamat http atau https")
+	}
+	return nil
+}
+
+func (s *icalService) fetch(ctx context.Context, feedURL string) ([]ical.Event, error) {
+	if err := validateFeedURL(feedURL); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("url feed tidak valid: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil feed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("feed mengembalikan status %d", resp.StatusCode)
+	}
+	if resp.ContentLength > icalMaxFeedBytes {
+		return nil, fmt.Errorf("feed melebihi batas %d MB", icalMaxFeedBytes>>20)
+	}
+
+	// Baca satu byte lebih dari batas supaya feed yang terpotong tidak diimpor sebagian
+	body, err := io.ReadAll(io.LimitReader(resp.Body, icalMaxFeedBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca feed: %w", err)
+	}
+	if len(body) > icalMaxFeedBytes {
+		return nil, fmt.Errorf("feed melebihi batas %d MB", icalMaxFeedBytes>>20)
+	}
+	return ical.Parse(bytes.NewReader(body))
+}
+
+// RoomCalendar: satu event per booking aktif, tanpa data pribadi tamu
+func (s *icalService) RoomCalendar(roomID uint) ([]byte, error) {
+	room, err := s.roomRepo.FindByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+	today, _ := time.Parse(dateLayout, time.Now().Format(dateLayout))
+	bookings, err := s.repo.ActiveBookingsForRoom(roomID, today)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := s.repo.BlocksForRoom(roomID, today)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]ical.Event, 0, len(bookings)+len(blocks))
+	for _, b := range bookings {
+		events = append(events, ical.Event{
+			UID:     fmt.Sprintf("booking-%d@mutiara-hotel", b.ID),
+			Summary: "Not available",
+			Start:   b.CheckIn,
+			End:     b.CheckOut,
+		})
+	}
+	for _, b := range blocks {
+		events = append(events, ical.Event{
+			UID:     fmt.Sprintf("maintenance-%d@mutiara-hotel", b.ID),
+			Summary: "Not available",
+			Start:   b.StartDate,
+			End:     b.EndDate,
+		})
+	}
+	return ical.Encode("Kamar "+room.Number, events), nil
+}
+
+// RoomTypeCalendar: rentang malam saat semua kamar tipe ini terisi (sold out)
+func (s *icalService) RoomTypeCalendar(roomTypeID uint) ([]byte, error) {
+	rt, err := s.roomTypeRepo.FindByID(roomTypeID)
+	if err != nil {
+		return nil, err
+	}
+	totalRooms, err := s.repo.CountRoomsOfType(roomTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, _ := time.Parse(dateLayout, time.Now().Format(dateLayout))
+	to := from.AddDate(0, 0, icalExportDays)
+	bookings, err := s.repo.ActiveBookingsForRoomType(roomTypeID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := s.repo.BlocksForRoomType(roomTypeID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// jumlah kamar terpakai (booking atau maintenance) per malam
+	used := make(map[string]map[uint]bool)
+	markUsed := func(roomID uint, start, end time.Time) {
+		for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+			if d.Before(from) || !d.Before(to) {
+				continue
+			}
+			key := d.Format(dateLayout)
+			if used[key] == nil {
+				used[key] = make(map[uint]bool)
+			}
+			used[key][roomID] = true
+		}
+	}
+	for _, b := range bookings {
+		markUsed(b.RoomID, b.CheckIn, b.CheckOut)
+	}
+	for _, b := range blocks {
+		markUsed(b.RoomID, b.StartDate, b.EndDate)
+	}
+
+	var events []ical.Event
+	var start *time.Time
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		soldOut := totalRooms > 0 && d.Before(to) && int64(len(used[d.Format(dateLayout)])) >= totalRooms
+		if soldOut && start == nil {
+			day := d
+			start = &day
+		}
+		if !soldOut && start != nil {
+			events = append(events, ical.Event{
+				UID:     fmt.Sprintf("roomtype-%d-%s@mutiara-hotel", roomTypeID, start.Format(dateLayout)),
+				Summary: "Sold out",
+				Start:   *start,
+				End:     d,
+			})
+			start = nil
+		}
+	}
+	return ical.Encode("Tipe "+rt.DisplayName(), events), nil
+}
@@ -0,0 +1,217 @@
+// internal/service/hotelservice/ical_service_test.go
+package hotelservice
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/internal/models/hotel"
+	"backend/internal/repository/repohotel"
+	"gorm.io/gorm"
+)
+
+type fakeICalRepo struct {
+	repohotel.ICalRepository
+	feed     hotel.ICalFeed
+	imported []hotel.Booking
+	saved    *hotel.ICalFeed
+}
+
+func (r *fakeICalRepo) WithTx(tx *gorm.DB) repohotel.ICalRepository { return r }
+
+func (r *fakeICalRepo) FindFeed(id uint) (*hotel.ICalFeed, error) {
+	if id != r.feed.ID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	f := r.feed
+	return &f, nil
+}
+
+func (r *fakeICalRepo) ListFeeds(activeOnly bool) ([]hotel.ICalFeed, error) {
+	return []hotel.ICalFeed{r.feed}, nil
+}
+
+func (r *fakeICalRepo) UpdateFeed(f *hotel.ICalFeed) error {
+	cp := *f
+	r.saved = &cp
+	return nil
+}
+
+func (r *fakeICalRepo) ImportedBookings(feedID uint) ([]hotel.Booking, error) {
+	out := make([]hotel.Booking, len(r.imported))
+	copy(out, r.imported)
+	return out, nil
+}
+
+type icalFixture struct {
+	service  ICalService
+	repo     *fakeICalRepo
+	bookings *fakeBookingRepo
+	db       *stubDB
+}
+
+func newICalFixture(t *testing.T, feedURL string) *icalFixture {
+	t.Helper()
+	db, stub := newStubDB(t)
+	repo := &fakeICalRepo{feed: hotel.ICalFeed{ID: 1, RoomID: 3, Name: "Booking.com", URL: feedURL, Active: true}}
+	bookings := &fakeBookingRepo{}
+	service := NewICalService(repo, bookings, nil, nil, db)
+	// server OTA palsu jalan di loopback yang ditolak client produksi
+	service.(*icalService).client = &http.Client{Timeout: 5 * time.Second}
+	return &icalFixture{
+		service:  service,
+		repo:     repo,
+		bookings: bookings,
+		db:       stub,
+	}
+}
+
+// serveFeed: server OTA palsu yang mengembalikan body apa adanya
+func serveFeed(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/calendar")
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func icalDate(t time.Time) string { return t.Format("20060102") }
+
+func TestSyncFeedImportsOTAEvents(t *testing.T) {
+	day := localDay(time.Now()).AddDate(0, 0, 10)
+	at := func(n int) time.Time { return day.AddDate(0, 0, n) }
+
+	feed := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		// event baru, UID & SUMMARY dilipat ke baris berikutnya
+		"BEGIN:VEVENT",
+		"UID:new-event-with-a-rather-long-identifier",
+		" @ota.example.com",
+		"SUMMARY:Reserved by",
+		"  OTA guest",
+		"DTSTART;VALUE=DATE:" + icalDate(at(0)),
+		"DTEND;VALUE=DATE:" + icalDate(at(2)),
+		"END:VEVENT",
+		// tanggal berubah di OTA
+		"BEGIN:VEVENT",
+		"UID:moved@ota",
+		"DTSTART;VALUE=DATE:" + icalDate(at(5)),
+		"DTEND;VALUE=DATE:" + icalDate(at(7)),
+		"END:VEVENT",
+		// tidak berubah
+		"BEGIN:VEVENT",
+		"UID:same@ota",
+		"DTSTART;VALUE=DATE:" + icalDate(at(20)),
+		"DTEND;VALUE=DATE:" + icalDate(at(21)),
+		"END:VEVENT",
+		// jam lokal ber-TZID: hanya tanggalnya yang dipakai
+		"BEGIN:VEVENT",
+		"UID:tz@ota",
+		"DTSTART;TZID=Asia/Jakarta:" + icalDate(at(30)) + "T140000",
+		"DTEND;TZID=Asia/Jakarta:" + icalDate(at(32)) + "T120000",
+		"END:VEVENT",
+		// bentrok dengan booking website
+		"BEGIN:VEVENT",
+		"UID:clash@ota",
+		"DTSTART;VALUE=DATE:" + icalDate(at(40)),
+		"DTEND;VALUE=DATE:" + icalDate(at(42)),
+		"END:VEVENT",
+		// bentrok dengan blok maintenance
+		"BEGIN:VEVENT",
+		"UID:maintenance@ota",
+		"DTSTART;VALUE=DATE:" + icalDate(at(50)),
+		"DTEND;VALUE=DATE:" + icalDate(at(51)),
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	f := newICalFixture(t, serveFeed(t, feed).URL)
+	feedID := uint(1)
+	f.repo.imported = []hotel.Booking{
+		{ID: 10, RoomID: 3, FeedID: &feedID, ExternalUID: "moved@ota", CheckIn: at(4), CheckOut: at(6), Status: hotel.BookingStatusBlocked},
+		{ID: 11, RoomID: 3, FeedID: &feedID, ExternalUID: "same@ota", CheckIn: at(20), CheckOut: at(21), Status: hotel.BookingStatusBlocked},
+		{ID: 12, RoomID: 3, FeedID: &feedID, ExternalUID: "gone@ota", CheckIn: at(60), CheckOut: at(62), Status: hotel.BookingStatusBlocked},
+	}
+	f.bookings.taken = []dateRange{{at(41), at(43)}}
+	f.bookings.blocks = []dateRange{{at(50), at(52)}}
+
+	res, err := f.service.SyncFeed(1)
+	if err != nil {
+		t.Fatalf("SyncFeed: %v", err)
+	}
+	if res.Created != 2 || res.Updated != 1 || res.Released != 1 || len(res.Conflicts) != 2 {
+		t.Fatalf("result = %+v, want 2 created, 1 updated, 1 released, 2 conflicts", res)
+	}
+	for _, uid := range []string{"clash@ota", "maintenance@ota"} {
+		if !strings.Contains(strings.Join(res.Conflicts, ";"), uid) {
+			t.Errorf("conflicts %v missing %s", res.Conflicts, uid)
+		}
+	}
+	if n := len(f.db.Execs("INSERT INTO `bookings`")); n != 2 {
+		t.Fatalf("inserted %d blocks, want 2", n)
+	}
+	if f.repo.saved == nil || f.repo.saved.LastSyncedAt == nil || f.repo.saved.LastError == "" {
+		t.Fatalf("feed status = %+v, want last_synced_at and conflicts recorded", f.repo.saved)
+	}
+}
+
+func TestSyncFeedRejectsOversizedFeed(t *testing.T) {
+	body := "BEGIN:VCALENDAR\r\nX-PAD:" + strings.Repeat("a", icalMaxFeedBytes) + "\r\nEND:VCALENDAR\r\n"
+	f := newICalFixture(t, serveFeed(t, body).URL)
+
+	if _, err := f.service.SyncFeed(1); err == nil || !strings.Contains(err.Error(), "batas") {
+		t.Fatalf("err = %v, want size limit error", err)
+	}
+	if f.repo.saved == nil || f.repo.saved.LastError == "" {
+		t.Fatal("fetch error should be stored on the feed")
+	}
+	if len(f.db.Execs("INSERT")) != 0 {
+		t.Fatal("nothing should be imported from an oversized feed")
+	}
+}
+
+func TestSyncFeedRejectsNonHTTPURL(t *testing.T) {
+	for _, u := range []string{"file:///etc/passwd", "ftp://ota.example.com/feed.ics", "/relative.ics"} {
+		f := newICalFixture(t, u)
+		if _, err := f.service.SyncFeed(1); err == nil {
+			t.Errorf("%s: expected error", u)
+		}
+	}
+}
+
+func TestSyncAllStopsWhenContextCancelled(t *testing.T) {
+	f := newICalFixture(t, "http://127.0.0.1:1/feed.ics")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := f.service.SyncAll(ctx); err != context.Canceled {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestFeedClientRejectsInternalAddresses(t *testing.T) {
+	srv := serveFeed(t, "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")
+	f := newICalFixture(t, srv.URL)
+	f.service.(*icalService).client = newFeedClient()
+
+	if _, err := f.service.SyncFeed(1); err == nil || !strings.Contains(err.Error(), "tidak diizinkan") {
+		t.Fatalf("err = %v, want loopback rejected", err)
+	}
+
+	for _, addr := range []string{"10.0.0.5:80", "192.168.1.1:443", "169.254.169.254:80", "[::1]:80", "100.64.0.1:80", "0.0.0.0:80"} {
+		if err := guardFeedDial("tcp", addr, nil); err == nil {
+			t.Errorf("%s: expected rejection", addr)
+		}
+	}
+	if err := guardFeedDial("tcp", "93.184.216.34:443", nil); err != nil {
+		t.Errorf("public address rejected: %v", err)
+	}
+}
@@ -0,0 +1,321 @@
+// internal/service/hotelservice/invoice_service.go
+package hotelservice
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"backend/internal/models/hotel"
+	"backend/internal/pdf"
+	"backend/internal/repository/repohotel"
+	"backend/utils"
+)
+
+// InvoiceService: invoice / voucher konfirmasi booking dalam bentuk PDF
+type InvoiceService interface {
+	// Render: PDF beserta nama file-nya
+	Render(bookingID uint) ([]byte, string, error)
+	RenderForUser(bookingID, userID uint) ([]byte, string, error)
+	// Email: kirim invoice sebagai lampiran ke email guest
+	Email(bookingID uint) error
+}
+
+// hotelBranding: identitas hotel di kop dokumen, diambil dari env
+type hotelBranding struct {
+	Name    string
+	Address string
+	Phone   string
+	Email   string
+}
+
+type invoiceService struct {
+	bookingRepo repohotel.BookingRepository
+	paymentRepo repohotel.PaymentRepository
+	brand       hotelBranding
+}
+
+func NewInvoiceService(bookingRepo repohotel.BookingRepository, paymentRepo repohotel.PaymentRepository) InvoiceService {
+	brand := hotelBranding{
+		Name:    os.Getenv("HOTEL_NAME"),
+		Address: os.Getenv("HOTEL_ADDRESS"),
+		Phone:   os.Getenv("HOTEL_PHONE"),
+		Email:   os.Getenv("HOTEL_EMAIL"),
+	}
+	if brand.Name == "" {
+		brand.Name = "Mutiara Hotel"
+	}
+	return &invoiceService{
+		bookingRepo: bookingRepo,
+		paymentRepo: paymentRepo,
+		brand:       brand,
+	}
+}
+
+func (s *invoiceService) Render(bookingID uint) ([]byte, string, error) {
+	b, err := s.bookingRepo.FindDetail(bookingID)
+	if err != nil {
+		return nil, "", err
+	}
+	return s.render(b)
+}
+
+func (s *invoiceService) RenderForUser(bookingID, userID uint) ([]byte, string, error) {
+	b, err := s.bookingRepo.FindForUser(bookingID, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	return s.render(b)
+}
+
+func (s *invoiceService) Email(bookingID uint) error {
+	b, err := s.bookingRepo.FindDetail(bookingID)
+	if err != nil {
+		return err
+	}
+	if b.Email == "" {
+		return errors.New("booking tidak memiliki email")
+	}
+	doc, filename, err := s.render(b)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(`
+		<h2>Halo %s,</h2>
+		<p>Terlampir invoice untuk booking <strong>#%d</strong> (%s - %s).</p>
+		<br>
+		<p>Terima kasih,<br>Tim %s</p>
+	`, b.Name, b.ID, b.CheckIn.Format("02 Jan 2006"), b.CheckOut.Format("02 Jan 2006"), s.brand.Name)
+
+	return utils.SendEmailWithAttachments(b.Email, "Invoice Booking #"+fmt.Sprint(b.ID), body, utils.Attachment{
+		Filename:    filename,
+		ContentType: "application/pdf",
+		Data:        doc,
+	})
+}
+
+// invoiceNumber: INV-202512-000042
+func invoiceNumber(b *hotel.Booking) string {
+	return fmt.Sprintf("INV-%s-%06d", b.CreatedAt.Format("200601"), b.ID)
+}
+
+// paymentStatusLabel: status pembayaran yang dicetak di dokumen
+func paymentStatusLabel(b *hotel.Booking, paid int64) string {
+	switch {
+	case b.Status == hotel.BookingStatusCancelled:
+		return "DIBATALKAN"
+	case b.Status == hotel.BookingStatusExpired:
+		return "KEDALUWARSA"
+	case paid >= b.TotalPrice:
+		return "LUNAS"
+	case paid > 0:
+		return "DIBAYAR SEBAGIAN"
+	default:
+		return "BELUM DIBAYAR"
+	}
+}
+
+// isVoucher: booking yang sudah terkonfirmasi dicetak juga sebagai voucher check-in
+func isVoucher(b *hotel.Booking) bool {
+	switch b.Status {
+	case hotel.BookingStatusConfirmed, hotel.BookingStatusPaid, hotel.BookingStatusCheckedIn, hotel.BookingStatusCheckedOut:
+		return true
+	}
+	return false
+}
+
+func (s *invoiceService) render(b *hotel.Booking) ([]byte, string, error) {
+	paid, err := paidAmount(s.paymentRepo, b)
+	if err != nil {
+		return nil, "", err
+	}
+	payments, err := s.paymentRepo.ListByBooking(b.ID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	number := invoiceNumber(b)
+	title := "INVOICE"
+	if isVoucher(b) {
+		title = "INVOICE & VOUCHER"
+	}
+
+	const (
+		left  = 40.0
+		right = pdf.PageWidth - 40
+	)
+	doc := pdf.New(title + " " + number)
+
+	// Kop hotel
+	doc.FillRect(0, 0, pdf.PageWidth, 110, 0.93)
+	doc.Text(left, 50, 20, pdf.Bold, pdf.Left, s.brand.Name)
+	y := 66.0
+	for _, line := range []string{s.brand.Address, s.brand.Phone, s.brand.Email} {
+		if line != "" {
+			doc.Text(left, y, 9, pdf.Regular, pdf.Left, line)
+			y += 12
+		}
+	}
+	doc.Text(right, 50, 18, pdf.Bold, pdf.Right, title)
+	doc.Text(right, 66, 9, pdf.Regular, pdf.Right, "No. "+number)
+	doc.Text(right, 78, 9, pdf.Regular, pdf.Right, "Tanggal: "+time.Now().Format("02 Jan 2006"))
+	doc.Text(right, 90, 9, pdf.Bold, pdf.Right, paymentStatusLabel(b, paid))
+
+	// Data tamu & menginap
+	y = 140
+	doc.Text(left, y, 10, pdf.Bold, pdf.Left, "Ditagihkan kepada")
+	doc.Text(320, y, 10, pdf.Bold, pdf.Left, "Detail menginap")
+	guest := []string{b.Name, b.Phone, b.Email}
+	stay := []string{
+		fmt.Sprintf("Booking #%d - %s", b.ID, b.Status),
+		fmt.Sprintf("Kamar %s (No. %s)", b.Room.RoomType.DisplayName(), b.Room.Number),
+		fmt.Sprintf("Check-in: %s", b.CheckIn.Format("02 Jan 2006")),
+		fmt.Sprintf("Check-out: %s", b.CheckOut.Format("02 Jan 2006")),
+		fmt.Sprintf("%d malam, %d tamu", b.TotalNights, b.Guests),
+	}
+	if b.Adults > 0 {
+		stay[len(stay)-1] = fmt.Sprintf("%d malam, %d dewasa, %d anak", b.TotalNights, b.Adults, b.Children)
+	}
+	for i := 0; i < len(stay); i++ {
+		y += 14
+		if i < len(guest) {
+			doc.Text(left, y, 10, pdf.Regular, pdf.Left, guest[i])
+		}
+		doc.Text(320, y, 10, pdf.Regular, pdf.Left, stay[i])
+	}
+
+	// Rincian per malam
+	y += 30
+	doc.FillRect(left, y-12, right-left, 18, 0.85)
+	doc.Text(left+6, y, 10, pdf.Bold, pdf.Left, "Tanggal")
+	doc.Text(160, y, 10, pdf.Bold, pdf.Left, "Keterangan")
+	doc.Text(right-6, y, 10, pdf.Bold, pdf.Right, "Harga")
+	y += 8
+	for _, n := range b.Nights {
+		if y > pdf.PageHeight-160 {
+			doc.AddPage()
+			y = 60
+		}
+		y += 16
+		desc := "Kamar " + b.Room.RoomType.DisplayName()
+		if n.Source != "" && n.Source != hotel.RateSourceBase {
+			desc += " (" + n.Source + ")"
+		}
+		doc.Text(left+6, y, 10, pdf.Regular, pdf.Left, n.Date.Format("02 Jan 2006"))
+		doc.Text(160, y, 10, pdf.Regular, pdf.Left, desc)
+		doc.Text(right-6, y, 10, pdf.Regular, pdf.Right, formatRupiah(n.Price))
+	}
+	for _, a := range b.AddOns {
+		if y > pdf.PageHeight-160 {
+			doc.AddPage()
+			y = 60
+		}
+		y += 16
+		doc.Text(left+6, y, 10, pdf.Regular, pdf.Left, "Add-on")
+		doc.Text(160, y, 10, pdf.Regular, pdf.Left, fmt.Sprintf("%s x%d (%s)", a.Name, a.Quantity, a.Pricing.Label()))
+		doc.Text(right-6, y, 10, pdf.Regular, pdf.Right, formatRupiah(a.Total))
+	}
+	y += 10
+	doc.Line(left, y, right, y, 0.5)
+
+	// Ringkasan total
+	type row struct {
+		label string
+		value string
+		bold  bool
+	}
+	subtotal := b.Subtotal
+	if subtotal == 0 {
+		subtotal = b.TotalPrice + b.Discount - b.AddOnTotal
+	}
+	rows := []row{{"Subtotal kamar", formatRupiah(subtotal), false}}
+	if b.Discount > 0 {
+		rows = append(rows, row{"Diskon promo " + b.PromoCode, "-" + formatRupiah(b.Discount), false})
+	}
+	if b.AddOnTotal > 0 {
+		rows = append(rows, row{"Add-on", formatRupiah(b.AddOnTotal), false})
+	}
+	if b.ExtraGuestTotal > 0 {
+		rows = append(rows, row{"Tambahan tamu", formatRupiah(b.ExtraGuestTotal), false})
+	}
+	if b.LateCheckoutFee > 0 {
+		rows = append(rows, row{"Denda late check-out", formatRupiah(b.LateCheckoutFee), false})
+	}
+	for _, t := range b.Taxes {
+		rows = append(rows, row{taxLabel(t), formatRupiah(t.Amount), false})
+	}
+	rows = append(rows,
+		row{"Total", formatRupiah(b.TotalPrice), true},
+		row{"Dibayar", formatRupiah(paid), false},
+	)
+	if b.CancelledAt != nil {
+		rows = append(rows,
+			row{"Denda pembatalan", formatRupiah(b.CancellationFee), false},
+			row{"Refund", formatRupiah(b.RefundAmount), true},
+		)
+	} else if b.RefundAmount > 0 {
+		// Kelebihan bayar karena pulang lebih awal
+		rows = append(rows, row{"Refund", formatRupiah(b.RefundAmount), true})
+	} else if due := b.TotalPrice - paid; due > 0 {
+		rows = append(rows, row{"Sisa tagihan", formatRupiah(due), true})
+	}
+	for _, r := range rows {
+		y += 16
+		font := pdf.Regular
+		if r.bold {
+			font = pdf.Bold
+		}
+		doc.Text(360, y, 10, font, pdf.Left, r.label)
+		doc.Text(right-6, y, 10, font, pdf.Right, r.value)
+	}
+
+	// Riwayat pembayaran
+	if len(payments) > 0 || b.LegacyPaidAmount > 0 {
+		if y > pdf.PageHeight-160 {
+			doc.AddPage()
+			y = 40
+		}
+		y += 30
+		doc.Text(left, y, 10, pdf.Bold, pdf.Left, "Pembayaran")
+		for _, p := range payments {
+			y += 14
+			when := p.CreatedAt.Format("02 Jan 2006 15:04")
+			if p.PaidAt != nil {
+				when = p.PaidAt.Format("02 Jan 2006 15:04")
+			}
+			doc.Text(left, y, 9, pdf.Regular, pdf.Left, fmt.Sprintf("%s  %s  %s  %s", when, p.Provider, p.OrderID, p.Status))
+			amount := formatRupiah(p.Amount)
+			if p.Status == hotel.PaymentStatusRefund {
+				amount = "-" + amount
+			}
+			doc.Text(right-6, y, 9, pdf.Regular, pdf.Right, amount)
+		}
+		if b.LegacyPaidAmount > 0 {
+			y += 14
+			doc.Text(left, y, 9, pdf.Regular, pdf.Left, "Pembayaran manual sebelum pencatatan transaksi")
+			doc.Text(right-6, y, 9, pdf.Regular, pdf.Right, formatRupiah(b.LegacyPaidAmount))
+		}
+	}
+
+	// Catatan & footer
+	if isVoucher(b) {
+		y += 30
+		doc.Text(left, y, 10, pdf.Bold, pdf.Left, "Tunjukkan dokumen ini beserta kartu identitas saat check-in.")
+	}
+	if b.Notes != "" {
+		y += 6
+		for _, line := range pdf.WrapText("Catatan: "+b.Notes, right-left, 9, pdf.Regular) {
+			if y > pdf.PageHeight-80 {
+				break
+			}
+			y += 14
+			doc.Text(left, y, 9, pdf.Regular, pdf.Left, line)
+		}
+	}
+	doc.Line(left, pdf.PageHeight-60, right, pdf.PageHeight-60, 0.5)
+	doc.Text(pdf.PageWidth/2, pdf.PageHeight-45, 9, pdf.Regular, pdf.Center, "Terima kasih telah menginap di "+s.brand.Name)
+
+	return doc.Bytes(), fmt.Sprintf("%s.pdf", number), nil
+}
@@ -0,0 +1,145 @@
+// internal/service/hotelservice/maintenance_service.go
+package hotelservice
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"backend/internal/models/hotel"
+	"backend/internal/repository/repohotel"
+	"gorm.io/gorm"
+)
+
+// ErrMaintenanceConflict: blok bentrok dengan booking aktif dan tidak dipaksa (force)
+var ErrMaintenanceConflict = errors.New("kamar masih memiliki booking aktif pada tanggal tersebut, pindahkan booking dulu atau gunakan force")
+
+var errMaintenanceBlocked = errors.New("kamar sedang dalam perawatan pada tanggal tersebut")
+
+type MaintenanceService interface {
+	// Create: blok kamar untuk maintenance. Booking aktif yang bentrok selalu
+	// dikembalikan sebagai laporan relokasi; tanpa Force blok tidak dibuat.
+	Create(userID uint, req hotel.CreateMaintenanceBlockRequest) (*hotel.MaintenanceBlockResult, error)
+	List(roomID uint, start, end *time.Time) ([]hotel.MaintenanceBlock, error)
+	Delete(id uint) error
+}
+
+type maintenanceService struct {
+	repo        repohotel.MaintenanceRepository
+	bookingRepo repohotel.BookingRepository
+	db          *gorm.DB
+}
+
+func NewMaintenanceService(repo repohotel.MaintenanceRepository, bookingRepo repohotel.BookingRepository, db *gorm.DB) MaintenanceService {
+	return &maintenanceService{
+		repo:        repo,
+		bookingRepo: bookingRepo,
+		db:          db,
+	}
+}
+
+func (s *maintenanceService) Create(userID uint, req hotel.CreateMaintenanceBlockRequest) (*hotel.MaintenanceBlockResult, error) {
+	start, err := time.Parse(dateLayout, req.StartDate)
+	if err != nil {
+		return nil, errors.New("format start_date tidak valid")
+	}
+	end, err := time.Parse(dateLayout, req.EndDate)
+	if err != nil {
+		return nil, errors.New("format end_date tidak valid")
+	}
+	if !end.After(start) {
+		return nil, errors.New("end_date harus setelah start_date")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, errors.New("alasan maintenance wajib diisi")
+	}
+
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, errors.New("gagal memulai transaksi")
+	}
+	repo := s.repo.WithTx(tx)
+
+	// Kunci kamar supaya tidak ada booking baru yang masuk selama blok dibuat
+	room, err := s.bookingRepo.WithTx(tx).LockRoom(req.RoomID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	count, err := repo.CountOverlapping(room.ID, start, end)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if count > 0 {
+		tx.Rollback()
+		return nil, errors.New("kamar sudah diblokir maintenance pada tanggal tersebut")
+	}
+
+	bookings, err := repo.ActiveBookings(room.ID, start, end)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	result := &hotel.MaintenanceBlockResult{Relocations: []hotel.MaintenanceRelocation{}}
+	suggested := []uint{room.ID}
+	for _, b := range bookings {
+		item := hotel.MaintenanceRelocation{
+			BookingID: b.ID,
+			Name:      b.Name,
+			Status:    b.Status,
+			CheckIn:   b.CheckIn,
+			CheckOut:  b.CheckOut,
+		}
+		// Kamar pengganti tidak disarankan dua kali supaya laporan tidak saling bentrok
+		free, err := repo.FindFreeRoom(room.RoomTypeID, suggested, b.CheckIn, b.CheckOut)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			tx.Rollback()
+			return nil, err
+		}
+		if free != nil {
+			item.SuggestedRoomID = &free.ID
+			item.SuggestedRoomNumber = free.Number
+			suggested = append(suggested, free.ID)
+		}
+		result.Relocations = append(result.Relocations, item)
+	}
+	if len(bookings) > 0 && !req.Force {
+		tx.Rollback()
+		return result, ErrMaintenanceConflict
+	}
+
+	block := &hotel.MaintenanceBlock{
+		RoomID:    room.ID,
+		Reason:    reason,
+		StartDate: start,
+		EndDate:   end,
+		Forced:    len(bookings) > 0,
+		CreatedBy: &userID,
+	}
+	if err := repo.Create(block); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	result.Block, err = s.repo.FindByID(block.ID)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *maintenanceService) List(roomID uint, start, end *time.Time) ([]hotel.MaintenanceBlock, error) {
+	return s.repo.List(repohotel.MaintenanceFilter{RoomID: roomID, Start: start, End: end})
+}
+
+func (s *maintenanceService) Delete(id uint) error {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
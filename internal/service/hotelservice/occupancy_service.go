@@ -0,0 +1,107 @@
+// internal/service/hotelservice/occupancy_service.go
+package hotelservice
+
+import (
+	"errors"
+	"time"
+
+	"backend/internal/models/hotel"
+	"backend/internal/repository/repohotel"
+)
+
+// maxOccupancyDays: batas rentang tape chart per request
+const maxOccupancyDays = 93
+
+type OccupancyService interface {
+	Grid(start, end time.Time, roomTypeID uint) (*hotel.OccupancyGrid, error)
+}
+
+type occupancyService struct {
+	repo repohotel.OccupancyRepository
+}
+
+func NewOccupancyService(repo repohotel.OccupancyRepository) OccupancyService {
+	return &occupancyService{repo: repo}
+}
+
+func (s *occupancyService) Grid(start, end time.Time, roomTypeID uint) (*hotel.OccupancyGrid, error) {
+	start, end = calendarDay(start), calendarDay(end)
+	if !end.After(start) {
+		return nil, errors.New("end harus setelah start")
+	}
+	days := int(end.Sub(start).Hours() / 24)
+	if days > maxOccupancyDays {
+		return nil, errors.New("rentang maksimal 93 hari")
+	}
+
+	rooms, err := s.repo.ListRooms(roomTypeID)
+	if err != nil {
+		return nil, err
+	}
+	stays, err := s.repo.ListStays(start, end, roomTypeID)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := s.repo.ListBlocks(start, end, roomTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	grid := &hotel.OccupancyGrid{
+		Start:       start.Format(dateLayout),
+		End:         end.Format(dateLayout),
+		Dates:       make([]string, days),
+		Rooms:       rooms,
+		Bookings:    stays,
+		Maintenance: blocks,
+	}
+	for i := range grid.Dates {
+		grid.Dates[i] = start.AddDate(0, 0, i).Format(dateLayout)
+	}
+
+	rowByRoom := make(map[uint]int, len(rooms))
+	for i := range grid.Rooms {
+		grid.Rooms[i].Nights = make([]uint, days)
+		grid.Rooms[i].Blocks = make([]uint, days)
+		rowByRoom[grid.Rooms[i].RoomID] = i
+	}
+	// span: indeks malam [from, to) di grid untuk rentang tanggal a..b
+	span := func(a, b time.Time) (int, int) {
+		from := int(calendarDay(a).Sub(start).Hours() / 24)
+		to := int(calendarDay(b).Sub(start).Hours() / 24)
+		if from < 0 {
+			from = 0
+		}
+		if to > days {
+			to = days
+		}
+		return from, to
+	}
+	for _, b := range blocks {
+		row, ok := rowByRoom[b.RoomID]
+		if !ok {
+			continue
+		}
+		from, to := span(b.StartDate, b.EndDate)
+		for d := from; d < to; d++ {
+			if grid.Rooms[row].Blocks[d] == 0 {
+				grid.Rooms[row].Blocks[d] = b.ID
+			}
+		}
+	}
+	for _, b := range stays {
+		row, ok := rowByRoom[b.RoomID]
+		if !ok {
+			continue
+		}
+		from, to := span(b.CheckIn, b.CheckOut)
+		nights := grid.Rooms[row].Nights
+		for d := from; d < to; d++ {
+			// Bentrok (mis. blok OTA yang tumpang tindih): booking yang lebih dulu tetap tampil
+			if nights[d] == 0 {
+				nights[d] = b.ID
+			}
+		}
+	}
+	return grid, nil
+}
@@ -0,0 +1,205 @@
+// internal/service/hotelservice/payment_service.go
+package hotelservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"backend/internal/models/hotel"
+	"backend/internal/payment"
+	"backend/internal/repository/repohotel"
+	"gorm.io/gorm"
+)
+
+type PaymentService interface {
+	CreateForBooking(ctx context.Context, bookingID uint, userID *uint) (*hotel.PaymentResponse, error)
+	HandleWebhook(header http.Header, body []byte) error
+	ListByBooking(bookingID uint) ([]hotel.Payment, error)
+}
+
+type paymentService struct {
+	paymentRepo repohotel.PaymentRepository
+	bookingRepo repohotel.BookingRepository
+	provider    payment.PaymentProvider
+	db          *gorm.DB
+}
+
+func NewPaymentService(paymentRepo repohotel.PaymentRepository, bookingRepo repohotel.BookingRepository, provider payment.PaymentProvider, db *gorm.DB) PaymentService {
+	return &paymentService{
+		paymentRepo: paymentRepo,
+		bookingRepo: bookingRepo,
+		provider:    provider,
+		db:          db,
+	}
+}
+
+// CreateForBooking: buat tagihan di payment gateway untuk booking pending.
+// userID diisi bila dipanggil oleh guest; booking tanpa pemilik (walk-in) tidak bisa
+// dibayar lewat akun guest.
+func (s *paymentService) CreateForBooking(ctx context.Context, bookingID uint, userID *uint) (*hotel.PaymentResponse, error) {
+	b, err := s.bookingRepo.FindByID(bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if userID != nil && (b.UserID == nil || *b.UserID != *userID) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if b.Status != hotel.BookingStatusPending {
+		return nil, errors.New("hanya booking pending yang bisa dibayar")
+	}
+
+	paid, err := s.paymentRepo.SumPaidByBooking(b.ID)
+	if err != nil {
+		return nil, err
+	}
+	amount := b.TotalPrice - paid
+	if amount <= 0 {
+		return nil, errors.New("booking sudah lunas")
+	}
+
+	// Pakai ulang tagihan pending yang masih sama nominalnya
+	if existing, err := s.paymentRepo.FindPendingByBooking(b.ID); err == nil && existing.Amount == amount {
+		return toPaymentResponse(existing), nil
+	}
+
+	orderID := fmt.Sprintf("BK-%d-%d", b.ID, time.Now().UnixNano())
+	res, err := s.provider.CreateCharge(ctx, payment.ChargeRequest{
+		OrderID:       orderID,
+		Amount:        amount,
+		Description:   fmt.Sprintf("Booking #%d %s", b.ID, b.Room.RoomType.DisplayName()),
+		CustomerName:  b.Name,
+		CustomerEmail: b.Email,
+		CustomerPhone: b.Phone,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &hotel.Payment{
+		BookingID:   b.ID,
+		Provider:    s.provider.Name(),
+		OrderID:     orderID,
+		ExternalID:  res.ExternalID,
+		Amount:      amount,
+		Status:      hotel.PaymentStatusPending,
+		RedirectURL: res.RedirectURL,
+		RawPayload:  res.Raw,
+	}
+	if err := s.paymentRepo.Create(p); err != nil {
+		return nil, err
+	}
+	return toPaymentResponse(p), nil
+}
+
+// HandleWebhook: verifikasi notifikasi gateway lalu selesaikan pembayaran.
+// Idempoten: notifikasi ulang untuk pembayaran yang sudah paid diabaikan.
+func (s *paymentService) HandleWebhook(header http.Header, body []byte) error {
+	event, err := s.provider.ParseWebhook(header, body)
+	if err != nil {
+		return err
+	}
+
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return errors.New("gagal memulai transaksi")
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	paymentRepo := s.paymentRepo.WithTx(tx)
+	p, err := paymentRepo.LockByOrderID(event.OrderID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if p.Status == hotel.PaymentStatusPaid {
+		tx.Rollback()
+		return nil
+	}
+
+	p.RawPayload = event.Raw
+	if event.ExternalID != "" {
+		p.ExternalID = event.ExternalID
+	}
+
+	switch event.Status {
+	case payment.StatusPaid:
+		if event.Amount < p.Amount {
+			tx.Rollback()
+			return fmt.Errorf("nominal pembayaran %d kurang dari tagihan %d", event.Amount, p.Amount)
+		}
+		now := time.Now()
+		p.Status = hotel.PaymentStatusPaid
+		p.PaidAt = &now
+		reason, err := s.settleBooking(tx, p.BookingID, now)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if reason != "" {
+			p.RefundRequired = true
+			p.Note = reason
+			log.Printf("Payment %s: %s, dana %d perlu direfund", p.OrderID, reason, event.Amount)
+		}
+	case payment.StatusFailed:
+		p.Status = hotel.PaymentStatusFailed
+	case payment.StatusExpired:
+		p.Status = hotel.PaymentStatusExpired
+	}
+
+	if err := paymentRepo.Update(p); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// settleBooking: booking pending → paid (konfirmasi otomatis); status kamar tidak diubah.
+// Booking yang hold-nya sudah expired tetap diproses bila kamarnya masih kosong.
+// Selain itu booking dibiarkan dan alasan refund dikembalikan untuk dicatat di pembayaran.
+func (s *paymentService) settleBooking(tx *gorm.DB, bookingID uint, paidAt time.Time) (string, error) {
+	repo := s.bookingRepo.WithTx(tx)
+	b, err := repo.LockByID(bookingID)
+	if err != nil {
+		return "", err
+	}
+	switch b.Status {
+	case hotel.BookingStatusPending:
+	case hotel.BookingStatusExpired:
+		if _, err := repo.LockRoom(b.RoomID); err != nil {
+			return "", err
+		}
+		if err := ensureRoomFree(repo, b); err != nil {
+			return "booking expired dan kamar sudah dipesan tamu lain", nil
+		}
+	default:
+		return fmt.Sprintf("booking berstatus %s saat pembayaran diterima", b.Status), nil
+	}
+
+	return "", tx.Model(b).Updates(map[string]interface{}{
+		"status":          hotel.BookingStatusPaid,
+		"paid_at":         paidAt,
+		"hold_expires_at": nil,
+	}).Error
+}
+
+func (s *paymentService) ListByBooking(bookingID uint) ([]hotel.Payment, error) {
+	return s.paymentRepo.ListByBooking(bookingID)
+}
+
+func toPaymentResponse(p *hotel.Payment) *hotel.PaymentResponse {
+	return &hotel.PaymentResponse{
+		PaymentID:   p.ID,
+		OrderID:     p.OrderID,
+		Amount:      p.Amount,
+		Status:      p.Status,
+		RedirectURL: p.RedirectURL,
+	}
+}
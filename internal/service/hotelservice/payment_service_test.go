@@ -0,0 +1,312 @@
+// internal/service/hotelservice/payment_service_test.go
+package hotelservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"backend/internal/models/hotel"
+	"backend/internal/payment"
+	"backend/internal/repository/repohotel"
+	"gorm.io/gorm"
+)
+
+const testWebhookSecret = "test-secret"
+
+type fakePaymentRepo struct {
+	byOrder map[string]*hotel.Payment
+	nextID  uint
+	updates int
+}
+
+func newFakePaymentRepo() *fakePaymentRepo {
+	return &fakePaymentRepo{byOrder: map[string]*hotel.Payment{}}
+}
+
+func (r *fakePaymentRepo) Create(p *hotel.Payment) error {
+	r.nextID++
+	p.ID = r.nextID
+	cp := *p
+	r.byOrder[p.OrderID] = &cp
+	return nil
+}
+
+func (r *fakePaymentRepo) FindByOrderID(orderID string) (*hotel.Payment, error) {
+	p, ok := r.byOrder[orderID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	cp := *p
+	return &cp, nil
+}
+
+func (r *fakePaymentRepo) FindPendingByBooking(bookingID uint) (*hotel.Payment, error) {
+	for _, p := range r.byOrder {
+		if p.BookingID == bookingID && p.Status == hotel.PaymentStatusPending {
+			cp := *p
+			return &cp, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakePaymentRepo) ListByBooking(bookingID uint) ([]hotel.Payment, error) {
+	var out []hotel.Payment
+	for _, p := range r.byOrder {
+		if p.BookingID == bookingID {
+			out = append(out, *p)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakePaymentRepo) SumPaidByBooking(bookingID uint) (int64, error) {
+	var total int64
+	for _, p := range r.byOrder {
+		if p.BookingID == bookingID && p.Status == hotel.PaymentStatusPaid {
+			total += p.Amount
+		}
+	}
+	return total, nil
+}
+
+func (r *fakePaymentRepo) LockByOrderID(orderID string) (*hotel.Payment, error) {
+	return r.FindByOrderID(orderID)
+}
+
+func (r *fakePaymentRepo) Update(p *hotel.Payment) error {
+	r.updates++
+	cp := *p
+	r.byOrder[p.OrderID] = &cp
+	return nil
+}
+
+func (r *fakePaymentRepo) WithTx(tx *gorm.DB) repohotel.PaymentRepository { return r }
+
+// fakeBookingRepo: hanya method yang dipakai service yang diuji; method lain panic.
+// overlapping memaksa hasil CountOverlapping, taken & blocks dicek per rentang tanggal.
+type fakeBookingRepo struct {
+	repohotel.BookingRepository
+	bookings    map[uint]*hotel.Booking
+	overlapping int64
+	taken       []dateRange
+	blocks      []dateRange
+	locked      []uint
+}
+
+type dateRange struct{ from, to time.Time }
+
+func (d dateRange) overlaps(from, to time.Time) bool {
+	return d.from.Before(to) && from.Before(d.to)
+}
+
+func (r *fakeBookingRepo) WithTx(tx *gorm.DB) repohotel.BookingRepository { return r }
+
+func (r *fakeBookingRepo) FindByID(id uint) (*hotel.Booking, error) {
+	b, ok := r.bookings[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	cp := *b
+	return &cp, nil
+}
+
+func (r *fakeBookingRepo) LockByID(id uint) (*hotel.Booking, error) {
+	r.locked = append(r.locked, id)
+	return r.FindByID(id)
+}
+
+func (r *fakeBookingRepo) LockRoom(roomID uint) (*hotel.Room, error) {
+	return &hotel.Room{ID: roomID}, nil
+}
+
+func (r *fakeBookingRepo) CountOverlapping(roomID uint, checkIn, checkOut time.Time, excludeID *uint) (int64, error) {
+	count := r.overlapping
+	for _, d := range r.taken {
+		if d.overlaps(checkIn, checkOut) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeBookingRepo) IsBlocked(roomID uint, checkIn, checkOut time.Time) (bool, error) {
+	for _, d := range r.blocks {
+		if d.overlaps(checkIn, checkOut) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type paymentFixture struct {
+	service  PaymentService
+	provider *payment.FakeProvider
+	payments *fakePaymentRepo
+	bookings *fakeBookingRepo
+	db       *stubDB
+}
+
+func newPaymentFixture(t *testing.T, status hotel.BookingStatus) *paymentFixture {
+	t.Helper()
+	db, stub := newStubDB(t)
+	owner := uint(7)
+	checkIn := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	bookings := &fakeBookingRepo{bookings: map[uint]*hotel.Booking{
+		1: {ID: 1, RoomID: 3, UserID: &owner, Status: status, TotalPrice: 500000,
+			CheckIn: checkIn, CheckOut: checkIn.AddDate(0, 0, 2)},
+	}}
+	payments := newFakePaymentRepo()
+	payments.Create(&hotel.Payment{BookingID: 1, Provider: "fake", OrderID: "BK-1-1", Amount: 500000, Status: hotel.PaymentStatusPending})
+
+	provider := payment.NewFakeProvider(testWebhookSecret)
+	return &paymentFixture{
+		service:  NewPaymentService(payments, bookings, provider, db),
+		provider: provider,
+		payments: payments,
+		bookings: bookings,
+		db:       stub,
+	}
+}
+
+// webhook: kirim notifikasi bertanda tangan seperti gateway
+func (f *paymentFixture) webhook(t *testing.T, status string, amount int64) error {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{"order_id": "BK-1-1", "status": status, "amount": amount})
+	header := http.Header{}
+	header.Set("X-Signature", f.provider.Sign(body))
+	return f.service.HandleWebhook(header, body)
+}
+
+func TestHandleWebhookRejectsInvalidSignature(t *testing.T) {
+	f := newPaymentFixture(t, hotel.BookingStatusPending)
+
+	body := []byte(`{"order_id":"BK-1-1","status":"paid","amount":500000}`)
+	header := http.Header{}
+	header.Set("X-Signature", payment.NewFakeProvider("wrong-secret").Sign(body))
+
+	if err := f.service.HandleWebhook(header, body); !errors.Is(err, payment.ErrInvalidSignature) {
+		t.Fatalf("err = %v, want ErrInvalidSignature", err)
+	}
+	if p, _ := f.payments.FindByOrderID("BK-1-1"); p.Status != hotel.PaymentStatusPending {
+		t.Fatalf("payment status = %s, want pending", p.Status)
+	}
+	if len(f.db.Execs("UPDATE")) != 0 {
+		t.Fatalf("unexpected writes: %v", f.db.Execs("UPDATE"))
+	}
+}
+
+func TestHandleWebhookSettlesPendingBooking(t *testing.T) {
+	f := newPaymentFixture(t, hotel.BookingStatusPending)
+
+	if err := f.webhook(t, "paid", 500000); err != nil {
+		t.Fatalf("webhook: %v", err)
+	}
+	p, _ := f.payments.FindByOrderID("BK-1-1")
+	if p.Status != hotel.PaymentStatusPaid || p.PaidAt == nil {
+		t.Fatalf("payment = %+v, want paid with paid_at", p)
+	}
+	if p.RefundRequired {
+		t.Fatal("refund_required set on a settled booking")
+	}
+	if len(f.db.Execs("UPDATE `bookings`")) != 1 {
+		t.Fatalf("booking updates = %v, want 1", f.db.Execs("UPDATE `bookings`"))
+	}
+	// Ketersediaan berbasis tanggal: status fisik kamar tidak disentuh
+	if len(f.db.Execs("UPDATE `rooms`")) != 0 {
+		t.Fatalf("room updates = %v, want none", f.db.Execs("UPDATE `rooms`"))
+	}
+}
+
+func TestHandleWebhookIsIdempotent(t *testing.T) {
+	f := newPaymentFixture(t, hotel.BookingStatusPending)
+
+	if err := f.webhook(t, "paid", 500000); err != nil {
+		t.Fatalf("first webhook: %v", err)
+	}
+	// Booking sudah dilunasi oleh notifikasi pertama
+	f.bookings.bookings[1].Status = hotel.BookingStatusPaid
+	updates := f.payments.updates
+
+	if err := f.webhook(t, "paid", 500000); err != nil {
+		t.Fatalf("second webhook: %v", err)
+	}
+	if f.payments.updates != updates {
+		t.Fatal("duplicate notification updated the payment again")
+	}
+	if n := len(f.db.Execs("UPDATE `bookings`")); n != 1 {
+		t.Fatalf("booking updates = %d, want 1", n)
+	}
+}
+
+func TestHandleWebhookRejectsUnderpayment(t *testing.T) {
+	f := newPaymentFixture(t, hotel.BookingStatusPending)
+
+	if err := f.webhook(t, "paid", 100000); err == nil {
+		t.Fatal("expected error for underpayment")
+	}
+	if p, _ := f.payments.FindByOrderID("BK-1-1"); p.Status != hotel.PaymentStatusPending {
+		t.Fatalf("payment status = %s, want pending", p.Status)
+	}
+}
+
+func TestHandleWebhookFlagsRefundWhenRoomResold(t *testing.T) {
+	f := newPaymentFixture(t, hotel.BookingStatusExpired)
+	f.bookings.overlapping = 1
+
+	if err := f.webhook(t, "paid", 500000); err != nil {
+		t.Fatalf("webhook: %v", err)
+	}
+	p, _ := f.payments.FindByOrderID("BK-1-1")
+	if p.Status != hotel.PaymentStatusPaid || !p.RefundRequired || p.Note == "" {
+		t.Fatalf("payment = %+v, want paid with refund_required and note", p)
+	}
+	if len(f.db.Execs("UPDATE `bookings`")) != 0 {
+		t.Fatal("booking should not be settled when the room was resold")
+	}
+}
+
+func TestHandleWebhookSettlesExpiredBookingWhenRoomFree(t *testing.T) {
+	f := newPaymentFixture(t, hotel.BookingStatusExpired)
+
+	if err := f.webhook(t, "paid", 500000); err != nil {
+		t.Fatalf("webhook: %v", err)
+	}
+	if p, _ := f.payments.FindByOrderID("BK-1-1"); p.RefundRequired {
+		t.Fatal("refund_required set although the booking was settled")
+	}
+	if len(f.db.Execs("UPDATE `bookings`")) != 1 {
+		t.Fatal("expired booking with a free room should be settled")
+	}
+}
+
+func TestHandleWebhookFlagsRefundForCancelledBooking(t *testing.T) {
+	f := newPaymentFixture(t, hotel.BookingStatusCancelled)
+
+	if err := f.webhook(t, "paid", 500000); err != nil {
+		t.Fatalf("webhook: %v", err)
+	}
+	if p, _ := f.payments.FindByOrderID("BK-1-1"); !p.RefundRequired {
+		t.Fatal("payment for a cancelled booking must be flagged for refund")
+	}
+}
+
+func TestCreateForBookingRejectsOtherGuests(t *testing.T) {
+	f := newPaymentFixture(t, hotel.BookingStatusPending)
+	other := uint(99)
+
+	if _, err := f.service.CreateForBooking(context.Background(), 1, &other); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("other guest: err = %v, want ErrRecordNotFound", err)
+	}
+
+	// Booking walk-in tanpa pemilik tidak boleh dibayar lewat akun guest mana pun
+	f.bookings.bookings[1].UserID = nil
+	owner := uint(7)
+	if _, err := f.service.CreateForBooking(context.Background(), 1, &owner); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("ownerless booking: err = %v, want ErrRecordNotFound", err)
+	}
+}
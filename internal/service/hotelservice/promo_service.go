@@ -0,0 +1,267 @@
+// internal/service/hotelservice/promo_service.go
+package hotelservice
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"backend/internal/models/hotel"
+	"backend/internal/repository/repohotel"
+	"gorm.io/gorm"
+)
+
+// PromoInput: data pemesanan yang dipakai untuk validasi & hitung diskon promo
+type PromoInput struct {
+	UserID     *uint
+	Email      string
+	RoomTypeID uint
+	Nights     int
+	Subtotal   int64 // total harga kamar sebelum diskon
+}
+
+type PromoService interface {
+	Create(req hotel.CreatePromoCodeRequest) (*hotel.PromoCode, error)
+	List(activeOnly bool) ([]hotel.PromoCode, error)
+	Update(id uint, req hotel.UpdatePromoCodeRequest) (*hotel.PromoCode, error)
+	Delete(id uint) error
+
+	// Apply: validasi kode & hitung diskon, dipanggil di dalam transaksi booking
+	Apply(tx *gorm.DB, code string, in PromoInput) (*hotel.PromoCode, int64, error)
+	// Redeem: catat pemakaian promo untuk reservasi yang baru dibuat (bookingID = booking pertamanya)
+	Redeem(tx *gorm.DB, promo *hotel.PromoCode, bookingID uint, reservationID *uint, in PromoInput, amount int64) error
+	// Reprice: hitung ulang diskon booking yang sudah memakai promo saat harganya berubah
+	Reprice(code string, oldSubtotal, oldDiscount, newSubtotal int64) int64
+}
+
+type promoService struct {
+	repo         repohotel.PromoRepository
+	roomTypeRepo repohotel.RoomTypeRepository
+}
+
+func NewPromoService(repo repohotel.PromoRepository, roomTypeRepo repohotel.RoomTypeRepository) PromoService {
+	return &promoService{
+		repo:         repo,
+		roomTypeRepo: roomTypeRepo,
+	}
+}
+
+// normalizePromoCode: kode promo tidak case-sensitive
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func validatePromoValue(t hotel.PromoDiscountType, value int64) error {
+	if t == hotel.PromoDiscountPercentage && (value <= 0 || value > 100) {
+		return errors.New("diskon persentase harus antara 1 dan 100")
+	}
+	return nil
+}
+
+func (s *promoService) loadRoomTypes(ids []uint) ([]hotel.RoomType, error) {
+	roomTypes := make([]hotel.RoomType, 0, len(ids))
+	for _, id := range ids {
+		rt, err := s.roomTypeRepo.FindByID(id)
+		if err != nil {
+			return nil, fmt.Errorf("room_type_id %d tidak ditemukan", id)
+		}
+		roomTypes = append(roomTypes, *rt)
+	}
+	return roomTypes, nil
+}
+
+func (s *promoService) Create(req hotel.CreatePromoCodeRequest) (*hotel.PromoCode, error) {
+	code := normalizePromoCode(req.Code)
+	if code == "" {
+		return nil, errors.New("kode promo wajib diisi")
+	}
+	if err := validatePromoValue(req.DiscountType, req.Value); err != nil {
+		return nil, err
+	}
+	from, until, err := parseDateRange(req.ValidFrom, req.ValidUntil)
+	if err != nil {
+		return nil, err
+	}
+	roomTypes, err := s.loadRoomTypes(req.RoomTypeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &hotel.PromoCode{
+		Code:           code,
+		Description:    req.Description,
+		DiscountType:   req.DiscountType,
+		Value:          req.Value,
+		MaxDiscount:    req.MaxDiscount,
+		ValidFrom:      from,
+		ValidUntil:     until,
+		MaxUses:        req.MaxUses,
+		MaxUsesPerUser: req.MaxUsesPerUser,
+		MinNights:      req.MinNights,
+		RoomTypes:      roomTypes,
+		Active:         true,
+	}
+	if err := s.repo.Create(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *promoService) List(activeOnly bool) ([]hotel.PromoCode, error) {
+	return s.repo.List(activeOnly)
+}
+
+func (s *promoService) Update(id uint, req hotel.UpdatePromoCodeRequest) (*hotel.PromoCode, error) {
+	p, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := p.ValidFrom.Format(dateLayout), p.ValidUntil.Format(dateLayout)
+	if req.ValidFrom != nil {
+		start = *req.ValidFrom
+	}
+	if req.ValidUntil != nil {
+		end = *req.ValidUntil
+	}
+	from, until, err := parseDateRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	p.ValidFrom, p.ValidUntil = from, until
+
+	if req.Description != nil {
+		p.Description = *req.Description
+	}
+	if req.Value != nil {
+		if err := validatePromoValue(p.DiscountType, *req.Value); err != nil {
+			return nil, err
+		}
+		p.Value = *req.Value
+	}
+	if req.MaxDiscount != nil {
+		p.MaxDiscount = *req.MaxDiscount
+	}
+	if req.MaxUses != nil {
+		p.MaxUses = *req.MaxUses
+	}
+	if req.MaxUsesPerUser != nil {
+		p.MaxUsesPerUser = *req.MaxUsesPerUser
+	}
+	if req.MinNights != nil {
+		p.MinNights = *req.MinNights
+	}
+	if req.Active != nil {
+		p.Active = *req.Active
+	}
+	if req.RoomTypeIDs != nil {
+		// validasi dulu supaya id yang salah tidak diam-diam terbuang
+		if _, err := s.loadRoomTypes(req.RoomTypeIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Update(p, req.RoomTypeIDs); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *promoService) Delete(id uint) error {
+	return s.repo.Delete(id)
+}
+
+func (s *promoService) Apply(tx *gorm.DB, code string, in PromoInput) (*hotel.PromoCode, int64, error) {
+	repo := s.repo.WithTx(tx)
+	p, err := repo.LockByCode(normalizePromoCode(code))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, errors.New("kode promo tidak ditemukan")
+		}
+		return nil, 0, err
+	}
+	if !p.Active {
+		return nil, 0, errors.New("kode promo tidak aktif")
+	}
+
+	// Masa berlaku inklusif sampai akhir hari valid_until
+	today := time.Now().Format(dateLayout)
+	if today < p.ValidFrom.Format(dateLayout) || today > p.ValidUntil.Format(dateLayout) {
+		return nil, 0, errors.New("kode promo sudah tidak berlaku atau belum dimulai")
+	}
+	if p.MinNights > 0 && in.Nights < p.MinNights {
+		return nil, 0, fmt.Errorf("kode promo hanya berlaku untuk minimal %d malam", p.MinNights)
+	}
+	if len(p.RoomTypes) > 0 {
+		allowed := false
+		for _, rt := range p.RoomTypes {
+			if rt.ID == in.RoomTypeID {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, 0, errors.New("kode promo tidak berlaku untuk tipe kamar ini")
+		}
+	}
+
+	total, perUser, err := repo.CountRedemptions(p.ID, in.UserID, in.Email)
+	if err != nil {
+		return nil, 0, err
+	}
+	if p.MaxUses > 0 && total >= int64(p.MaxUses) {
+		return nil, 0, errors.New("kuota kode promo sudah habis")
+	}
+	if p.MaxUsesPerUser > 0 && perUser >= int64(p.MaxUsesPerUser) {
+		return nil, 0, errors.New("kode promo sudah mencapai batas pemakaian untuk akun ini")
+	}
+
+	return p, promoDiscount(p, in.Subtotal), nil
+}
+
+// promoDiscount: besar diskon, tidak pernah melebihi subtotal
+func promoDiscount(p *hotel.PromoCode, subtotal int64) int64 {
+	var discount int64
+	switch p.DiscountType {
+	case hotel.PromoDiscountPercentage:
+		discount = subtotal * p.Value / 100
+		if p.MaxDiscount > 0 && discount > p.MaxDiscount {
+			discount = p.MaxDiscount
+		}
+	case hotel.PromoDiscountFixed:
+		discount = p.Value
+	}
+	if discount > subtotal {
+		discount = subtotal
+	}
+	return discount
+}
+
+func (s *promoService) Redeem(tx *gorm.DB, promo *hotel.PromoCode, bookingID uint, reservationID *uint, in PromoInput, amount int64) error {
+	return s.repo.WithTx(tx).CreateRedemption(&hotel.PromoRedemption{
+		PromoCodeID:   promo.ID,
+		BookingID:     bookingID,
+		ReservationID: reservationID,
+		UserID:        in.UserID,
+		Email:         in.Email,
+		Amount:        amount,
+	})
+}
+
+// Reprice: diskon persentase diskalakan mengikuti subtotal baru, diskon nominal
+// tetap sebesar yang sudah diberikan. Kuota tidak dicek ulang karena
+// pemakaiannya sudah tercatat saat booking dibuat.
+func (s *promoService) Reprice(code string, oldSubtotal, oldDiscount, newSubtotal int64) int64 {
+	if code == "" || oldDiscount <= 0 {
+		return 0
+	}
+	discount := oldDiscount
+	if p, err := s.repo.FindByCode(code); err == nil && p.DiscountType == hotel.PromoDiscountPercentage && oldSubtotal > 0 {
+		discount = oldDiscount * newSubtotal / oldSubtotal
+	}
+	if discount > newSubtotal {
+		discount = newSubtotal
+	}
+	return discount
+}
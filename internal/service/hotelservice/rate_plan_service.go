@@ -0,0 +1,223 @@
+// internal/service/hotelservice/rate_plan_service.go
+package hotelservice
+
+import (
+	"errors"
+	"time"
+
+	"backend/internal/models/hotel"
+	"backend/internal/repository/repohotel"
+)
+
+type RatePlanService interface {
+	Create(req hotel.CreateRatePlanRequest) (*hotel.RatePlan, error)
+	List(roomTypeID uint) ([]hotel.RatePlan, error)
+	Update(id uint, req hotel.UpdateRatePlanRequest) (*hotel.RatePlan, error)
+	Delete(id uint) error
+	SetOverride(req hotel.SetRateOverrideRequest) (*hotel.RateOverride, error)
+	ListOverrides(roomTypeID uint, from, to time.Time) ([]hotel.RateOverride, error)
+	DeleteOverride(id uint) error
+	Quote(roomTypeID uint, checkIn, checkOut time.Time) ([]hotel.NightlyRate, int64, error)
+}
+
+type ratePlanService struct {
+	repo         repohotel.RatePlanRepository
+	roomTypeRepo repohotel.RoomTypeRepository
+}
+
+func NewRatePlanService(repo repohotel.RatePlanRepository, roomTypeRepo repohotel.RoomTypeRepository) RatePlanService {
+	return &ratePlanService{
+		repo:         repo,
+		roomTypeRepo: roomTypeRepo,
+	}
+}
+
+const dateLayout = "2006-01-02"
+
+func parseDateRange(start, end string) (time.Time, time.Time, error) {
+	from, err := time.Parse(dateLayout, start)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("format start_date tidak valid, gunakan YYYY-MM-DD")
+	}
+	to, err := time.Parse(dateLayout, end)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("format end_date tidak valid, gunakan YYYY-MM-DD")
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, errors.New("end_date tidak boleh sebelum start_date")
+	}
+	return from, to, nil
+}
+
+// isWeekendNight: malam Jumat & Sabtu dikenakan weekend surcharge
+func isWeekendNight(d time.Time) bool {
+	wd := d.Weekday()
+	return wd == time.Friday || wd == time.Saturday
+}
+
+func (s *ratePlanService) Create(req hotel.CreateRatePlanRequest) (*hotel.RatePlan, error) {
+	if _, err := s.roomTypeRepo.FindByID(req.RoomTypeID); err != nil {
+		return nil, errors.New("invalid room_type_id")
+	}
+	from, to, err := parseDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &hotel.RatePlan{
+		RoomTypeID:           req.RoomTypeID,
+		Name:                 req.Name,
+		StartDate:            from,
+		EndDate:              to,
+		Price:                req.Price,
+		WeekendSurcharge:     req.WeekendSurcharge,
+		Priority:             req.Priority,
+		CancellationPolicyID: req.CancellationPolicyID,
+		Active:               true,
+	}
+	if err := s.repo.Create(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *ratePlanService) List(roomTypeID uint) ([]hotel.RatePlan, error) {
+	return s.repo.List(roomTypeID)
+}
+
+func (s *ratePlanService) Update(id uint, req hotel.UpdateRatePlanRequest) (*hotel.RatePlan, error) {
+	p, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := p.StartDate.Format(dateLayout), p.EndDate.Format(dateLayout)
+	if req.StartDate != nil {
+		start = *req.StartDate
+	}
+	if req.EndDate != nil {
+		end = *req.EndDate
+	}
+	from, to, err := parseDateRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	p.StartDate, p.EndDate = from, to
+
+	if req.Name != nil {
+		p.Name = *req.Name
+	}
+	if req.Price != nil {
+		p.Price = *req.Price
+	}
+	if req.WeekendSurcharge != nil {
+		p.WeekendSurcharge = req.WeekendSurcharge
+	}
+	if req.Priority != nil {
+		p.Priority = *req.Priority
+	}
+	if req.CancellationPolicyID != nil {
+		p.CancellationPolicyID = nilIfZero(*req.CancellationPolicyID)
+	}
+	if req.Active != nil {
+		p.Active = *req.Active
+	}
+
+	if err := s.repo.Update(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *ratePlanService) Delete(id uint) error {
+	return s.repo.Delete(id)
+}
+
+func (s *ratePlanService) SetOverride(req hotel.SetRateOverrideRequest) (*hotel.RateOverride, error) {
+	if _, err := s.roomTypeRepo.FindByID(req.RoomTypeID); err != nil {
+		return nil, errors.New("invalid room_type_id")
+	}
+	date, err := time.Parse(dateLayout, req.Date)
+	if err != nil {
+		return nil, errors.New("format date tidak valid, gunakan YYYY-MM-DD")
+	}
+
+	o := &hotel.RateOverride{
+		RoomTypeID: req.RoomTypeID,
+		Date:       date,
+		Price:      req.Price,
+		Note:       req.Note,
+	}
+	if err := s.repo.UpsertOverride(o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func (s *ratePlanService) ListOverrides(roomTypeID uint, from, to time.Time) ([]hotel.RateOverride, error) {
+	return s.repo.ListOverrides(roomTypeID, from, to)
+}
+
+func (s *ratePlanService) DeleteOverride(id uint) error {
+	return s.repo.DeleteOverride(id)
+}
+
+// Quote: rincian harga per malam untuk satu kamar.
+// Urutan: override per malam > rate plan (priority tertinggi) > harga dasar tipe kamar.
+// Weekend surcharge ditambahkan ke harga rate plan / dasar, tidak ke override.
+func (s *ratePlanService) Quote(roomTypeID uint, checkIn, checkOut time.Time) ([]hotel.NightlyRate, int64, error) {
+	if !checkOut.After(checkIn) {
+		return nil, 0, errors.New("check_out harus setelah check_in")
+	}
+
+	rt, err := s.roomTypeRepo.FindByID(roomTypeID)
+	if err != nil {
+		return nil, 0, err
+	}
+	plans, overrides, err := s.repo.FindForRange(roomTypeID, checkIn, checkOut)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	overrideByDate := make(map[string]hotel.RateOverride, len(overrides))
+	for _, o := range overrides {
+		overrideByDate[o.Date.Format(dateLayout)] = o
+	}
+
+	var nights []hotel.NightlyRate
+	var total int64
+	for d := checkIn; d.Before(checkOut); d = d.AddDate(0, 0, 1) {
+		key := d.Format(dateLayout)
+
+		if o, ok := overrideByDate[key]; ok {
+			nights = append(nights, hotel.NightlyRate{Date: key, Price: o.Price, Source: hotel.RateSourceOverride})
+			total += o.Price
+			continue
+		}
+
+		night := hotel.NightlyRate{Date: key, Price: rt.Price, Source: hotel.RateSourceBase}
+		surcharge := rt.WeekendSurcharge
+		for _, p := range plans {
+			// plans sudah urut priority DESC, ambil yang pertama mencakup malam ini
+			if key >= p.StartDate.Format(dateLayout) && key <= p.EndDate.Format(dateLayout) {
+				night.Price = p.Price
+				night.Source = hotel.RateSourcePlan
+				night.RatePlan = p.Name
+				night.RatePlanID = p.ID
+				if p.WeekendSurcharge != nil {
+					surcharge = *p.WeekendSurcharge
+				}
+				break
+			}
+		}
+		if isWeekendNight(d) && surcharge > 0 {
+			night.Surcharge = surcharge
+			night.Price += surcharge
+		}
+
+		nights = append(nights, night)
+		total += night.Price
+	}
+
+	return nights, total, nil
+}
@@ -0,0 +1,265 @@
+// internal/service/hotelservice/report_service.go
+package hotelservice
+
+import (
+	"errors"
+	"time"
+
+	"backend/internal/models/hotel"
+	"backend/internal/repository/repohotel"
+)
+
+// maxReportDays: batas rentang laporan KPI per request
+const maxReportDays = 366
+
+type ReportService interface {
+	KPI(start, end time.Time, groupBy string, roomTypeID uint) (*hotel.KPIReport, error)
+}
+
+type reportService struct {
+	repo repohotel.ReportRepository
+}
+
+func NewReportService(repo repohotel.ReportRepository) ReportService {
+	return &reportService{repo: repo}
+}
+
+// kpiAcc: angka mentah sebelum dihitung menjadi rasio
+type kpiAcc struct {
+	available     int
+	sold          int
+	revenue       int64
+	addOnRevenue  int64
+	arrivals      int
+	leadDays      int
+	cancellations int
+}
+
+func (a *kpiAcc) add(b kpiAcc) {
+	a.available += b.available
+	a.sold += b.sold
+	a.revenue += b.revenue
+	a.addOnRevenue += b.addOnRevenue
+	a.arrivals += b.arrivals
+	a.leadDays += b.leadDays
+	a.cancellations += b.cancellations
+}
+
+func (a kpiAcc) metrics() hotel.KPIMetrics {
+	m := hotel.KPIMetrics{
+		AvailableRoomNights: a.available,
+		RoomNightsSold:      a.sold,
+		Revenue:             a.revenue,
+		AddOnRevenue:        a.addOnRevenue,
+		Arrivals:            a.arrivals,
+		Cancellations:       a.cancellations,
+	}
+	if a.available > 0 {
+		m.OccupancyRate = float64(int(float64(a.sold)/float64(a.available)*10000+0.5)) / 100
+		m.RevPAR = a.revenue / int64(a.available)
+	}
+	if a.sold > 0 {
+		m.ADR = a.revenue / int64(a.sold)
+	}
+	if a.arrivals > 0 {
+		m.AvgLeadTimeDays = float64(int(float64(a.leadDays)/float64(a.arrivals)*10+0.5)) / 10
+	}
+	return m
+}
+
+// periodStart: tanggal awal periode yang memuat d
+func periodStart(d time.Time, groupBy string) time.Time {
+	switch groupBy {
+	case hotel.ReportGroupWeek:
+		offset := (int(d.Weekday()) + 6) % 7 // Senin = 0
+		return d.AddDate(0, 0, -offset)
+	case hotel.ReportGroupMonth:
+		return time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return d
+	}
+}
+
+func (s *reportService) KPI(start, end time.Time, groupBy string, roomTypeID uint) (*hotel.KPIReport, error) {
+	start, end = calendarDay(start), calendarDay(end)
+	if !end.After(start) {
+		return nil, errors.New("end harus setelah start")
+	}
+	if end.Sub(start).Hours()/24 > maxReportDays {
+		return nil, errors.New("rentang maksimal 366 hari")
+	}
+	switch groupBy {
+	case "":
+		groupBy = hotel.ReportGroupMonth
+	case hotel.ReportGroupDay, hotel.ReportGroupWeek, hotel.ReportGroupMonth:
+	default:
+		return nil, errors.New("group_by harus day, week, atau month")
+	}
+
+	roomCounts, err := s.repo.RoomCounts(roomTypeID)
+	if err != nil {
+		return nil, err
+	}
+	stays, err := s.repo.SoldStays(start, end, roomTypeID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, len(stays))
+	for i, st := range stays {
+		ids[i] = st.ID
+	}
+	nightRows, err := s.repo.Nights(ids, start, end)
+	if err != nil {
+		return nil, err
+	}
+	addOnRows, err := s.repo.AddOns(ids)
+	if err != nil {
+		return nil, err
+	}
+	cancellations, err := s.repo.Cancellations(start, end, roomTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Periode & indeks tipe kamar
+	var periods []string
+	periodIdx := map[string]int{}
+	for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+		key := periodStart(d, groupBy).Format(dateLayout)
+		if _, ok := periodIdx[key]; !ok {
+			periodIdx[key] = len(periods)
+			periods = append(periods, key)
+		}
+	}
+	typeIdx := make(map[uint]int, len(roomCounts))
+	for i, rc := range roomCounts {
+		typeIdx[rc.RoomTypeID] = i
+	}
+	acc := make([][]kpiAcc, len(periods))
+	for i := range acc {
+		acc[i] = make([]kpiAcc, len(roomCounts))
+	}
+	bucket := func(d time.Time) int {
+		return periodIdx[periodStart(d, groupBy).Format(dateLayout)]
+	}
+
+	// Room-night tersedia: jumlah kamar saat ini × hari dalam periode
+	for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+		p := bucket(d)
+		for t, rc := range roomCounts {
+			acc[p][t].available += rc.Rooms
+		}
+	}
+
+	// Harga per malam dari rincian booking; booking lama tanpa rincian memakai rata-rata subtotal
+	prices := make(map[uint]map[string]int64, len(stays))
+	for _, n := range nightRows {
+		if prices[n.BookingID] == nil {
+			prices[n.BookingID] = map[string]int64{}
+		}
+		prices[n.BookingID][calendarDay(n.Date).Format(dateLayout)] = n.Price
+	}
+
+	// Porsi setiap booking yang jatuh di rentang laporan, untuk rincian per add-on
+	inRange := make(map[uint][2]int64, len(stays))
+	for _, st := range stays {
+		t, ok := typeIdx[st.RoomTypeID]
+		if !ok {
+			continue
+		}
+		checkIn, checkOut := calendarDay(st.CheckIn), calendarDay(st.CheckOut)
+		stayNights := int64(checkOut.Sub(checkIn).Hours() / 24)
+
+		if !checkIn.Before(start) {
+			p := bucket(checkIn)
+			acc[p][t].arrivals++
+			if lead := int(checkIn.Sub(localDay(st.CreatedAt)).Hours() / 24); lead > 0 {
+				acc[p][t].leadDays += lead
+			}
+		}
+
+		var avgPrice int64
+		if st.TotalNights > 0 {
+			avgPrice = st.Subtotal / int64(st.TotalNights)
+		}
+		from, to := checkIn, checkOut
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		for d := from; d.Before(to); d = d.AddDate(0, 0, 1) {
+			price, ok := prices[st.ID][d.Format(dateLayout)]
+			if !ok {
+				price = avgPrice
+			}
+			// Diskon promo dibagi proporsional ke setiap malam
+			if st.Discount > 0 && st.Subtotal > 0 {
+				price -= price * st.Discount / st.Subtotal
+			}
+			p := bucket(d)
+			acc[p][t].sold++
+			acc[p][t].revenue += price
+
+			// Add-on dibagi rata ke setiap malam menginap, sisa pembagian tidak hilang
+			if st.AddOnTotal > 0 && stayNights > 0 {
+				k := int64(d.Sub(checkIn).Hours() / 24)
+				acc[p][t].addOnRevenue += st.AddOnTotal*(k+1)/stayNights - st.AddOnTotal*k/stayNights
+			}
+		}
+		inRange[st.ID] = [2]int64{int64(to.Sub(from).Hours() / 24), stayNights}
+	}
+
+	for _, c := range cancellations {
+		if t, ok := typeIdx[c.RoomTypeID]; ok {
+			acc[bucket(localDay(c.CancelledAt))][t].cancellations++
+		}
+	}
+
+	report := &hotel.KPIReport{
+		Start:   start.Format(dateLayout),
+		End:     end.Format(dateLayout),
+		GroupBy: groupBy,
+		Periods: make([]hotel.KPIPeriod, len(periods)),
+		AddOns:  []hotel.KPIAddOnRevenue{},
+	}
+	addOnIdx := map[uint]int{}
+	for _, a := range addOnRows {
+		share, ok := inRange[a.BookingID]
+		if !ok || share[1] == 0 || share[0] <= 0 {
+			continue
+		}
+		i, ok := addOnIdx[a.AddOnID]
+		if !ok {
+			i = len(report.AddOns)
+			addOnIdx[a.AddOnID] = i
+			report.AddOns = append(report.AddOns, hotel.KPIAddOnRevenue{AddOnID: a.AddOnID, Name: a.Name})
+		}
+		report.AddOns[i].Bookings++
+		report.AddOns[i].Revenue += a.Total * share[0] / share[1]
+	}
+	summaryByType := make([]kpiAcc, len(roomCounts))
+	var summary kpiAcc
+	for p, key := range periods {
+		var total kpiAcc
+		period := hotel.KPIPeriod{Period: key, RoomTypes: make([]hotel.KPIRoomTypeMetrics, len(roomCounts))}
+		for t, rc := range roomCounts {
+			total.add(acc[p][t])
+			summaryByType[t].add(acc[p][t])
+			period.RoomTypes[t] = hotel.KPIRoomTypeMetrics{RoomTypeID: rc.RoomTypeID, RoomType: rc.RoomType, KPIMetrics: acc[p][t].metrics()}
+		}
+		summary.add(total)
+		period.KPIMetrics = total.metrics()
+		report.Periods[p] = period
+	}
+	report.Summary = hotel.KPIPeriod{
+		Period:     report.Start,
+		KPIMetrics: summary.metrics(),
+		RoomTypes:  make([]hotel.KPIRoomTypeMetrics, len(roomCounts)),
+	}
+	for t, rc := range roomCounts {
+		report.Summary.RoomTypes[t] = hotel.KPIRoomTypeMetrics{RoomTypeID: rc.RoomTypeID, RoomType: rc.RoomType, KPIMetrics: summaryByType[t].metrics()}
+	}
+	return report, nil
+}
@@ -1,15 +1,14 @@
 package hotelservice
 
 import (
-	"backend/internal/models/auth"
 	"backend/internal/models/hotel"
 	"backend/internal/repository/repohotel"
-	"backend/internal/repository/admin" // GUNAKAN YANG SUDAH ADA
+	"backend/internal/repository/guest"
 	"fmt"
 )
 
 type ReviewService interface {
-	Create(input CreateInput, ip string, adminID uint) error
+	Create(input CreateInput, ip string, guestID uint) error
 	GetApproved() ([]hotel.GuestReview, error)
 	GetPending() ([]hotel.GuestReview, error)
 	Approve(id uint) error
@@ -18,7 +17,7 @@ type ReviewService interface {
 
 type service struct {
 	reviewRepo repohotel.ReviewRepository
-	adminRepo  admin.AdminRepository // GUNAKAN YANG SUDAH ADA
+	guestRepo  guest.GuestRepository
 }
 
 type CreateInput struct {
@@ -27,25 +26,22 @@ type CreateInput struct {
 	GuestName string
 }
 
-func NewReviewService(reviewRepo repohotel.ReviewRepository, adminRepo admin.AdminRepository) ReviewService {
+func NewReviewService(reviewRepo repohotel.ReviewRepository, guestRepo guest.GuestRepository) ReviewService {
 	return &service{
 		reviewRepo: reviewRepo,
-		adminRepo:  adminRepo,
+		guestRepo:  guestRepo,
 	}
 }
 
-func (s *service) Create(input CreateInput, ip string, adminID uint) error {
-	// Validasi role via adminRepo
-	adminData, err := s.adminRepo.FindByID(adminID)
+func (s *service) Create(input CreateInput, ip string, guestID uint) error {
+	// Ulasan hanya dari akun tamu yang terdaftar
+	guestData, err := s.guestRepo.FindByID(guestID)
 	if err != nil {
 		return fmt.Errorf("gagal memeriksa user: %v", err)
 	}
-	if adminData == nil {
+	if guestData == nil {
 		return fmt.Errorf("user tidak ditemukan")
 	}
-	if adminData.Role != auth.RoleGuest {
-		return fmt.Errorf("hanya role guest yang dapat mengirim ulasan")
-	}
 
 	limited, err := s.reviewRepo.CheckRateLimit(ip, 3)
 	if err != nil {
@@ -61,7 +57,7 @@ func (s *service) Create(input CreateInput, ip string, adminID uint) error {
 		GuestName:  input.GuestName,
 		IPAddress:  ip,
 		IsApproved: false,
-		AdminID:    adminID,
+		GuestID:    guestID,
 	}
 	return s.reviewRepo.Create(rev)
 }
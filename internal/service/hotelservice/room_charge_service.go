@@ -0,0 +1,76 @@
+// internal/service/hotelservice/room_charge_service.go
+package hotelservice
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"backend/internal/models/hotel"
+	"gorm.io/gorm"
+)
+
+// OutletProduct: produk outlet (cafe/souvenir/buku) yang sudah dikunci di dalam transaksi
+type OutletProduct struct {
+	ID    uint
+	Name  string
+	Price float64
+	Stock int
+	// Save: simpan stok produk setelah dikurangi
+	Save func(stock int) error
+}
+
+// OutletProductLookup: kunci produk outlet berdasarkan id memakai tx yang diberikan
+type OutletProductLookup func(tx *gorm.DB, productID uint) (*OutletProduct, error)
+
+// OutletChargeItem: produk & jumlah yang dibebankan ke kamar
+type OutletChargeItem struct {
+	ProductID uint
+	Quantity  int
+}
+
+// ChargeOutletToRoom: stok produk dikurangi di transaksi yang sama dengan posting folio.
+// source adalah hotel.FolioSourceCafe/FolioSourceSouvenir/FolioSourceBook, label awalan deskripsi baris.
+func ChargeOutletToRoom(db *gorm.DB, folios FolioService, source, label string, target hotel.RoomChargeTarget, items []OutletChargeItem, userID uint, lookup OutletProductLookup) (*hotel.Folio, error) {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, errors.New("gagal memulai transaksi")
+	}
+
+	lines := make([]hotel.FolioLine, 0, len(items))
+	for _, item := range items {
+		product, err := lookup(tx, item.ProductID)
+		if err != nil {
+			tx.Rollback()
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("produk %d tidak ditemukan", item.ProductID)
+			}
+			return nil, err
+		}
+		if product.Stock < item.Quantity {
+			tx.Rollback()
+			return nil, fmt.Errorf("stok %s tidak mencukupi (sisa %d)", product.Name, product.Stock)
+		}
+		if err := product.Save(product.Stock - item.Quantity); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		lines = append(lines, hotel.FolioLine{
+			Description: label + " - " + product.Name,
+			Quantity:    item.Quantity,
+			UnitPrice:   int64(math.Round(product.Price)),
+			Reference:   fmt.Sprintf("%s-product:%d", source, product.ID),
+		})
+	}
+
+	folio, err := folios.PostRoomCharges(tx, target, source, userID, lines)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	return folios.Get(folio.BookingID)
+}
@@ -0,0 +1,62 @@
+// internal/service/hotelservice/room_charge_service_test.go
+package hotelservice
+
+import (
+	"strings"
+	"testing"
+
+	"backend/internal/models/hotel"
+	"gorm.io/gorm"
+)
+
+type fakeFolioService struct {
+	FolioService
+	source string
+	lines  []hotel.FolioLine
+}
+
+func (f *fakeFolioService) PostRoomCharges(tx *gorm.DB, target hotel.RoomChargeTarget, source string, postedBy uint, lines []hotel.FolioLine) (*hotel.Folio, error) {
+	f.source, f.lines = source, lines
+	return &hotel.Folio{BookingID: target.BookingID}, nil
+}
+
+func (f *fakeFolioService) Get(bookingID uint) (*hotel.Folio, error) {
+	return &hotel.Folio{BookingID: bookingID}, nil
+}
+
+func TestChargeOutletToRoomDeductsStock(t *testing.T) {
+	db, _ := newStubDB(t)
+	folios := &fakeFolioService{}
+	stock := map[uint]int{1: 5, 2: 1}
+	lookup := func(tx *gorm.DB, id uint) (*OutletProduct, error) {
+		if _, ok := stock[id]; !ok {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return &OutletProduct{
+			ID: id, Name: "Kopi", Price: 25000.4, Stock: stock[id],
+			Save: func(n int) error { stock[id] = n; return nil },
+		}, nil
+	}
+	target := hotel.RoomChargeTarget{BookingID: 7}
+
+	items := []OutletChargeItem{{ProductID: 1, Quantity: 2}}
+	if _, err := ChargeOutletToRoom(db, folios, hotel.FolioSourceCafe, "Cafe", target, items, 1, lookup); err != nil {
+		t.Fatalf("ChargeOutletToRoom: %v", err)
+	}
+	if stock[1] != 3 {
+		t.Fatalf("stock = %d, want 3", stock[1])
+	}
+	want := hotel.FolioLine{Description: "Cafe - Kopi", Quantity: 2, UnitPrice: 25000, Reference: "cafe-product:1"}
+	if folios.source != hotel.FolioSourceCafe || len(folios.lines) != 1 || folios.lines[0] != want {
+		t.Fatalf("posted %s %+v, want cafe %+v", folios.source, folios.lines, want)
+	}
+
+	items = []OutletChargeItem{{ProductID: 2, Quantity: 3}}
+	if _, err := ChargeOutletToRoom(db, folios, hotel.FolioSourceCafe, "Cafe", target, items, 1, lookup); err == nil || !strings.Contains(err.Error(), "tidak mencukupi") {
+		t.Fatalf("err = %v, want insufficient stock", err)
+	}
+	items = []OutletChargeItem{{ProductID: 9, Quantity: 1}}
+	if _, err := ChargeOutletToRoom(db, folios, hotel.FolioSourceCafe, "Cafe", target, items, 1, lookup); err == nil || !strings.Contains(err.Error(), "tidak ditemukan") {
+		t.Fatalf("err = %v, want product not found", err)
+	}
+}
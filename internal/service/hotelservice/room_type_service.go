@@ -3,6 +3,8 @@ package hotelservice
 
 import (
 	"errors"
+	"regexp"
+	"strings"
 
 	"backend/internal/models/hotel"
 	"backend/internal/repository/repohotel"
@@ -24,15 +26,51 @@ func NewRoomTypeService(repo repohotel.RoomTypeRepository) RoomTypeService {
 	return &roomTypeService{repo: repo}
 }
 
+var roomTypeSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// roomTypeSlug: "Family Suite" → "family-suite"
+func roomTypeSlug(s string) (string, error) {
+	slug := slugify(strings.Join(strings.Fields(s), " "))
+	if !roomTypeSlugPattern.MatchString(slug) {
+		return "", errors.New("type hanya boleh berisi huruf, angka, spasi, atau tanda -")
+	}
+	return slug, nil
+}
+
 func (s *roomTypeService) Create(req hotel.CreateRoomTypeRequest) (*hotel.RoomType, error) {
-	if existing, _ := s.repo.FindByType(req.Type); existing != nil {
+	slug, err := roomTypeSlug(req.Type)
+	if err != nil {
+		return nil, err
+	}
+	if existing, _ := s.repo.FindByType(slug); existing != nil {
 		return nil, errors.New("room type already exists")
 	}
 
+	maxOccupancy := req.MaxOccupancy
+	if maxOccupancy == 0 {
+		maxOccupancy = 2
+	}
+	baseOccupancy := req.BaseOccupancy
+	if baseOccupancy == 0 {
+		baseOccupancy = maxOccupancy
+	}
+	if baseOccupancy > maxOccupancy {
+		return nil, errors.New("base_occupancy tidak boleh melebihi max_occupancy")
+	}
 	rt := &hotel.RoomType{
-		Type:        req.Type,
-		Price:       req.Price,
-		Description: req.Description,
+		Type:                 slug,
+		Name:                 strings.TrimSpace(req.Name),
+		MaxOccupancy:         maxOccupancy,
+		BaseOccupancy:        baseOccupancy,
+		ExtraAdultPrice:      req.ExtraAdultPrice,
+		ExtraChildPrice:      req.ExtraChildPrice,
+		BedConfiguration:     strings.TrimSpace(req.BedConfiguration),
+		Size:                 req.Size,
+		SortOrder:            req.SortOrder,
+		Price:                req.Price,
+		WeekendSurcharge:     req.WeekendSurcharge,
+		Description:          req.Description,
+		CancellationPolicyID: req.CancellationPolicyID,
 	}
 
 	if err := s.repo.Create(rt); err != nil {
@@ -55,12 +93,55 @@ func (s *roomTypeService) Update(id uint, req hotel.UpdateRoomTypeRequest) (*hot
 		return nil, err
 	}
 
+	if req.Type != nil {
+		slug, err := roomTypeSlug(*req.Type)
+		if err != nil {
+			return nil, err
+		}
+		if existing, _ := s.repo.FindByType(slug); existing != nil && existing.ID != rt.ID {
+			return nil, errors.New("room type already exists")
+		}
+		rt.Type = slug
+	}
+	if req.Name != nil {
+		rt.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.MaxOccupancy != nil {
+		rt.MaxOccupancy = *req.MaxOccupancy
+	}
+	if req.BaseOccupancy != nil {
+		rt.BaseOccupancy = *req.BaseOccupancy
+	}
+	if rt.BaseOccupancy > rt.MaxOccupancy {
+		return nil, errors.New("base_occupancy tidak boleh melebihi max_occupancy")
+	}
+	if req.ExtraAdultPrice != nil {
+		rt.ExtraAdultPrice = *req.ExtraAdultPrice
+	}
+	if req.ExtraChildPrice != nil {
+		rt.ExtraChildPrice = *req.ExtraChildPrice
+	}
+	if req.BedConfiguration != nil {
+		rt.BedConfiguration = strings.TrimSpace(*req.BedConfiguration)
+	}
+	if req.Size != nil {
+		rt.Size = *req.Size
+	}
+	if req.SortOrder != nil {
+		rt.SortOrder = *req.SortOrder
+	}
 	if req.Price != nil {
 		rt.Price = *req.Price
 	}
+	if req.WeekendSurcharge != nil {
+		rt.WeekendSurcharge = *req.WeekendSurcharge
+	}
 	if req.Description != nil {
 		rt.Description = *req.Description
 	}
+	if req.CancellationPolicyID != nil {
+		rt.CancellationPolicyID = nilIfZero(*req.CancellationPolicyID)
+	}
 
 	if err := s.repo.Update(rt); err != nil {
 		return nil, err
@@ -0,0 +1,195 @@
+// internal/service/hotelservice/stay_restriction_service.go
+package hotelservice
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"backend/internal/models/hotel"
+	"backend/internal/repository/repohotel"
+)
+
+type StayRestrictionService interface {
+	Create(req hotel.CreateStayRestrictionRequest) (*hotel.StayRestriction, error)
+	List(roomTypeID uint) ([]hotel.StayRestriction, error)
+	Update(id uint, req hotel.UpdateStayRestrictionRequest) (*hotel.StayRestriction, error)
+	Delete(id uint) error
+	// Check: aturan pertama yang menolak tanggal menginap, nil bila boleh dipesan
+	Check(roomTypeID uint, checkIn, checkOut time.Time) (*hotel.StayRestrictionViolation, error)
+}
+
+type stayRestrictionService struct {
+	repo         repohotel.StayRestrictionRepository
+	roomTypeRepo repohotel.RoomTypeRepository
+}
+
+func NewStayRestrictionService(repo repohotel.StayRestrictionRepository, roomTypeRepo repohotel.RoomTypeRepository) StayRestrictionService {
+	return &stayRestrictionService{
+		repo:         repo,
+		roomTypeRepo: roomTypeRepo,
+	}
+}
+
+// validateRestriction: aturan harus membatasi sesuatu dan min tidak melebihi max
+func validateRestriction(r *hotel.StayRestriction) error {
+	if r.MinNights == 0 && r.MaxNights == 0 && !r.ClosedToArrival && !r.ClosedToDeparture && !r.StopSell {
+		return errors.New("isi minimal satu aturan: min_nights, max_nights, closed_to_arrival, closed_to_departure atau stop_sell")
+	}
+	if r.MinNights > 0 && r.MaxNights > 0 && r.MinNights > r.MaxNights {
+		return errors.New("min_nights tidak boleh melebihi max_nights")
+	}
+	return nil
+}
+
+func (s *stayRestrictionService) Create(req hotel.CreateStayRestrictionRequest) (*hotel.StayRestriction, error) {
+	if _, err := s.roomTypeRepo.FindByID(req.RoomTypeID); err != nil {
+		return nil, errors.New("invalid room_type_id")
+	}
+	from, to, err := parseDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &hotel.StayRestriction{
+		RoomTypeID:        req.RoomTypeID,
+		Name:              strings.TrimSpace(req.Name),
+		StartDate:         from,
+		EndDate:           to,
+		MinNights:         req.MinNights,
+		MaxNights:         req.MaxNights,
+		ClosedToArrival:   req.ClosedToArrival,
+		ClosedToDeparture: req.ClosedToDeparture,
+		StopSell:          req.StopSell,
+		Active:            true,
+	}
+	if err := validateRestriction(r); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (s *stayRestrictionService) List(roomTypeID uint) ([]hotel.StayRestriction, error) {
+	return s.repo.List(roomTypeID)
+}
+
+func (s *stayRestrictionService) Update(id uint, req hotel.UpdateStayRestrictionRequest) (*hotel.StayRestriction, error) {
+	r, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := r.StartDate.Format(dateLayout), r.EndDate.Format(dateLayout)
+	if req.StartDate != nil {
+		start = *req.StartDate
+	}
+	if req.EndDate != nil {
+		end = *req.EndDate
+	}
+	from, to, err := parseDateRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	r.StartDate, r.EndDate = from, to
+
+	if req.Name != nil {
+		r.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.MinNights != nil {
+		r.MinNights = *req.MinNights
+	}
+	if req.MaxNights != nil {
+		r.MaxNights = *req.MaxNights
+	}
+	if req.ClosedToArrival != nil {
+		r.ClosedToArrival = *req.ClosedToArrival
+	}
+	if req.ClosedToDeparture != nil {
+		r.ClosedToDeparture = *req.ClosedToDeparture
+	}
+	if req.StopSell != nil {
+		r.StopSell = *req.StopSell
+	}
+	if req.Active != nil {
+		r.Active = *req.Active
+	}
+	if err := validateRestriction(r); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (s *stayRestrictionService) Delete(id uint) error {
+	return s.repo.Delete(id)
+}
+
+// Check: urutan pemeriksaan stop-sell tiap malam > closed to arrival > closed to
+// departure > min/max malam (berdasarkan tanggal check-in).
+func (s *stayRestrictionService) Check(roomTypeID uint, checkIn, checkOut time.Time) (*hotel.StayRestrictionViolation, error) {
+	restrictions, err := s.repo.FindForStay(roomTypeID, checkIn, checkOut)
+	if err != nil {
+		return nil, err
+	}
+	if len(restrictions) == 0 {
+		return nil, nil
+	}
+
+	covers := func(r hotel.StayRestriction, day string) bool {
+		return day >= r.StartDate.Format(dateLayout) && day <= r.EndDate.Format(dateLayout)
+	}
+	violation := func(r hotel.StayRestriction, rule string, d time.Time, msg string) *hotel.StayRestrictionViolation {
+		return &hotel.StayRestrictionViolation{
+			RestrictionID: r.ID,
+			Name:          r.Name,
+			Rule:          rule,
+			Date:          d.Format(dateLayout),
+			Message:       fmt.Sprintf("%s (aturan %q)", msg, r.Name),
+		}
+	}
+
+	for d := checkIn; d.Before(checkOut); d = d.AddDate(0, 0, 1) {
+		for _, r := range restrictions {
+			if r.StopSell && covers(r, d.Format(dateLayout)) {
+				return violation(r, hotel.RestrictionStopSell, d,
+					fmt.Sprintf("kamar tidak dijual untuk malam %s", d.Format("02 Jan 2006"))), nil
+			}
+		}
+	}
+
+	arrival, departure := checkIn.Format(dateLayout), checkOut.Format(dateLayout)
+	nights := int(checkOut.Sub(checkIn).Hours() / 24)
+	for _, r := range restrictions {
+		if r.ClosedToArrival && covers(r, arrival) {
+			return violation(r, hotel.RestrictionClosedToArrival, checkIn,
+				fmt.Sprintf("check-in tidak bisa dilakukan pada %s", checkIn.Format("02 Jan 2006"))), nil
+		}
+	}
+	for _, r := range restrictions {
+		if r.ClosedToDeparture && covers(r, departure) {
+			return violation(r, hotel.RestrictionClosedToDeparture, checkOut,
+				fmt.Sprintf("check-out tidak bisa dilakukan pada %s", checkOut.Format("02 Jan 2006"))), nil
+		}
+	}
+	for _, r := range restrictions {
+		if !covers(r, arrival) {
+			continue
+		}
+		if r.MinNights > 0 && nights < r.MinNights {
+			return violation(r, hotel.RestrictionMinNights, checkIn,
+				fmt.Sprintf("minimal menginap %d malam untuk check-in %s", r.MinNights, checkIn.Format("02 Jan 2006"))), nil
+		}
+		if r.MaxNights > 0 && nights > r.MaxNights {
+			return violation(r, hotel.RestrictionMaxNights, checkIn,
+				fmt.Sprintf("maksimal menginap %d malam untuk check-in %s", r.MaxNights, checkIn.Format("02 Jan 2006"))), nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,105 @@
+// internal/service/hotelservice/stubdb_test.go
+package hotelservice
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// stubDB: koneksi gorm tanpa database sungguhan. Semua perintah tulis dicatat dan
+// dianggap berhasil, semua SELECT mengembalikan hasil kosong. Dipakai bersama
+// repository palsu supaya alur transaksi service bisa diuji tanpa MySQL.
+type stubDB struct {
+	mu     sync.Mutex
+	execs  []string
+	lastID int64
+}
+
+func newStubDB(t *testing.T) (*gorm.DB, *stubDB) {
+	t.Helper()
+	stub := &stubDB{}
+	sqlDB := sql.OpenDB(stubConnector{stub})
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		Logger: logger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("open stub db: %v", err)
+	}
+	return db, stub
+}
+
+// Execs: perintah tulis yang mengandung fragmen (contoh "UPDATE `bookings`")
+func (s *stubDB) Execs(fragment string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []string
+	for _, q := range s.execs {
+		if strings.Contains(q, fragment) {
+			out = append(out, q)
+		}
+	}
+	return out
+}
+
+type stubConnector struct{ db *stubDB }
+
+func (c stubConnector) Connect(context.Context) (driver.Conn, error) { return &stubConn{db: c.db}, nil }
+func (c stubConnector) Driver() driver.Driver                        { return stubDriver{c.db} }
+
+type stubDriver struct{ db *stubDB }
+
+func (d stubDriver) Open(string) (driver.Conn, error) { return &stubConn{db: d.db}, nil }
+
+type stubConn struct{ db *stubDB }
+
+func (c *stubConn) Prepare(query string) (driver.Stmt, error) {
+	return &stubStmt{db: c.db, query: query}, nil
+}
+func (c *stubConn) Close() error              { return nil }
+func (c *stubConn) Begin() (driver.Tx, error) { return stubTx{}, nil }
+
+type stubTx struct{}
+
+func (stubTx) Commit() error   { return nil }
+func (stubTx) Rollback() error { return nil }
+
+type stubStmt struct {
+	db    *stubDB
+	query string
+}
+
+func (s *stubStmt) Close() error  { return nil }
+func (s *stubStmt) NumInput() int { return -1 }
+
+func (s *stubStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.db.execs = append(s.db.execs, s.query)
+	s.db.lastID++
+	return stubResult{id: s.db.lastID}, nil
+}
+
+// stubResult: INSERT butuh LastInsertId agar gorm bisa mengisi primary key
+type stubResult struct{ id int64 }
+
+func (r stubResult) LastInsertId() (int64, error) { return r.id, nil }
+func (r stubResult) RowsAffected() (int64, error) { return 1, nil }
+
+func (s *stubStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return stubRows{}, nil
+}
+
+type stubRows struct{}
+
+func (stubRows) Columns() []string              { return nil }
+func (stubRows) Close() error                   { return nil }
+func (stubRows) Next(dest []driver.Value) error { return io.EOF }
@@ -0,0 +1,237 @@
+// internal/service/hotelservice/waitlist_service.go
+package hotelservice
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"time"
+
+	"backend/internal/models/hotel"
+	"backend/internal/repository/repohotel"
+	"backend/utils"
+	"gorm.io/gorm"
+)
+
+type WaitlistService interface {
+	Join(userID uint, req hotel.JoinWaitlistRequest) (*hotel.WaitlistEntry, error)
+	ListMine(userID uint) ([]hotel.WaitlistEntry, error)
+	Leave(id, userID uint) error
+	List(f repohotel.WaitlistFilter) ([]hotel.WaitlistEntry, error)
+	// ProcessQueue: kedaluwarsakan antrean lama lalu kirim link klaim FIFO
+	// selama masih ada kamar yang belum "dijanjikan" ke antrean lain.
+	// Hanya dijalankan oleh job waitlist-queue. Penawaran bersifat best-effort:
+	// kamar tidak ditahan selama masa klaim dan tetap bisa dipesan tamu lain.
+	ProcessQueue(ctx context.Context) (int, error)
+	// Reserve & Finish dipakai BookingService saat klaim; Finish dengan
+	// bookingID nil mengembalikan entry ke notified agar bisa dicoba lagi
+	Reserve(token string, userID uint) (*hotel.WaitlistEntry, error)
+	Finish(entry *hotel.WaitlistEntry, bookingID *uint) error
+}
+
+type waitlistService struct {
+	repo         repohotel.WaitlistRepository
+	bookingRepo  repohotel.BookingRepository
+	roomTypeRepo repohotel.RoomTypeRepository
+	claimTTL     time.Duration
+	claimURL     string
+	db           *gorm.DB
+}
+
+func NewWaitlistService(repo repohotel.WaitlistRepository, bookingRepo repohotel.BookingRepository, roomTypeRepo repohotel.RoomTypeRepository, db *gorm.DB) WaitlistService {
+	// Lama link klaim berlaku, contoh: 2h, 30m
+	claimTTL, err := time.ParseDuration(os.Getenv("WAITLIST_CLAIM_TTL"))
+	if err != nil || claimTTL <= 0 {
+		claimTTL = 2 * time.Hour
+	}
+	claimURL := os.Getenv("WAITLIST_CLAIM_URL")
+	if claimURL == "" {
+		claimURL = "http://localhost:3000/waitlist/claim"
+	}
+	return &waitlistService{
+		repo:         repo,
+		bookingRepo:  bookingRepo,
+		roomTypeRepo: roomTypeRepo,
+		claimTTL:     claimTTL,
+		claimURL:     claimURL,
+		db:           db,
+	}
+}
+
+func (s *waitlistService) Join(userID uint, req hotel.JoinWaitlistRequest) (*hotel.WaitlistEntry, error) {
+	checkIn, err := time.Parse(dateLayout, req.CheckIn)
+	if err != nil {
+		return nil, errors.New("format check_in tidak valid")
+	}
+	checkOut, err := time.Parse(dateLayout, req.CheckOut)
+	if err != nil {
+		return nil, errors.New("format check_out tidak valid")
+	}
+	if !checkOut.After(checkIn) {
+		return nil, errors.New("check_out harus setelah check_in")
+	}
+	if checkIn.Before(localDay(time.Now())) {
+		return nil, errors.New("check_in tidak boleh di masa lalu")
+	}
+	adults, children, err := resolveOccupancy(req.Guests, req.Adults, req.Children)
+	if err != nil {
+		return nil, err
+	}
+	guests := adults + children
+
+	rt, err := s.roomTypeRepo.FindByID(req.RoomTypeID)
+	if err != nil {
+		return nil, errors.New("tipe kamar tidak ditemukan")
+	}
+	if rt.MaxOccupancy > 0 && guests > rt.MaxOccupancy {
+		return nil, fmt.Errorf("kamar %s maksimal %d tamu", rt.DisplayName(), rt.MaxOccupancy)
+	}
+	avail, err := s.bookingRepo.CheckAvailability(checkIn, checkOut, rt.Type)
+	if err != nil {
+		return nil, err
+	}
+	if len(avail) > 0 && avail[0].AvailableRooms > 0 {
+		return nil, errors.New("kamar masih tersedia, silakan langsung booking")
+	}
+
+	entry := &hotel.WaitlistEntry{
+		UserID:     userID,
+		RoomTypeID: rt.ID,
+		Name:       req.Name,
+		Phone:      req.Phone,
+		Email:      req.Email,
+		CheckIn:    checkIn,
+		CheckOut:   checkOut,
+		Guests:     guests,
+		Adults:     adults,
+		Children:   children,
+		Status:     hotel.WaitlistWaiting,
+	}
+	if err := s.repo.Create(entry); err != nil {
+		return nil, err
+	}
+	entry.RoomType = *rt
+	return entry, nil
+}
+
+func (s *waitlistService) ListMine(userID uint) ([]hotel.WaitlistEntry, error) {
+	return s.repo.List(repohotel.WaitlistFilter{UserID: &userID})
+}
+
+func (s *waitlistService) Leave(id, userID uint) error {
+	e, err := s.repo.FindForUser(id, userID)
+	if err != nil {
+		return err
+	}
+	if e.Status != hotel.WaitlistWaiting && e.Status != hotel.WaitlistNotified {
+		return errors.New("antrean sudah tidak aktif")
+	}
+	e.Status = hotel.WaitlistCancelled
+	e.ClaimToken = nil
+	return s.repo.Update(e)
+}
+
+func (s *waitlistService) List(f repohotel.WaitlistFilter) ([]hotel.WaitlistEntry, error) {
+	return s.repo.List(f)
+}
+
+func (s *waitlistService) ProcessQueue(ctx context.Context) (int, error) {
+	db := s.db.WithContext(ctx)
+	now := time.Now()
+	if _, err := s.repo.WithTx(db).ExpireStale(now, localDay(now)); err != nil {
+		return 0, err
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return 0, errors.New("gagal memulai transaksi")
+	}
+	txRepo := s.repo.WithTx(tx)
+
+	// Entry dikunci agar dua proses antrean tidak mengirim link ganda
+	waiting, err := txRepo.LockWaiting(200)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	var notified []hotel.WaitlistEntry
+	for i := range waiting {
+		e := &waiting[i]
+		avail, err := s.bookingRepo.WithTx(tx).CheckAvailability(e.CheckIn, e.CheckOut, e.RoomType.Type)
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+		if len(avail) == 0 {
+			continue
+		}
+		outstanding, err := txRepo.CountOutstanding(e.RoomTypeID, e.CheckIn, e.CheckOut)
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+		if int64(avail[0].AvailableRooms) <= outstanding {
+			continue
+		}
+
+		token, err := newClaimToken()
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+		expires := now.Add(s.claimTTL)
+		e.Status = hotel.WaitlistNotified
+		e.ClaimToken = &token
+		e.NotifiedAt = &now
+		e.ClaimExpiresAt = &expires
+		if err := txRepo.Update(e); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+		notified = append(notified, *e)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return 0, err
+	}
+
+	for _, e := range notified {
+		link := s.claimURL + "?token=" + url.QueryEscape(*e.ClaimToken)
+		if err := utils.SendWaitlistOfferEmail(e.Email, e.Name, e.RoomType.DisplayName(),
+			e.CheckIn.Format(dateLayout), e.CheckOut.Format(dateLayout), link, *e.ClaimExpiresAt); err != nil {
+			log.Printf("Waitlist: gagal mengirim email ke %s: %v", e.Email, err)
+		}
+	}
+	return len(notified), nil
+}
+
+func (s *waitlistService) Reserve(token string, userID uint) (*hotel.WaitlistEntry, error) {
+	e, err := s.repo.Reserve(token, userID, time.Now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("link klaim tidak valid atau sudah kedaluwarsa")
+	}
+	return e, err
+}
+
+func (s *waitlistService) Finish(e *hotel.WaitlistEntry, bookingID *uint) error {
+	if bookingID == nil {
+		e.Status = hotel.WaitlistNotified
+	} else {
+		e.BookingID = bookingID
+	}
+	return s.repo.Update(e)
+}
+
+func newClaimToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
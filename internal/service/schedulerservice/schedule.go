@@ -0,0 +1,147 @@
+// internal/service/schedulerservice/schedule.go
+package schedulerservice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule: kapan job berikutnya dijalankan
+type Schedule interface {
+	Next(after time.Time) time.Time
+}
+
+// ParseSchedule menerima ekspresi cron 5 kolom (menit jam tanggal bulan hari),
+// contoh "*/5 * * * *" atau "0 2 * * 1-5", serta "@every 15m", "@hourly", "@daily".
+// Jadwal dihitung dalam UTC supaya sama di semua instance.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	switch spec {
+	case "@hourly":
+		spec = "0 * * * *"
+	case "@daily", "@midnight":
+		spec = "0 0 * * *"
+	}
+	if strings.HasPrefix(spec, "@every ") {
+		d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil || d < time.Second {
+			return nil, fmt.Errorf("interval jadwal %q tidak valid", spec)
+		}
+		return everySchedule{interval: d}, nil
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("jadwal %q harus 5 kolom: menit jam tanggal bulan hari", spec)
+	}
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	var c cronSchedule
+	sets := []*[]bool{&c.minute, &c.hour, &c.dom, &c.month, &c.dow}
+	for i, f := range fields {
+		set, err := parseCronField(f, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("jadwal %q: %v", spec, err)
+		}
+		*sets[i] = set
+	}
+	c.dow[0] = c.dow[0] || c.dow[7] // 0 dan 7 sama-sama Minggu
+	c.domAny, c.dowAny = fields[2] == "*", fields[4] == "*"
+	return c, nil
+}
+
+// everySchedule: interval tetap. Slot dibulatkan ke kelipatan interval (bukan sejak
+// instance start) supaya semua instance menghitung slot yang sama
+type everySchedule struct {
+	interval time.Duration
+}
+
+func (s everySchedule) Next(after time.Time) time.Time {
+	return after.UTC().Truncate(s.interval).Add(s.interval)
+}
+
+type cronSchedule struct {
+	minute, hour, dom, month, dow []bool
+	domAny, dowAny                bool
+}
+
+func (c cronSchedule) dayMatches(t time.Time) bool {
+	dom, dow := c.dom[t.Day()], c.dow[int(t.Weekday())]
+	// Aturan cron: bila tanggal & hari sama-sama dibatasi, cukup salah satu yang cocok
+	if !c.domAny && !c.dowAny {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+func (c cronSchedule) Next(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	// Batas pencarian 5 tahun untuk jadwal yang tidak mungkin (contoh 30 Februari)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if !c.month[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.hour[t.Hour()] {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if !c.minute[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// parseCronField: "*", "5", "1-5", "*/15", "10-30/5" dan daftar dipisah koma
+func parseCronField(field string, min, max int) ([]bool, error) {
+	set := make([]bool, max+1)
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("step %q tidak valid", part)
+			}
+			step = n
+			part = part[:i]
+		}
+
+		lo, hi := min, max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			a, errA := strconv.Atoi(bounds[0])
+			b, errB := strconv.Atoi(bounds[1])
+			if errA != nil || errB != nil {
+				return nil, fmt.Errorf("rentang %q tidak valid", part)
+			}
+			lo, hi = a, b
+		default:
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, fmt.Errorf("nilai %q tidak valid", part)
+			}
+			lo, hi = n, n
+			if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("nilai %q di luar rentang %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
@@ -0,0 +1,308 @@
+// internal/service/schedulerservice/scheduler_service.go
+package schedulerservice
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"backend/internal/models/scheduler"
+	"backend/internal/repository/reposcheduler"
+	"gorm.io/gorm"
+)
+
+// ErrJobNotFound: nama job tidak terdaftar
+var ErrJobNotFound = errors.New("job tidak ditemukan")
+
+// ErrJobLocked: job sedang dijalankan (oleh instance ini atau replica lain)
+var ErrJobLocked = errors.New("job sedang berjalan, coba lagi setelah selesai")
+
+// defaultLeaseTTL: lama lease sekali ambil/perpanjang. Selama job berjalan lease
+// diperpanjang tiap TTL/3, jadi lease hanya kedaluwarsa bila instance pemegangnya mati.
+const defaultLeaseTTL = time.Minute
+
+// Job: pekerjaan latar belakang. Run mengembalikan ringkasan hasil untuk riwayat;
+// ctx dibatalkan saat Timeout habis atau server dimatikan.
+type Job struct {
+	Name     string
+	Schedule string
+	Timeout  time.Duration // default 10 menit
+	Run      func(ctx context.Context) (string, error)
+}
+
+type Scheduler interface {
+	Register(job Job) error
+	// Start: jalankan semua job terdaftar sesuai jadwal sampai Stop dipanggil
+	Start()
+	// Stop: hentikan penjadwalan lalu tunggu job yang sedang berjalan, maksimal sampai ctx habis
+	Stop(ctx context.Context) error
+
+	Jobs() ([]scheduler.JobInfo, error)
+	// Trigger: jalankan job sekarang di latar belakang, run yang dibuat langsung dikembalikan
+	Trigger(name string, userID uint) (*scheduler.JobRun, error)
+	ListRuns(f reposcheduler.RunFilter) ([]scheduler.JobRun, int64, error)
+}
+
+type registeredJob struct {
+	Job
+	schedule Schedule
+}
+
+type schedulerService struct {
+	repo     reposcheduler.SchedulerRepository
+	owner    string
+	leaseTTL time.Duration
+	jobs     map[string]*registeredJob
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func NewScheduler(repo reposcheduler.SchedulerRepository) Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &schedulerService{
+		repo:     repo,
+		owner:    instanceID(),
+		leaseTTL: defaultLeaseTTL,
+		jobs:     make(map[string]*registeredJob),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// instanceID: identitas instance pemegang lease, contoh "api-7f9c-1234-a1b2c3"
+func instanceID() string {
+	host, _ := os.Hostname()
+	buf := make([]byte, 3)
+	rand.Read(buf)
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(buf))
+}
+
+func (s *schedulerService) Register(job Job) error {
+	if job.Name == "" || job.Run == nil {
+		return errors.New("job wajib punya nama dan fungsi Run")
+	}
+	if _, ok := s.jobs[job.Name]; ok {
+		return fmt.Errorf("job %s sudah terdaftar", job.Name)
+	}
+	schedule, err := ParseSchedule(job.Schedule)
+	if err != nil {
+		return err
+	}
+	if job.Timeout <= 0 {
+		job.Timeout = 10 * time.Minute
+	}
+	s.jobs[job.Name] = &registeredJob{Job: job, schedule: schedule}
+	return nil
+}
+
+func (s *schedulerService) Start() {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(job)
+	}
+	log.Printf("Scheduler: %d job dimulai (instance %s)", len(s.jobs), s.owner)
+}
+
+func (s *schedulerService) Stop(ctx context.Context) error {
+	s.cancel()
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		log.Println("Scheduler: semua job berhenti")
+		return nil
+	case <-ctx.Done():
+		return errors.New("scheduler: job masih berjalan saat batas waktu shutdown habis")
+	}
+}
+
+// loop: tunggu slot jadwal berikutnya lalu jalankan job bila lease didapat
+func (s *schedulerService) loop(job *registeredJob) {
+	defer s.wg.Done()
+	for {
+		next := job.schedule.Next(time.Now())
+		if next.IsZero() {
+			log.Printf("Scheduler: job %s tidak punya jadwal berikutnya", job.Name)
+			return
+		}
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		run, err := s.begin(job, scheduler.TriggerSchedule, nil, &next)
+		if err != nil {
+			// Lease dipegang replica lain = normal, cukup lewati slot ini
+			if !errors.Is(err, ErrJobLocked) {
+				log.Printf("Scheduler: %s: %v", job.Name, err)
+			}
+			continue
+		}
+		s.execute(job, run)
+	}
+}
+
+// begin: ambil lease lalu catat run berstatus running
+func (s *schedulerService) begin(job *registeredJob, trigger string, userID *uint, slot *time.Time) (*scheduler.JobRun, error) {
+	now := time.Now().UTC()
+	ok, err := s.repo.AcquireLease(job.Name, s.owner, now.Add(s.leaseTTL), slot)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrJobLocked
+	}
+	if err := s.repo.AbandonRuns(job.Name, now); err != nil {
+		log.Printf("Scheduler: %s: %v", job.Name, err)
+	}
+
+	run := &scheduler.JobRun{
+		Job:         job.Name,
+		Trigger:     trigger,
+		TriggeredBy: userID,
+		Owner:       s.owner,
+		Status:      scheduler.RunRunning,
+		StartedAt:   now,
+	}
+	if err := s.repo.CreateRun(run); err != nil {
+		s.releaseLease(job.Name)
+		return nil, err
+	}
+	return run, nil
+}
+
+// execute: jalankan job dengan timeout, simpan hasil ke riwayat lalu lepas lease
+func (s *schedulerService) execute(job *registeredJob, run *scheduler.JobRun) {
+	ctx, cancel := context.WithTimeout(s.ctx, job.Timeout)
+	defer cancel()
+
+	// Lease tetap dipegang sampai Run benar-benar kembali, termasuk bila Run
+	// terlambat menghormati ctx, supaya replica lain tidak menjalankan job yang sama
+	stopRenew := make(chan struct{})
+	renewDone := make(chan struct{})
+	go func() {
+		defer close(renewDone)
+		s.renewLease(job.Name, cancel, stopRenew)
+	}()
+
+	msg, err := func() (msg string, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		return job.Run(ctx)
+	}()
+	close(stopRenew)
+	<-renewDone
+
+	finished := time.Now().UTC()
+	run.FinishedAt = &finished
+	run.DurationMs = finished.Sub(run.StartedAt).Milliseconds()
+	run.Message = msg
+	run.Status = scheduler.RunSuccess
+	if err != nil {
+		run.Status = scheduler.RunFailed
+		run.Error = err.Error()
+		log.Printf("Scheduler: %s gagal: %v", job.Name, err)
+	} else if msg != "" {
+		log.Printf("Scheduler: %s: %s", job.Name, msg)
+	}
+
+	if err := s.repo.FinishRun(run); err != nil {
+		log.Printf("Scheduler: %s: gagal menyimpan riwayat: %v", job.Name, err)
+	}
+	s.releaseLease(job.Name)
+}
+
+// renewLease: perpanjang lease berkala sampai stop ditutup. Bila lease ternyata sudah
+// diambil instance lain, ctx job dibatalkan agar job berhenti secepatnya.
+func (s *schedulerService) renewLease(name string, cancel context.CancelFunc, stop <-chan struct{}) {
+	ticker := time.NewTicker(s.leaseTTL / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		ok, err := s.repo.RenewLease(name, s.owner, time.Now().UTC().Add(s.leaseTTL))
+		if err != nil {
+			log.Printf("Scheduler: %s: gagal memperpanjang lease: %v", name, err)
+			continue
+		}
+		if !ok {
+			log.Printf("Scheduler: %s: lease hilang, job dibatalkan", name)
+			cancel()
+			return
+		}
+	}
+}
+
+func (s *schedulerService) releaseLease(name string) {
+	if err := s.repo.ReleaseLease(name, s.owner); err != nil {
+		log.Printf("Scheduler: %s: gagal melepas lease: %v", name, err)
+	}
+}
+
+func (s *schedulerService) Jobs() ([]scheduler.JobInfo, error) {
+	now := time.Now()
+	infos := make([]scheduler.JobInfo, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		info := scheduler.JobInfo{
+			Name:     job.Name,
+			Schedule: job.Schedule,
+			Timeout:  job.Timeout.String(),
+			NextRun:  job.schedule.Next(now),
+		}
+		last, err := s.repo.LastRun(job.Name)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		info.LastRun = last
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos, nil
+}
+
+func (s *schedulerService) Trigger(name string, userID uint) (*scheduler.JobRun, error) {
+	job, ok := s.jobs[name]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	if s.ctx.Err() != nil {
+		return nil, errors.New("scheduler sedang dimatikan")
+	}
+
+	run, err := s.begin(job, scheduler.TriggerManual, &userID, nil)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := *run
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.execute(job, run)
+	}()
+	return &snapshot, nil
+}
+
+func (s *schedulerService) ListRuns(f reposcheduler.RunFilter) ([]scheduler.JobRun, int64, error) {
+	return s.repo.ListRuns(f)
+}
@@ -0,0 +1,114 @@
+// internal/service/schedulerservice/scheduler_service_test.go
+package schedulerservice
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"backend/internal/models/scheduler"
+	"backend/internal/repository/reposcheduler"
+)
+
+// fakeSchedulerRepo: lease di memori; renewOK=false mensimulasikan lease diambil instance lain
+type fakeSchedulerRepo struct {
+	reposcheduler.SchedulerRepository
+	mu       sync.Mutex
+	renewOK  bool
+	renewals int
+	released bool
+	finished *scheduler.JobRun
+}
+
+func (r *fakeSchedulerRepo) AcquireLease(name, owner string, until time.Time, slot *time.Time) (bool, error) {
+	return true, nil
+}
+
+func (r *fakeSchedulerRepo) RenewLease(name, owner string, until time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.renewals++
+	return r.renewOK, nil
+}
+
+func (r *fakeSchedulerRepo) ReleaseLease(name, owner string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.released = true
+	return nil
+}
+
+func (r *fakeSchedulerRepo) AbandonRuns(name string, now time.Time) error { return nil }
+func (r *fakeSchedulerRepo) CreateRun(run *scheduler.JobRun) error        { return nil }
+
+func (r *fakeSchedulerRepo) FinishRun(run *scheduler.JobRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *run
+	r.finished = &cp
+	return nil
+}
+
+func newTestScheduler(repo *fakeSchedulerRepo) *schedulerService {
+	s := NewScheduler(repo).(*schedulerService)
+	s.leaseTTL = 30 * time.Millisecond
+	return s
+}
+
+func TestExecuteRenewsLeaseWhileRunning(t *testing.T) {
+	repo := &fakeSchedulerRepo{renewOK: true}
+	s := newTestScheduler(repo)
+	job := &registeredJob{Job: Job{
+		Name:    "slow",
+		Timeout: time.Second,
+		Run: func(ctx context.Context) (string, error) {
+			time.Sleep(100 * time.Millisecond)
+			return "selesai", ctx.Err()
+		},
+	}}
+
+	run, err := s.begin(job, scheduler.TriggerManual, nil, nil)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	s.execute(job, run)
+
+	if repo.renewals < 2 {
+		t.Fatalf("renewals = %d, want lease renewed during the run", repo.renewals)
+	}
+	if !repo.released || repo.finished == nil || repo.finished.Status != scheduler.RunSuccess {
+		t.Fatalf("run = %+v released=%v, want success and released lease", repo.finished, repo.released)
+	}
+}
+
+func TestExecuteCancelsJobWhenLeaseLost(t *testing.T) {
+	repo := &fakeSchedulerRepo{renewOK: false}
+	s := newTestScheduler(repo)
+	job := &registeredJob{Job: Job{
+		Name:    "stuck",
+		Timeout: time.Minute,
+		Run: func(ctx context.Context) (string, error) {
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-time.After(5 * time.Second):
+				return "", nil
+			}
+		},
+	}}
+
+	run, err := s.begin(job, scheduler.TriggerManual, nil, nil)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	start := time.Now()
+	s.execute(job, run)
+
+	if time.Since(start) > time.Second {
+		t.Fatal("job kept running after the lease was lost")
+	}
+	if repo.finished == nil || repo.finished.Status != scheduler.RunFailed {
+		t.Fatalf("run = %+v, want failed", repo.finished)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	// HAPUS INI: "backend/internal/config"
 	"backend/internal/models/auth"
 	"backend/internal/repository/admin"
+	"backend/internal/repository/guest"
 	"errors"
 	"time"
 
@@ -29,18 +30,19 @@ type LoginResponse struct {
 type AdminService interface {
 	Register(req *RegisterRequest) (*auth.AdminResponse, error)
 	Login(email, password string) (*LoginResponse, error)
-	GetProfile(id uint) (*auth.AdminResponse, error)
+	GetProfile(id uint, role auth.Role) (*auth.AdminResponse, error)
 	ApproveUser(id uint, requesterRole auth.Role) error
 	GetPendingAdmins(requesterRole auth.Role) ([]auth.AdminResponse, error)
 }
 
 type adminService struct {
 	repo      admin.AdminRepository
+	guestRepo guest.GuestRepository
 	jwtSecret string
 }
 
-func NewAdminService(repo admin.AdminRepository, jwtSecret string) AdminService {
-	return &adminService{repo, jwtSecret}
+func NewAdminService(repo admin.AdminRepository, guestRepo guest.GuestRepository, jwtSecret string) AdminService {
+	return &adminService{repo, guestRepo, jwtSecret}
 }
 
 func (s *adminService) Register(req *RegisterRequest) (*auth.AdminResponse, error) {
@@ -51,21 +53,36 @@ func (s *adminService) Register(req *RegisterRequest) (*auth.AdminResponse, erro
 	if existing, _ := s.repo.FindByEmail(req.Email); existing != nil {
 		return nil, errors.New("email sudah digunakan")
 	}
+	if existing, _ := s.guestRepo.FindByEmail(req.Email); existing != nil {
+		return nil, errors.New("email sudah digunakan")
+	}
 
 	hashed, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
 		return nil, err
 	}
 
-	isApproved := req.Role == auth.RoleGuest
+	// Tamu disimpan di tabel guests, bukan admins
+	if req.Role == auth.RoleGuest {
+		g := &auth.Guest{
+			FullName:    req.FullName,
+			Email:       req.Email,
+			PhoneNumber: req.PhoneNumber,
+			Password:    string(hashed),
+		}
+		if err := s.guestRepo.Create(g); err != nil {
+			return nil, err
+		}
+		return guestToResponse(g), nil
+	}
 
+	// Admin baru menunggu approval superadmin
 	admin := &auth.Admin{
 		FullName:    req.FullName,
 		Email:       req.Email,
 		PhoneNumber: req.PhoneNumber,
 		Password:    string(hashed),
 		Role:        req.Role,
-		IsApproved:  isApproved,
 	}
 
 	if err := s.repo.Create(admin); err != nil {
@@ -77,15 +94,22 @@ func (s *adminService) Register(req *RegisterRequest) (*auth.AdminResponse, erro
 
 func (s *adminService) Login(email, password string) (*LoginResponse, error) {
 	admin, err := s.repo.FindByEmail(email)
-	if err != nil || admin == nil {
+	if err != nil {
 		return nil, errors.New("email atau password salah")
 	}
+	if admin == nil {
+		return s.loginGuest(email, password)
+	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(admin.Password), []byte(password)); err != nil {
 		return nil, errors.New("email atau password salah")
 	}
 
-	if admin.Role != auth.RoleGuest && admin.Role != auth.RoleSuperAdmin && !admin.IsApproved {
+	// Sisa baris tamu di admins (belum dimigrasi) tidak boleh login: id-nya bukan id guests
+	if admin.Role == auth.RoleGuest {
+		return nil, errors.New("akun tamu lama belum dimigrasi, hubungi admin hotel")
+	}
+	if admin.Role != auth.RoleSuperAdmin && !admin.IsApproved {
 		return nil, errors.New("akun Anda belum disetujui oleh Superadmin")
 	}
 
@@ -100,7 +124,39 @@ func (s *adminService) Login(email, password string) (*LoginResponse, error) {
 	}, nil
 }
 
-func (s *adminService) GetProfile(id uint) (*auth.AdminResponse, error) {
+// loginGuest: email tidak ada di admins, coba akun tamu
+func (s *adminService) loginGuest(email, password string) (*LoginResponse, error) {
+	g, err := s.guestRepo.FindByEmail(email)
+	if err != nil || g == nil {
+		return nil, errors.New("email atau password salah")
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(g.Password), []byte(password)); err != nil {
+		return nil, errors.New("email atau password salah")
+	}
+
+	token, err := s.generateToken(g.ID, auth.RoleGuest)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	g.LastLoginAt = &now
+	s.guestRepo.Update(g)
+
+	return &LoginResponse{
+		Token: token,
+		User:  guestToResponse(g),
+	}, nil
+}
+
+func (s *adminService) GetProfile(id uint, role auth.Role) (*auth.AdminResponse, error) {
+	if role == auth.RoleGuest {
+		g, err := s.guestRepo.FindByID(id)
+		if err != nil || g == nil {
+			return nil, errors.New("guest tidak ditemukan")
+		}
+		return guestToResponse(g), nil
+	}
 	admin, err := s.repo.FindByID(id)
 	if err != nil || admin == nil {
 		return nil, errors.New("admin tidak ditemukan")
@@ -149,4 +205,15 @@ func toResponse(a *auth.Admin) *auth.AdminResponse {
 		Role:        a.Role,
 		IsApproved:  a.IsApproved,
 	}
-}
\ No newline at end of file
+}
+
+func guestToResponse(g *auth.Guest) *auth.AdminResponse {
+	return &auth.AdminResponse{
+		ID:          g.ID,
+		FullName:    g.FullName,
+		Email:       g.Email,
+		PhoneNumber: g.PhoneNumber,
+		Role:        auth.RoleGuest,
+		IsApproved:  true,
+	}
+}
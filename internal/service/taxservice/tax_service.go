@@ -0,0 +1,217 @@
+// internal/service/taxservice/tax_service.go
+package taxservice
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"backend/internal/models/tax"
+	"backend/internal/repository/repotax"
+	"gorm.io/gorm"
+)
+
+// maxReportDays: batas rentang laporan pajak per request
+const maxReportDays = 366
+
+type TaxService interface {
+	Create(req tax.CreateTaxRateRequest) (*tax.TaxRate, error)
+	List(unit string) ([]tax.TaxRate, error)
+	Update(id uint, req tax.UpdateTaxRateRequest) (*tax.TaxRate, error)
+	Delete(id uint) error
+
+	// Calculate: pajak atas amount (harga jual setelah diskon) sesuai konfigurasi business unit.
+	// Tanpa tarif aktif, harga dianggap bebas pajak.
+	Calculate(unit string, amount int64) (*tax.Breakdown, error)
+	// Record: simpan baris pajak milik satu transaksi, menggantikan yang lama
+	Record(tx *gorm.DB, sourceType string, sourceID uint, lines []tax.TaxLine) error
+	Report(start, end time.Time, unit string) (*tax.Report, error)
+}
+
+type taxService struct {
+	repo repotax.TaxRepository
+}
+
+func NewTaxService(repo repotax.TaxRepository) TaxService {
+	return &taxService{repo: repo}
+}
+
+func validUnit(unit string) bool {
+	switch unit {
+	case tax.UnitHotel, tax.UnitCafe, tax.UnitSouvenir, tax.UnitBook:
+		return true
+	}
+	return false
+}
+
+func (s *taxService) Create(req tax.CreateTaxRateRequest) (*tax.TaxRate, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if code == "" {
+		return nil, errors.New("kode pajak wajib diisi")
+	}
+	rate := &tax.TaxRate{
+		BusinessUnit: req.BusinessUnit,
+		Code:         code,
+		Name:         strings.TrimSpace(req.Name),
+		Rate:         req.Rate,
+		Inclusive:    req.Inclusive,
+		Compound:     req.Compound,
+		Priority:     req.Priority,
+		Active:       true,
+	}
+	if err := s.repo.Create(rate); err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+func (s *taxService) List(unit string) ([]tax.TaxRate, error) {
+	if unit != "" && !validUnit(unit) {
+		return nil, errors.New("business_unit harus hotel, cafe, souvenir, atau book")
+	}
+	return s.repo.List(unit, false)
+}
+
+// Update: hanya berlaku untuk transaksi berikutnya, baris pajak yang sudah tersimpan tidak berubah
+func (s *taxService) Update(id uint, req tax.UpdateTaxRateRequest) (*tax.TaxRate, error) {
+	rate, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Code != nil {
+		code := strings.ToUpper(strings.TrimSpace(*req.Code))
+		if code == "" {
+			return nil, errors.New("kode pajak wajib diisi")
+		}
+		rate.Code = code
+	}
+	if req.Name != nil {
+		rate.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Rate != nil {
+		rate.Rate = *req.Rate
+	}
+	if req.Inclusive != nil {
+		rate.Inclusive = *req.Inclusive
+	}
+	if req.Compound != nil {
+		rate.Compound = *req.Compound
+	}
+	if req.Priority != nil {
+		rate.Priority = *req.Priority
+	}
+	if req.Active != nil {
+		rate.Active = *req.Active
+	}
+	if err := s.repo.Update(rate); err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+func (s *taxService) Delete(id uint) error {
+	return s.repo.Delete(id)
+}
+
+func (s *taxService) Calculate(unit string, amount int64) (*tax.Breakdown, error) {
+	if !validUnit(unit) {
+		return nil, errors.New("business unit pajak tidak dikenal: " + unit)
+	}
+	rates, err := s.repo.List(unit, true)
+	if err != nil {
+		return nil, err
+	}
+	return Compute(unit, amount, rates), nil
+}
+
+// Compute: rincian pajak atas amount untuk tarif yang sudah urut prioritas.
+// Setiap komponen dinyatakan sebagai faktor terhadap harga dasar (net):
+// biasa = tarif, compound = tarif × (1 + faktor komponen sebelumnya).
+// Komponen inclusive dikeluarkan dulu dari amount untuk mendapatkan net,
+// komponen exclusive ditambahkan di atas amount.
+func Compute(unit string, amount int64, rates []tax.TaxRate) *tax.Breakdown {
+	b := &tax.Breakdown{Net: amount, Gross: amount, Lines: []tax.TaxLine{}}
+	if amount <= 0 || len(rates) == 0 {
+		return b
+	}
+
+	factors := make([]float64, len(rates))
+	prior := make([]float64, len(rates))
+	var cumulative, inclusive float64
+	for i, r := range rates {
+		f := r.Rate / 100
+		prior[i] = cumulative
+		if r.Compound {
+			f *= 1 + cumulative
+		}
+		factors[i] = f
+		cumulative += f
+		if r.Inclusive {
+			inclusive += f
+		}
+	}
+
+	net := float64(amount) / (1 + inclusive)
+	var inclusiveTax int64
+	for i, r := range rates {
+		taxable := net
+		if r.Compound {
+			taxable = net * (1 + prior[i])
+		}
+		line := tax.TaxLine{
+			BusinessUnit:  unit,
+			TaxRateID:     r.ID,
+			Code:          r.Code,
+			Name:          r.Name,
+			Rate:          r.Rate,
+			Inclusive:     r.Inclusive,
+			TaxableAmount: int64(math.Round(taxable)),
+			Amount:        int64(math.Round(net * factors[i])),
+		}
+		if line.Inclusive {
+			inclusiveTax += line.Amount
+		} else {
+			b.Exclusive += line.Amount
+		}
+		b.Tax += line.Amount
+		b.Lines = append(b.Lines, line)
+	}
+	// Net dari selisih supaya net + pajak inclusive selalu tepat sama dengan amount
+	b.Net = amount - inclusiveTax
+	b.Gross = amount + b.Exclusive
+	return b
+}
+
+func (s *taxService) Record(tx *gorm.DB, sourceType string, sourceID uint, lines []tax.TaxLine) error {
+	return s.repo.WithTx(tx).ReplaceLines(sourceType, sourceID, lines)
+}
+
+func (s *taxService) Report(start, end time.Time, unit string) (*tax.Report, error) {
+	if !end.After(start) {
+		return nil, errors.New("end harus setelah start")
+	}
+	if end.Sub(start).Hours()/24 > maxReportDays {
+		return nil, errors.New("rentang maksimal 366 hari")
+	}
+	if unit != "" && !validUnit(unit) {
+		return nil, errors.New("business_unit harus hotel, cafe, souvenir, atau book")
+	}
+
+	rows, err := s.repo.Summary(start, end, unit)
+	if err != nil {
+		return nil, err
+	}
+	report := &tax.Report{
+		Start: start.Format("2006-01-02"),
+		End:   end.Format("2006-01-02"),
+		Rows:  rows,
+	}
+	if report.Rows == nil {
+		report.Rows = []tax.ReportRow{}
+	}
+	for _, r := range rows {
+		report.Total += r.Amount
+	}
+	return report, nil
+}
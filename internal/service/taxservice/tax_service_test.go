@@ -0,0 +1,91 @@
+// internal/service/taxservice/tax_service_test.go
+package taxservice
+
+import (
+	"testing"
+
+	"backend/internal/models/tax"
+)
+
+func TestCompute(t *testing.T) {
+	service := tax.TaxRate{ID: 1, Code: "SC", Rate: 5, Priority: 0}
+	pb1 := tax.TaxRate{ID: 2, Code: "PB1", Rate: 10, Priority: 1}
+	incl := func(r tax.TaxRate) tax.TaxRate { r.Inclusive = true; return r }
+	compound := func(r tax.TaxRate) tax.TaxRate { r.Compound = true; return r }
+
+	tests := []struct {
+		name      string
+		amount    int64
+		rates     []tax.TaxRate
+		net       int64
+		exclusive int64
+		tax       int64
+		gross     int64
+		lines     []int64 // amount per baris
+		taxable   []int64
+	}{
+		{
+			name:   "exclusive only",
+			amount: 100000, rates: []tax.TaxRate{service, pb1},
+			net: 100000, exclusive: 15000, tax: 15000, gross: 115000,
+			lines: []int64{5000, 10000}, taxable: []int64{100000, 100000},
+		},
+		{
+			name:   "inclusive only",
+			amount: 110000, rates: []tax.TaxRate{incl(pb1)},
+			net: 100000, exclusive: 0, tax: 10000, gross: 110000,
+			lines: []int64{10000}, taxable: []int64{100000},
+		},
+		{
+			name:   "compound on top of prior rate",
+			amount: 100000, rates: []tax.TaxRate{service, compound(pb1)},
+			net: 100000, exclusive: 15500, tax: 15500, gross: 115500,
+			lines: []int64{5000, 10500}, taxable: []int64{100000, 105000},
+		},
+		{
+			name:   "mixed inclusive service, exclusive compound PB1",
+			amount: 105000, rates: []tax.TaxRate{incl(service), compound(pb1)},
+			net: 100000, exclusive: 10500, tax: 15500, gross: 115500,
+			lines: []int64{5000, 10500}, taxable: []int64{100000, 105000},
+		},
+		{
+			name:   "inclusive rounding",
+			amount: 10000, rates: []tax.TaxRate{incl(tax.TaxRate{ID: 3, Code: "VAT", Rate: 11})},
+			net: 9009, exclusive: 0, tax: 991, gross: 10000,
+			lines: []int64{991}, taxable: []int64{9009},
+		},
+		{
+			name:   "no rates",
+			amount: 50000,
+			net:    50000, gross: 50000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := Compute(tax.UnitHotel, tt.amount, tt.rates)
+			if b.Net != tt.net || b.Exclusive != tt.exclusive || b.Tax != tt.tax || b.Gross != tt.gross {
+				t.Fatalf("breakdown = net %d, exclusive %d, tax %d, gross %d; want %d, %d, %d, %d",
+					b.Net, b.Exclusive, b.Tax, b.Gross, tt.net, tt.exclusive, tt.tax, tt.gross)
+			}
+			if len(b.Lines) != len(tt.lines) {
+				t.Fatalf("got %d lines, want %d", len(b.Lines), len(tt.lines))
+			}
+			var inclusiveTax int64
+			for i, l := range b.Lines {
+				if l.Amount != tt.lines[i] || l.TaxableAmount != tt.taxable[i] {
+					t.Errorf("line %s = amount %d, taxable %d; want %d, %d", l.Code, l.Amount, l.TaxableAmount, tt.lines[i], tt.taxable[i])
+				}
+				if l.Inclusive {
+					inclusiveTax += l.Amount
+				}
+			}
+			if b.Net+inclusiveTax != tt.amount {
+				t.Errorf("net %d + inclusive tax %d != amount %d", b.Net, inclusiveTax, tt.amount)
+			}
+			if b.Gross != tt.amount+b.Exclusive {
+				t.Errorf("gross %d != amount %d + exclusive %d", b.Gross, tt.amount, b.Exclusive)
+			}
+		})
+	}
+}
@@ -2,9 +2,14 @@
 package utils
 
 import (
+	"bytes"
+	"encoding/base64"
 	"fmt"
 	"log"
+	"mime/multipart"
 	"net/smtp"
+	"net/textproto"
+	"time"
 )
 
 func SendApprovalPendingEmail(to, name string) {
@@ -33,8 +38,75 @@ func SendApprovalSuccessEmail(to, name string) {
 	sendEmail(from, to, subject, body)
 }
 
+// SendWaitlistOfferEmail: kabari guest di waitlist bahwa ada kamar yang bisa diklaim
+func SendWaitlistOfferEmail(to, name, roomType, checkIn, checkOut, claimURL string, expiresAt time.Time) error {
+	subject := "Kamar Tersedia dari Waitlist Anda"
+	body := fmt.Sprintf(`
+		<h2>Halo %s,</h2>
+		<p>Kamar <strong>%s</strong> untuk tanggal <strong>%s</strong> s/d <strong>%s</strong> kini tersedia.</p>
+		<p>Klaim sebelum <strong>%s</strong>, setelah itu kamar ditawarkan ke antrean berikutnya.</p>
+		<p>Kamar tidak ditahan untuk Anda selama masa klaim, jadi segera klaim sebelum dipesan tamu lain.</p>
+		<p><a href="%s" style="background:#000;color:#fff;padding:10px 20px;text-decoration:none;border-radius:8px;">Klaim Kamar</a></p>
+		<br>
+		<p>Terima kasih,<br>Tim Hotel Mutiara</p>
+	`, name, roomType, checkIn, checkOut, expiresAt.Format("02 Jan 2006 15:04"), claimURL)
+	return SendEmailWithAttachments(to, subject, body)
+}
+
+// Attachment: file yang dilampirkan ke email (misalnya invoice PDF)
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// SendEmailWithAttachments: email HTML dengan lampiran, error dikembalikan ke pemanggil
+func SendEmailWithAttachments(to, subject, body string, attachments ...Attachment) error {
+	from := "no-reply@hotelmutiara.com"
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	htmlPart, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {`text/html; charset="UTF-8"`},
+	})
+	if err != nil {
+		return err
+	}
+	htmlPart.Write([]byte(body))
+
+	for _, a := range attachments {
+		part, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {a.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {fmt.Sprintf(`attachment; filename="%s"`, a.Filename)},
+		})
+		if err != nil {
+			return err
+		}
+		encoded := base64.StdEncoding.EncodeToString(a.Data)
+		// baris base64 maksimal 76 karakter (RFC 2045)
+		for i := 0; i < len(encoded); i += 76 {
+			end := i + 76
+			if end > len(encoded) {
+				end = len(encoded)
+			}
+			part.Write([]byte(encoded[i:end] + "\r\n"))
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return err
+	}
+
+	msg := []byte("To: " + to + "\r\n" +
+		"From: " + from + "\r\n" +
+		"Subject: " + subject + "\r\n" +
+		"MIME-version: 1.0;\r\n" +
+		"Content-Type: multipart/mixed; boundary=\"" + mw.Boundary() + "\"\r\n\r\n")
+	return deliver(from, to, append(msg, buf.Bytes()...))
+}
+
 func sendEmail(from, to, subject, body string) {
-	auth := smtp.PlainAuth("", "YOUR_GMAIL@gmail.com", "YOUR_APP_PASSWORD", "smtp.gmail.com")
 	msg := []byte("To: " + to + "\r\n" +
 		"From: " + from + "\r\n" +
 		"Subject: " + subject + "\r\n" +
@@ -42,8 +114,12 @@ func sendEmail(from, to, subject, body string) {
 		"Content-Type: text/html; charset=\"UTF-8\";\r\n\r\n" +
 		body)
 
-	err := smtp.SendMail("smtp.gmail.com:587", auth, from, []string{to}, msg)
-	if err != nil {
+	if err := deliver(from, to, msg); err != nil {
 		log.Println("Email gagal:", err)
 	}
+}
+
+func deliver(from, to string, msg []byte) error {
+	auth := smtp.PlainAuth("", "YOUR_GMAIL@gmail.com", "YOUR_APP_PASSWORD", "smtp.gmail.com")
+	return smtp.SendMail("smtp.gmail.com:587", auth, from, []string{to}, msg)
 }
\ No newline at end of file